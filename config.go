@@ -1,7 +1,83 @@
 package main
 
+import "time"
+
 type config struct {
 	Host     string `envconfig:"HOST" default:"0.0.0.0"`
 	Port     int    `envconfig:"PORT" default:"3000"`
 	LogLevel string `envconfig:"LOG_LEVEL" default:"INFO"`
+
+	// FeeBatchWorkers is the number of items of a POST /fee/batch request that are calculated at a time, and
+	// FeeBatchMaxItems the largest batch that is accepted.
+	FeeBatchWorkers  int `envconfig:"FEE_BATCH_WORKERS" default:"8"`
+	FeeBatchMaxItems int `envconfig:"FEE_BATCH_MAX_ITEMS" default:"10000"`
+
+	// BillingTimezone is the IANA time zone all entry times are converted to before pricing.
+	BillingTimezone string `envconfig:"BILLING_TIMEZONE" default:"Europe/Stockholm"`
+
+	// TariffFile is the path to a YAML or JSON tariff document. The hardcoded tariff is used when empty.
+	TariffFile string `envconfig:"TARIFF_FILE"`
+
+	// ZonesFile is the path to a YAML zones document mapping gantries to tariff zones. Fees by zone are only
+	// available when set.
+	ZonesFile string `envconfig:"ZONES_FILE"`
+
+	// GantryFile is the path to the JSON file of the gantry registry, which is managed through the admin API.
+	// When set, gantries are resolved to zones through the registry instead of the zones document.
+	GantryFile string `envconfig:"GANTRY_FILE"`
+
+	// VehicleListFile is the path to a YAML or JSON vehicles document listing the known vehicle types. If not set,
+	// the hardcoded vehicle types are used. It is reloaded together with the tariffs.
+	VehicleListFile string `envconfig:"VEHICLE_LIST_FILE"`
+
+	// VehicleRegistryFile is the path to a JSON file of vehicle registrations. Fees by registration number are
+	// only available when it is set. It is reloaded together with the tariffs.
+	VehicleRegistryFile string `envconfig:"VEHICLE_REGISTRY_FILE"`
+
+	// ExemptionFile is the path to the audit trail of exemptions granted to individual vehicles through the admin
+	// API. Exemptions apply to fees by registration number.
+	ExemptionFile string `envconfig:"EXEMPTION_FILE"`
+
+	// PassageLogDir is the directory of the passage log that POST /passages appends to. Passage ingestion is
+	// only available when it is set. A new segment file is started once the current one reaches
+	// PassageLogSegmentSize bytes. Resent events are recognized as duplicates for PassageLogDedupWindow after they
+	// were received.
+	PassageLogDir         string        `envconfig:"PASSAGE_LOG_DIR"`
+	PassageLogSegmentSize int64         `envconfig:"PASSAGE_LOG_SEGMENT_SIZE" default:"67108864"`
+	PassageLogDedupWindow time.Duration `envconfig:"PASSAGE_LOG_DEDUP_WINDOW" default:"24h"`
+
+	// DisputeFile is the path to the audit trail of disputes on stored passages, which requires PassageLogDir.
+	// Passages of accepted disputes are excluded from invoices.
+	DisputeFile string `envconfig:"DISPUTE_FILE"`
+
+	// InvoiceFile is the path to the file of issued invoices. Invoicing is only available when it is set, which
+	// requires PassageLogDir and VehicleRegistryFile. Invoices are due InvoicePaymentDays days after they are issued.
+	InvoiceFile        string `envconfig:"INVOICE_FILE"`
+	InvoicePaymentDays int    `envconfig:"INVOICE_PAYMENT_DAYS" default:"30"`
+
+	// InvoiceReceivableAccount and InvoiceRevenueAccount are the accounts invoices are booked on in SIE exports.
+	InvoiceReceivableAccount string `envconfig:"INVOICE_RECEIVABLE_ACCOUNT" default:"1510"`
+	InvoiceRevenueAccount    string `envconfig:"INVOICE_REVENUE_ACCOUNT" default:"3000"`
+
+	// HolidaySource selects where public holidays come from: "dagsmart", "offline" or "dagsmart-with-fallback",
+	// which uses the offline calendar when dagsmart is unavailable and to cross-check its responses.
+	HolidaySource string `envconfig:"HOLIDAY_SOURCE" default:"dagsmart"`
+
+	// HolidayCacheTTL is how long fetched holidays are cached before they are refreshed. Zero caches them forever.
+	HolidayCacheTTL time.Duration `envconfig:"HOLIDAY_CACHE_TTL" default:"24h"`
+
+	// HolidayCacheDir is a directory where fetched holidays are persisted across restarts. Holidays are only
+	// cached in memory when empty.
+	HolidayCacheDir string `envconfig:"HOLIDAY_CACHE_DIR"`
+
+	// Dagsmart* settings control the dagsmart integration. DagsmartURL can point at a local stub for testing.
+	// DagsmartMaxRetries of zero disables retries; a negative number is rejected on startup.
+	DagsmartURL              string        `envconfig:"DAGSMART_URL" default:"https://api.dagsmart.se"`
+	DagsmartTimeout          time.Duration `envconfig:"DAGSMART_TIMEOUT" default:"5s"`
+	DagsmartMaxRetries       int           `envconfig:"DAGSMART_MAX_RETRIES" default:"3"`
+	DagsmartBreakerThreshold int           `envconfig:"DAGSMART_BREAKER_THRESHOLD" default:"5"`
+	DagsmartBreakerCooldown  time.Duration `envconfig:"DAGSMART_BREAKER_COOLDOWN" default:"30s"`
+
+	// AdminToken is the bearer token required by the admin endpoints. Admin endpoints reject all requests when empty.
+	AdminToken string `envconfig:"ADMIN_TOKEN"`
 }
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// Reloader reloads the service configuration.
+type Reloader interface {
+	Reload() error
+}
+
+// RequireAdminToken only lets requests through that carry the admin token as a bearer token. All requests
+// are rejected when no token is configured.
+func RequireAdminToken(token string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		provided, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if token == "" || !ok || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+// ReloadHandler triggers a configuration reload. The previous configuration stays in place if the reload fails.
+func ReloadHandler(reloader Reloader) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "invalid method", http.StatusMethodNotAllowed)
+			return
+		}
+
+		if err := reloader.Reload(); err != nil {
+			http.Error(w, "reload failed, previous configuration is kept", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, err := w.Write([]byte(`{"status":"reloaded"}`))
+		if err != nil {
+			slog.ErrorContext(r.Context(), "failed to write reload response", "error", err)
+		}
+	}
+}
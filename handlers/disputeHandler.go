@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"afry-toll-calculator/services/dispute"
+	"afry-toll-calculator/services/passagelog"
+)
+
+type OpenDisputeRequest struct {
+	// Passage is the sequence number of the passage in the passage log.
+	Passage uint64 `json:"passage"`
+	Claim   string `json:"claim"`
+}
+
+type DisputeEvidenceRequest struct {
+	Ref         string `json:"ref"`
+	Description string `json:"description"`
+}
+
+type TransitionDisputeRequest struct {
+	State      string `json:"state"`
+	Resolution string `json:"resolution"`
+}
+
+// ListDisputesHandler responds with the disputes of the registration number in the registration query parameter,
+// or with all disputes if it is not set.
+func ListDisputesHandler(disputes dispute.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, r, http.StatusOK, disputes.List(r.URL.Query().Get("registration")))
+	}
+}
+
+// GetDisputeHandler responds with the dispute with the ID in the path.
+func GetDisputeHandler(disputes dispute.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		d, err := disputes.Get(r.PathValue("id"))
+		if err != nil {
+			writeDisputeError(w, r, err)
+			return
+		}
+
+		writeJSON(w, r, http.StatusOK, d)
+	}
+}
+
+// DisputeAuditHandler responds with the audit trail of the dispute with the ID in the path.
+func DisputeAuditHandler(disputes dispute.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue("id")
+		if _, err := disputes.Get(id); err != nil {
+			writeDisputeError(w, r, err)
+			return
+		}
+
+		writeJSON(w, r, http.StatusOK, disputes.Audit(id))
+	}
+}
+
+// OpenDisputeHandler opens a dispute on a stored passage on behalf of the actor in ActorHeader. A passage can only
+// have one dispute that has not been rejected (409).
+func OpenDisputeHandler(disputes dispute.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		actor := r.Header.Get(ActorHeader)
+		if actor == "" {
+			http.Error(w, "missing "+ActorHeader+" header", http.StatusBadRequest)
+			return
+		}
+
+		var req OpenDisputeRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		opened, err := disputes.Open(req.Passage, req.Claim, actor)
+		if err != nil {
+			writeDisputeError(w, r, err)
+			return
+		}
+
+		slog.InfoContext(r.Context(), "dispute opened",
+			"id", opened.ID, "passage", opened.Passage, "registration", opened.Registration, "actor", actor)
+		writeJSON(w, r, http.StatusCreated, opened)
+	}
+}
+
+// AddDisputeEvidenceHandler attaches evidence to the dispute with the ID in the path on behalf of the actor in
+// ActorHeader. Resolved disputes do not take evidence (409).
+func AddDisputeEvidenceHandler(disputes dispute.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		actor := r.Header.Get(ActorHeader)
+		if actor == "" {
+			http.Error(w, "missing "+ActorHeader+" header", http.StatusBadRequest)
+			return
+		}
+
+		var req DisputeEvidenceRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		updated, err := disputes.AddEvidence(r.PathValue("id"),
+			dispute.Evidence{Ref: req.Ref, Description: req.Description}, actor)
+		if err != nil {
+			writeDisputeError(w, r, err)
+			return
+		}
+
+		slog.InfoContext(r.Context(), "dispute evidence added", "id", updated.ID, "ref", req.Ref, "actor", actor)
+		writeJSON(w, r, http.StatusOK, updated)
+	}
+}
+
+// TransitionDisputeHandler moves the dispute with the ID in the path to another state on behalf of the actor in
+// ActorHeader. Transitions the state machine does not allow are rejected (409). If an accepted dispute cannot be
+// recalculated, the dispute stays accepted and the error is reported (500).
+func TransitionDisputeHandler(disputes dispute.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		actor := r.Header.Get(ActorHeader)
+		if actor == "" {
+			http.Error(w, "missing "+ActorHeader+" header", http.StatusBadRequest)
+			return
+		}
+
+		var req TransitionDisputeRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		updated, err := disputes.Transition(r.PathValue("id"), req.State, req.Resolution, actor)
+		if errors.Is(err, dispute.ErrRecalculation) {
+			slog.ErrorContext(r.Context(), "failed to recalculate accepted dispute", "id", updated.ID,
+				"registration", updated.Registration, "error", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if err != nil {
+			writeDisputeError(w, r, err)
+			return
+		}
+
+		slog.InfoContext(r.Context(), "dispute transitioned",
+			"id", updated.ID, "state", updated.State, "notes", updated.Notes, "actor", actor)
+		writeJSON(w, r, http.StatusOK, updated)
+	}
+}
+
+func writeDisputeError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, dispute.ErrInvalid):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, dispute.ErrNotFound), errors.Is(err, passagelog.ErrNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, dispute.ErrAlreadyDisputed), errors.Is(err, dispute.ErrInvalidTransition):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		slog.ErrorContext(r.Context(), "failed to store dispute", "error", err)
+		http.Error(w, "failed to store dispute", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"afry-toll-calculator/services/exemption"
+)
+
+// ActorHeader names the person or system making a change through the admin API, which is recorded in audit
+// trails. The admin token is shared, so it does not identify the actor by itself.
+const ActorHeader = "X-Admin-Actor"
+
+type GrantExemptionRequest struct {
+	Registration string    `json:"registration"`
+	Reason       string    `json:"reason"`
+	ValidFrom    time.Time `json:"validFrom"`
+	ValidTo      time.Time `json:"validTo"`
+}
+
+type RevokeExemptionRequest struct {
+	// At is the time the exemption ends. Defaults to the time of the request.
+	At time.Time `json:"at"`
+}
+
+// ListExemptionsHandler responds with the exemptions of the registration number in the registration query
+// parameter, or with all exemptions if it is not set.
+func ListExemptionsHandler(store exemption.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, r, http.StatusOK, store.List(r.URL.Query().Get("registration")))
+	}
+}
+
+// ExemptionAuditHandler responds with the audit trail of the registration number in the registration query
+// parameter, or of all exemptions if it is not set.
+func ExemptionAuditHandler(store exemption.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, r, http.StatusOK, store.Audit(r.URL.Query().Get("registration")))
+	}
+}
+
+// GrantExemptionHandler grants an exemption to an individual vehicle on behalf of the actor in ActorHeader.
+func GrantExemptionHandler(store exemption.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		actor := r.Header.Get(ActorHeader)
+		if actor == "" {
+			http.Error(w, "missing "+ActorHeader+" header", http.StatusBadRequest)
+			return
+		}
+
+		var req GrantExemptionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		granted, err := store.Grant(exemption.Exemption{
+			Registration: req.Registration,
+			Reason:       req.Reason,
+			ValidFrom:    req.ValidFrom,
+			ValidTo:      req.ValidTo,
+		}, actor)
+		if err != nil {
+			writeExemptionError(w, r, err)
+			return
+		}
+
+		slog.InfoContext(r.Context(), "exemption granted",
+			"id", granted.ID, "registration", granted.Registration, "reason", granted.Reason, "actor", actor)
+		writeJSON(w, r, http.StatusCreated, granted)
+	}
+}
+
+// RevokeExemptionHandler ends the exemption with the ID in the path on behalf of the actor in ActorHeader. The
+// request body is optional.
+func RevokeExemptionHandler(store exemption.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		actor := r.Header.Get(ActorHeader)
+		if actor == "" {
+			http.Error(w, "missing "+ActorHeader+" header", http.StatusBadRequest)
+			return
+		}
+
+		var req RevokeExemptionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		if req.At.IsZero() {
+			req.At = time.Now()
+		}
+
+		revoked, err := store.Revoke(r.PathValue("id"), req.At, actor)
+		if err != nil {
+			writeExemptionError(w, r, err)
+			return
+		}
+
+		slog.InfoContext(r.Context(), "exemption revoked",
+			"id", revoked.ID, "registration", revoked.Registration, "actor", actor)
+		writeJSON(w, r, http.StatusOK, revoked)
+	}
+}
+
+func writeExemptionError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, exemption.ErrInvalid):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, exemption.ErrNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		slog.ErrorContext(r.Context(), "failed to store exemption", "error", err)
+		http.Error(w, "failed to store exemption", http.StatusInternalServerError)
+	}
+}
@@ -3,12 +3,14 @@ package handlers
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"time"
 
 	"afry-toll-calculator/metrics"
 	"afry-toll-calculator/models"
+	"afry-toll-calculator/services/batch"
 	"afry-toll-calculator/services/fee"
 )
 
@@ -20,12 +22,14 @@ type FeeRequest struct {
 func GetFeeHandler(feeService fee.Service) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var (
-			err        error
-			feeRequest FeeRequest
-			fee        = 0
+			err         error
+			feeRequest  FeeRequest
+			explanation fee.Explanation
+			fee         = 0
 		)
 		defer func() {
 			metrics.RecordFeeCalculation(feeRequest.VehicleType, fee, err)
+			metrics.RecordCollapsedPassages(explanation.Collapsed)
 		}()
 
 		if r.Method != http.MethodPost {
@@ -57,7 +61,15 @@ func GetFeeHandler(feeService fee.Service) http.HandlerFunc {
 			return
 		}
 
-		fee, err = feeService.GetFee(models.VehicleType(feeRequest.VehicleType), feeRequest.Timestamps)
+		// The explanation is always calculated, as it holds the number of collapsed passages for the metrics.
+		explanation, err = feeService.Explain(models.VehicleType(feeRequest.VehicleType), feeRequest.Timestamps)
+		fee = explanation.Fee
+		var response interface{} = explanation
+		if r.URL.Query().Get("explain") != "true" {
+			response = map[string]interface{}{
+				"fee": fee,
+			}
+		}
 		if err != nil {
 			http.Error(w, "fee calculation failed", http.StatusInternalServerError)
 			return
@@ -65,11 +77,124 @@ func GetFeeHandler(feeService fee.Service) http.HandlerFunc {
 
 		// Send response
 		w.Header().Set("Content-Type", "application/json")
-		err = json.NewEncoder(w).Encode(map[string]interface{}{
-			"fee": fee,
-		})
+		err = json.NewEncoder(w).Encode(response)
 		if err != nil {
 			slog.ErrorContext(r.Context(), "failed to encode response", "error", err)
 		}
 	}
 }
+
+// BatchFeeItem is a FeeRequest with an ID chosen by the client, which identifies its result.
+type BatchFeeItem struct {
+	ID string `json:"id"`
+	FeeRequest
+}
+
+// BatchFeeResult holds either the fee of an item or the reason it failed.
+type BatchFeeResult struct {
+	ID    string `json:"id"`
+	Fee   *int   `json:"fee,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+type BatchFeeResponse struct {
+	Results []BatchFeeResult `json:"results"`
+	Failed  int              `json:"failed"`
+}
+
+// batchItemMaxBytes is the request body size allowed per item of a fee batch, which leaves room for about a hundred
+// timestamps per item.
+const batchItemMaxBytes = 4 << 10
+
+// GetBatchFeesHandler calculates the fees of an array of independent items, each like a request to /fee, and
+// responds with a result per item in the order of the request. Items that fail are reported in their result
+// without failing the batch. Batches of more than maxItems items, or bodies larger than batchItemMaxBytes per
+// allowed item, are rejected (413). The items are decoded one at a time, so an oversized batch is rejected without
+// reading it completely.
+func GetBatchFeesHandler(batchService batch.Service, maxItems int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			erri := r.Body.Close()
+			if erri != nil {
+				slog.ErrorContext(r.Context(), "failed to close request body", "error", erri)
+			}
+		}()
+
+		decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, int64(maxItems)*batchItemMaxBytes))
+		req, err := decodeBatch(decoder, maxItems)
+		var tooLarge *http.MaxBytesError
+		switch {
+		case errors.As(err, &tooLarge):
+			http.Error(w, fmt.Sprintf("request body exceeds %d bytes", tooLarge.Limit), http.StatusRequestEntityTooLarge)
+			return
+		case errors.Is(err, errBatchTooLarge):
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		case err != nil:
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		if len(req) == 0 {
+			http.Error(w, "missing items", http.StatusBadRequest)
+			return
+		}
+
+		items := make([]batch.Item, len(req))
+		for i, item := range req {
+			items[i] = batch.Item{
+				ID:          item.ID,
+				VehicleType: models.VehicleType(item.VehicleType),
+				Timestamps:  item.Timestamps,
+			}
+		}
+
+		response := BatchFeeResponse{Results: make([]BatchFeeResult, 0, len(items))}
+		for _, result := range batchService.Calculate(r.Context(), items) {
+			if result.Err == nil {
+				response.Results = append(response.Results, BatchFeeResult{ID: result.ID, Fee: &result.Fee})
+				continue
+			}
+
+			response.Failed++
+			message := result.Err.Error()
+			if !errors.Is(result.Err, batch.ErrInvalid) && !errors.Is(result.Err, fee.ErrUnknownVehicleType) &&
+				!errors.Is(result.Err, fee.ErrMultipleDays) && r.Context().Err() == nil {
+				slog.ErrorContext(r.Context(), "batch fee calculation failed", "id", result.ID, "error", result.Err)
+				message = "fee calculation failed"
+			}
+			response.Results = append(response.Results, BatchFeeResult{ID: result.ID, Error: message})
+		}
+
+		writeJSON(w, r, http.StatusOK, response)
+	}
+}
+
+var errBatchTooLarge = errors.New("batch exceeds the maximum number of items")
+
+// decodeBatch decodes a JSON array of batch items, and stops with errBatchTooLarge as soon as it holds more than
+// maxItems items.
+func decodeBatch(decoder *json.Decoder, maxItems int) ([]BatchFeeItem, error) {
+	if token, err := decoder.Token(); err != nil {
+		return nil, err
+	} else if token != json.Delim('[') {
+		return nil, errors.New("expected an array of items")
+	}
+
+	items := []BatchFeeItem{}
+	for decoder.More() {
+		if len(items) == maxItems {
+			return nil, fmt.Errorf("%w (%d)", errBatchTooLarge, maxItems)
+		}
+
+		var item BatchFeeItem
+		if err := decoder.Decode(&item); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"afry-toll-calculator/metrics"
+	"afry-toll-calculator/models"
+	"afry-toll-calculator/services/fee"
+)
+
+// GetFeesHandler calculates fees for entry times spanning any number of days and responds with the
+// total and a per-day breakdown.
+func GetFeesHandler(feeService fee.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var (
+			err        error
+			feeRequest FeeRequest
+			summary    fee.Summary
+		)
+		defer func() {
+			metrics.RecordFeeCalculation(feeRequest.VehicleType, summary.Total, err)
+			metrics.RecordCollapsedPassages(summary.Collapsed)
+		}()
+
+		if r.Method != http.MethodPost {
+			http.Error(w, "invalid method", http.StatusMethodNotAllowed)
+			return
+		}
+
+		err = json.NewDecoder(r.Body).Decode(&feeRequest)
+		if err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		defer func() {
+			erri := r.Body.Close()
+			if erri != nil {
+				slog.ErrorContext(r.Context(), "failed to close request body", "error", erri)
+			}
+		}()
+
+		// Validate
+		if feeRequest.VehicleType == "" {
+			err = errors.New("missing vehicle type")
+			http.Error(w, "missing vehicle type", http.StatusBadRequest)
+			return
+		}
+		if len(feeRequest.Timestamps) == 0 {
+			err = errors.New("missing timestamps array")
+			http.Error(w, "missing timestamps array", http.StatusBadRequest)
+			return
+		}
+
+		summary, err = feeService.GetFees(models.VehicleType(feeRequest.VehicleType), feeRequest.Timestamps)
+		if err != nil {
+			http.Error(w, "fee calculation failed", http.StatusInternalServerError)
+			return
+		}
+
+		// Send response
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(summary)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "failed to encode response", "error", err)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"afry-toll-calculator/services/gantry"
+)
+
+// ZoneChecker reports whether a tariff zone is configured.
+type ZoneChecker interface {
+	HasZone(name string) bool
+}
+
+type DeactivateGantryRequest struct {
+	// At is the time the gantry stops registering passages. Defaults to the time of the request.
+	At time.Time `json:"at"`
+}
+
+// ListGantriesHandler responds with all gantries, including inactive ones.
+func ListGantriesHandler(store gantry.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		gantries, err := store.List()
+		if err != nil {
+			slog.ErrorContext(r.Context(), "failed to list gantries", "error", err)
+			http.Error(w, "failed to list gantries", http.StatusInternalServerError)
+			return
+		}
+
+		writeJSON(w, r, http.StatusOK, gantries)
+	}
+}
+
+// CreateGantryHandler registers a new gantry. If zones is not nil, the gantry must belong to a configured zone.
+func CreateGantryHandler(store gantry.Store, zones ZoneChecker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var g gantry.Gantry
+		if err := json.NewDecoder(r.Body).Decode(&g); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		if zones != nil && !zones.HasZone(g.Zone) {
+			http.Error(w, fmt.Sprintf("unknown zone %q", g.Zone), http.StatusBadRequest)
+			return
+		}
+
+		if err := store.Create(g); err != nil {
+			writeGantryError(w, r, err)
+			return
+		}
+
+		writeJSON(w, r, http.StatusCreated, g)
+	}
+}
+
+// UpdateGantryHandler replaces the gantry with the ID in the path. If zones is not nil, the gantry must belong
+// to a configured zone.
+func UpdateGantryHandler(store gantry.Store, zones ZoneChecker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var g gantry.Gantry
+		if err := json.NewDecoder(r.Body).Decode(&g); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		if g.ID == "" {
+			g.ID = r.PathValue("id")
+		}
+		if g.ID != r.PathValue("id") {
+			http.Error(w, "gantry id does not match the path", http.StatusBadRequest)
+			return
+		}
+		if zones != nil && !zones.HasZone(g.Zone) {
+			http.Error(w, fmt.Sprintf("unknown zone %q", g.Zone), http.StatusBadRequest)
+			return
+		}
+
+		if err := store.Update(g); err != nil {
+			writeGantryError(w, r, err)
+			return
+		}
+
+		writeJSON(w, r, http.StatusOK, g)
+	}
+}
+
+// DeactivateGantryHandler ends the active period of the gantry with the ID in the path. The request body is
+// optional.
+func DeactivateGantryHandler(store gantry.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req DeactivateGantryRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		if req.At.IsZero() {
+			req.At = time.Now()
+		}
+
+		g, err := store.Deactivate(r.PathValue("id"), req.At)
+		if err != nil {
+			writeGantryError(w, r, err)
+			return
+		}
+
+		writeJSON(w, r, http.StatusOK, g)
+	}
+}
+
+func writeGantryError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, gantry.ErrInvalid):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, gantry.ErrNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, gantry.ErrAlreadyExists):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		slog.ErrorContext(r.Context(), "failed to store gantry", "error", err)
+		http.Error(w, "failed to store gantry", http.StatusInternalServerError)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, r *http.Request, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode response", "error", err)
+	}
+}
@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"afry-toll-calculator/services/fee"
+	"afry-toll-calculator/services/invoice"
+	"afry-toll-calculator/services/zone"
+)
+
+type GenerateInvoiceRequest struct {
+	Owner string `json:"owner"`
+	// Period is the billing month, such as 2025-03.
+	Period string `json:"period"`
+}
+
+// GenerateInvoiceHandler issues the invoice of an owner for a billing period from the stored passages. An owner
+// can only be invoiced once per period (409), and periods without passages of the owner are not invoiced (422).
+func GenerateInvoiceHandler(invoices invoice.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req GenerateInvoiceRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		inv, err := invoices.Generate(req.Owner, req.Period)
+		switch {
+		case errors.Is(err, invoice.ErrInvalid):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		case errors.Is(err, invoice.ErrAlreadyInvoiced), errors.Is(err, invoice.ErrTariffChanged):
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		case errors.Is(err, invoice.ErrNoPassages), errors.Is(err, fee.ErrUnknownVehicleType),
+			errors.Is(err, zone.ErrUnknownGantry):
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		case err != nil:
+			slog.ErrorContext(r.Context(), "failed to generate invoice", "owner", req.Owner, "period", req.Period, "error", err)
+			http.Error(w, "failed to generate invoice", http.StatusInternalServerError)
+			return
+		}
+
+		slog.InfoContext(r.Context(), "invoice issued",
+			"number", inv.Number, "owner", inv.Owner, "period", inv.Period, "total", inv.Total)
+		writeJSON(w, r, http.StatusCreated, inv)
+	}
+}
+
+// GetInvoiceHandler responds with the invoice with the number in the path, as JSON or in the format of the format
+// query parameter: pdf, csv or sie. All formats are rendered from the same invoice, so their amounts agree. SIE
+// exports are booked on accounts.
+func GetInvoiceHandler(invoices invoice.Service, accounts invoice.SIEAccounts) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		number, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil {
+			http.Error(w, "invoice not found", http.StatusNotFound)
+			return
+		}
+
+		var (
+			render      func(io.Writer, invoice.Invoice) error
+			contentType string
+			extension   string
+		)
+		switch format := r.URL.Query().Get("format"); format {
+		case "", "json":
+		case "pdf":
+			render, contentType, extension = invoice.WritePDF, "application/pdf", "pdf"
+		case "csv":
+			render, contentType, extension = invoice.WriteCSV, "text/csv; charset=utf-8", "csv"
+		case "sie":
+			render = func(w io.Writer, inv invoice.Invoice) error {
+				return invoice.WriteSIE(w, inv, accounts)
+			}
+			contentType, extension = "text/plain; charset=IBM437", "se"
+		default:
+			http.Error(w, fmt.Sprintf("unknown format %q", format), http.StatusBadRequest)
+			return
+		}
+
+		inv, err := invoices.Get(number)
+		if errors.Is(err, invoice.ErrNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			slog.ErrorContext(r.Context(), "failed to get invoice", "number", number, "error", err)
+			http.Error(w, "failed to get invoice", http.StatusInternalServerError)
+			return
+		}
+
+		if render == nil {
+			writeJSON(w, r, http.StatusOK, inv)
+			return
+		}
+
+		// Render completely before responding, so a failure can still be answered with an error status.
+		var buf bytes.Buffer
+		if err := render(&buf, inv); err != nil {
+			slog.ErrorContext(r.Context(), "failed to render invoice", "number", number, "error", err)
+			http.Error(w, "failed to render invoice", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"invoice-%d.%s\"", inv.Number, extension))
+		w.WriteHeader(http.StatusOK)
+		if _, err := buf.WriteTo(w); err != nil {
+			slog.ErrorContext(r.Context(), "failed to write invoice", "number", number, "error", err)
+		}
+	}
+}
+
+type RecalculateRequest struct {
+	Registration string `json:"registration"`
+	// From and To are the first and the last billing day to recalculate, such as 2025-03-01.
+	From string `json:"from"`
+	To   string `json:"to"`
+	// Reason is the reason code of the issued notes, such as dispute_accepted.
+	Reason string `json:"reason"`
+	DryRun bool   `json:"dryRun"`
+}
+
+// RecalculateHandler recalculates the fees of a vehicle on a range of days with the current data and issues credit
+// and debit notes for the differences to the invoiced amounts (201). A dry run, or a recalculation without
+// differences, only reports the days (200).
+func RecalculateHandler(invoices invoice.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req RecalculateRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		result, err := invoices.Recalculate(invoice.RecalculationRequest{
+			Registration: req.Registration,
+			From:         req.From,
+			To:           req.To,
+			Reason:       req.Reason,
+			DryRun:       req.DryRun,
+		})
+		switch {
+		case errors.Is(err, invoice.ErrInvalid):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		case errors.Is(err, invoice.ErrTariffChanged):
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		case errors.Is(err, fee.ErrUnknownVehicleType), errors.Is(err, zone.ErrUnknownGantry):
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		case err != nil:
+			slog.ErrorContext(r.Context(), "failed to recalculate", "registration", req.Registration,
+				"from", req.From, "to", req.To, "error", err)
+			http.Error(w, "failed to recalculate", http.StatusInternalServerError)
+			return
+		}
+
+		if len(result.Notes) == 0 {
+			writeJSON(w, r, http.StatusOK, result)
+			return
+		}
+		for _, note := range result.Notes {
+			slog.InfoContext(r.Context(), "invoice note issued", "number", note.Number, "kind", note.Kind,
+				"corrects", note.Corrects, "reason", note.Reason, "total", note.Total)
+		}
+		writeJSON(w, r, http.StatusCreated, result)
+	}
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"afry-toll-calculator/metrics"
+	"afry-toll-calculator/services/passagelog"
+)
+
+// GantryResolver resolves a gantry to the zone it belongs to at a given time.
+type GantryResolver interface {
+	Zone(gantry string, at time.Time) (string, bool)
+}
+
+type PassageRequest struct {
+	Registration string    `json:"registration"`
+	Gantry       string    `json:"gantry"`
+	Timestamp    time.Time `json:"timestamp"`
+	Confidence   float64   `json:"confidence"`
+	ImageRef     string    `json:"imageRef"`
+}
+
+type PassageResponse struct {
+	passagelog.Event
+	// Duplicate is set if the passage had already been received, in which case the stored event is returned.
+	Duplicate bool `json:"duplicate"`
+}
+
+// IngestPassageHandler validates a passage event from a gantry camera and appends it to the passage log. New
+// passages are answered with 201 and passages that were already received with 200, so cameras can safely
+// resend events. Invalid passages are rejected with 400 and, if gantries is not nil, passages at unknown or inactive
+// gantries with 422.
+func IngestPassageHandler(log passagelog.Log, gantries GantryResolver) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req PassageRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			metrics.RecordPassageIngested("rejected")
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		passage := passagelog.Event{
+			Registration: req.Registration,
+			Gantry:       req.Gantry,
+			Timestamp:    req.Timestamp,
+			Confidence:   req.Confidence,
+			ImageRef:     req.ImageRef,
+		}
+		if err := passage.Validate(); err != nil {
+			metrics.RecordPassageIngested("rejected")
+			http.Error(w, fmt.Sprintf("%v: %v", passagelog.ErrInvalid, err), http.StatusBadRequest)
+			return
+		}
+		if gantries != nil {
+			if _, ok := gantries.Zone(req.Gantry, req.Timestamp); !ok {
+				metrics.RecordPassageIngested("rejected")
+				http.Error(w, fmt.Sprintf("unknown gantry %q", req.Gantry), http.StatusUnprocessableEntity)
+				return
+			}
+		}
+
+		event, duplicate, err := log.Append(passage)
+		switch {
+		case errors.Is(err, passagelog.ErrInvalid):
+			metrics.RecordPassageIngested("rejected")
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		case err != nil:
+			metrics.RecordPassageIngested("error")
+			slog.ErrorContext(r.Context(), "failed to store passage", "error", err)
+			http.Error(w, "failed to store passage", http.StatusInternalServerError)
+			return
+		}
+
+		status := http.StatusCreated
+		if duplicate {
+			metrics.RecordPassageIngested("duplicate")
+			status = http.StatusOK
+		} else {
+			metrics.RecordPassageIngested("accepted")
+		}
+		writeJSON(w, r, status, PassageResponse{Event: event, Duplicate: duplicate})
+	}
+}
@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"afry-toll-calculator/metrics"
+	"afry-toll-calculator/models"
+	"afry-toll-calculator/services/exemption"
+	"afry-toll-calculator/services/fee"
+	"afry-toll-calculator/services/vehicleregistry"
+)
+
+type RegistrationFeeRequest struct {
+	Registration string      `json:"registration"`
+	Timestamps   []time.Time `json:"timestamps"`
+}
+
+type RegistrationFeeResponse struct {
+	Registration string `json:"registration"`
+	VehicleType  string `json:"vehicleType"`
+	Fee          int    `json:"fee"`
+	// Explanation is only set if the request asked for it with explain=true.
+	Explanation *fee.Explanation `json:"explanation,omitempty"`
+}
+
+// GetRegistrationFeeHandler calculates the fee for a single day of entry times of a registered vehicle. The
+// vehicle type is resolved from the registry, so unknown registration numbers (404) are reported separately
+// from registrations of a vehicle type the tariff does not know (422). If exemptions is not nil, the exemptions
+// of the individual vehicle are applied. The response does not reveal the owner of the registration, since the
+// endpoint requires no authentication.
+func GetRegistrationFeeHandler(
+	registry vehicleregistry.Store,
+	exemptions exemption.Store,
+	feeService fee.Service,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var (
+			err          error
+			feeRequest   RegistrationFeeRequest
+			registration vehicleregistry.Registration
+			explanation  fee.Explanation
+		)
+		defer func() {
+			metrics.RecordFeeCalculation(string(registration.VehicleType), explanation.Fee, err)
+			metrics.RecordCollapsedPassages(explanation.Collapsed)
+		}()
+
+		err = json.NewDecoder(r.Body).Decode(&feeRequest)
+		if err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		defer func() {
+			erri := r.Body.Close()
+			if erri != nil {
+				slog.ErrorContext(r.Context(), "failed to close request body", "error", erri)
+			}
+		}()
+
+		// Validate
+		if feeRequest.Registration == "" {
+			err = errors.New("missing registration number")
+			http.Error(w, "missing registration number", http.StatusBadRequest)
+			return
+		}
+		if len(feeRequest.Timestamps) == 0 {
+			err = errors.New("missing timestamps array")
+			http.Error(w, "missing timestamps array", http.StatusBadRequest)
+			return
+		}
+
+		registration, err = lookupRegistration(registry, feeRequest.Registration, feeRequest.Timestamps)
+		switch {
+		case errors.Is(err, vehicleregistry.ErrUnknownRegistration):
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+
+		var vehicle models.Vehicle = registration
+		if exemptions != nil {
+			vehicle = exemption.WithExemptions(registration, exemptions.List(registration.Number))
+		}
+
+		explanation, err = feeService.ExplainVehicle(vehicle, feeRequest.Timestamps)
+		switch {
+		case errors.Is(err, fee.ErrUnknownVehicleType):
+			http.Error(w, fmt.Sprintf("registration %q has unknown vehicle type %q",
+				registration.Number, registration.VehicleType), http.StatusUnprocessableEntity)
+			return
+		case errors.Is(err, fee.ErrMultipleDays):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		case err != nil:
+			http.Error(w, "fee calculation failed", http.StatusInternalServerError)
+			return
+		}
+
+		response := RegistrationFeeResponse{
+			Registration: registration.Number,
+			VehicleType:  string(registration.VehicleType),
+			Fee:          explanation.Fee,
+		}
+		if r.URL.Query().Get("explain") == "true" {
+			response.Explanation = &explanation
+		}
+
+		// Send response
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(response)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "failed to encode response", "error", err)
+		}
+	}
+}
+
+// lookupRegistration returns the registration of number that is valid at all entry times. A registration
+// number that changes hands between the entry times cannot be charged as a single vehicle.
+func lookupRegistration(
+	registry vehicleregistry.Store,
+	number string,
+	entryTimes []time.Time,
+) (vehicleregistry.Registration, error) {
+	var registration vehicleregistry.Registration
+	for i, t := range entryTimes {
+		r, err := registry.Lookup(number, t)
+		if err != nil {
+			return vehicleregistry.Registration{}, err
+		}
+		if i > 0 && r != registration {
+			return vehicleregistry.Registration{}, fmt.Errorf("registration of %q changes between the entry times", r.Number)
+		}
+		registration = r
+	}
+
+	return registration, nil
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"afry-toll-calculator/metrics"
+	"afry-toll-calculator/models"
+	"afry-toll-calculator/services/zone"
+)
+
+type ZoneFeesRequest struct {
+	VehicleType string           `json:"vehicleType"`
+	Passages    []models.Passage `json:"passages"`
+}
+
+// GetZoneFeesHandler calculates fees for passages at gantries in any number of tariff zones and responds
+// with the total and a subtotal for each zone.
+func GetZoneFeesHandler(zoneService zone.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var (
+			err         error
+			feesRequest ZoneFeesRequest
+			summary     zone.Summary
+		)
+		defer func() {
+			metrics.RecordFeeCalculation(feesRequest.VehicleType, summary.Total, err)
+			metrics.RecordCollapsedPassages(summary.Collapsed)
+		}()
+
+		if r.Method != http.MethodPost {
+			http.Error(w, "invalid method", http.StatusMethodNotAllowed)
+			return
+		}
+
+		err = json.NewDecoder(r.Body).Decode(&feesRequest)
+		if err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		defer func() {
+			erri := r.Body.Close()
+			if erri != nil {
+				slog.ErrorContext(r.Context(), "failed to close request body", "error", erri)
+			}
+		}()
+
+		// Validate
+		if feesRequest.VehicleType == "" {
+			err = errors.New("missing vehicle type")
+			http.Error(w, "missing vehicle type", http.StatusBadRequest)
+			return
+		}
+		if len(feesRequest.Passages) == 0 {
+			err = errors.New("missing passages array")
+			http.Error(w, "missing passages array", http.StatusBadRequest)
+			return
+		}
+
+		summary, err = zoneService.GetFees(models.VehicleType(feesRequest.VehicleType), feesRequest.Passages)
+		if errors.Is(err, zone.ErrUnknownGantry) {
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+		if err != nil {
+			http.Error(w, "fee calculation failed", http.StatusInternalServerError)
+			return
+		}
+
+		// Send response
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(summary)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "failed to encode response", "error", err)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package dagsmart
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_breaker(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := newBreaker(2, time.Minute)
+	b.now = func() time.Time { return now }
+
+	if !b.allow() || b.record(false) {
+		t.Fatal("breaker should stay closed after the first failure")
+	}
+	if !b.allow() || !b.record(false) {
+		t.Fatal("breaker should open after reaching the threshold")
+	}
+	if b.allow() {
+		t.Fatal("open breaker should reject attempts")
+	}
+
+	now = now.Add(time.Minute)
+	if !b.allow() {
+		t.Fatal("breaker should let a trial attempt through after the cooldown")
+	}
+	if b.allow() {
+		t.Fatal("breaker should only let a single trial attempt through")
+	}
+	if !b.record(false) {
+		t.Fatal("failed trial attempt should reopen the breaker")
+	}
+	if b.allow() {
+		t.Fatal("reopened breaker should reject attempts")
+	}
+
+	now = now.Add(time.Minute)
+	if !b.allow() {
+		t.Fatal("breaker should let a trial attempt through after the cooldown")
+	}
+	b.record(true)
+	if !b.allow() || !b.allow() {
+		t.Fatal("successful trial attempt should close the breaker")
+	}
+}
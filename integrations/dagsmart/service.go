@@ -3,33 +3,103 @@
 package dagsmart
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"math/rand/v2"
 	"net/http"
 	"time"
 
 	"afry-toll-calculator/models"
 )
 
-type HttpGetter interface {
-	Get(url string) (*http.Response, error)
+// HttpClient is satisfied by *http.Client.
+type HttpClient interface {
+	Do(req *http.Request) (*http.Response, error)
 }
 
 type Service interface {
-	Get(year int) ([]string, error)
+	Get(ctx context.Context, year int) ([]string, error)
 }
 
-func New(httpService HttpGetter) Service {
-	return &svc{
-		httpService: httpService,
+// Config controls how the integration talks to the dagsmart API. Zero and nil values are replaced by defaults.
+type Config struct {
+	// BaseURL is the address of the dagsmart API, without a trailing slash.
+	BaseURL string
+	// RequestTimeout is the deadline of a single attempt.
+	RequestTimeout time.Duration
+	// MaxRetries is the number of times a transient failure is retried. Zero disables retries, and nil retries
+	// defaultMaxRetries times.
+	MaxRetries *int
+	// BackoffBase and BackoffMax bound the jittered exponential backoff between attempts.
+	BackoffBase time.Duration
+	BackoffMax  time.Duration
+	// BreakerThreshold is the number of consecutive failed attempts that opens the circuit breaker.
+	BreakerThreshold int
+	// BreakerCooldown is how long the circuit breaker stays open before a trial request is let through.
+	BreakerCooldown time.Duration
+}
+
+const (
+	defaultBaseURL          = "https://api.dagsmart.se"
+	defaultRequestTimeout   = 5 * time.Second
+	defaultMaxRetries       = 3
+	defaultBackoffBase      = 200 * time.Millisecond
+	defaultBackoffMax       = 2 * time.Second
+	defaultBreakerThreshold = 5
+	defaultBreakerCooldown  = 30 * time.Second
+)
+
+func (c Config) withDefaults() Config {
+	if c.BaseURL == "" {
+		c.BaseURL = defaultBaseURL
+	}
+	if c.RequestTimeout <= 0 {
+		c.RequestTimeout = defaultRequestTimeout
+	}
+	if c.MaxRetries == nil {
+		maxRetries := defaultMaxRetries
+		c.MaxRetries = &maxRetries
+	}
+	if c.BackoffBase <= 0 {
+		c.BackoffBase = defaultBackoffBase
+	}
+	if c.BackoffMax <= 0 {
+		c.BackoffMax = defaultBackoffMax
+	}
+	if c.BreakerThreshold <= 0 {
+		c.BreakerThreshold = defaultBreakerThreshold
+	}
+	if c.BreakerCooldown <= 0 {
+		c.BreakerCooldown = defaultBreakerCooldown
+	}
+
+	return c
+}
+
+// New returns a Service that fetches holidays with httpClient. A negative MaxRetries is an error.
+func New(httpClient HttpClient, cfg Config) (Service, error) {
+	if cfg.MaxRetries != nil && *cfg.MaxRetries < 0 {
+		return nil, fmt.Errorf("max retries must not be negative, got %d", *cfg.MaxRetries)
 	}
+	cfg = cfg.withDefaults()
+
+	return &svc{
+		httpClient: httpClient,
+		cfg:        cfg,
+		breaker:    newBreaker(cfg.BreakerThreshold, cfg.BreakerCooldown),
+		sleep:      sleep,
+	}, nil
 }
 
 type svc struct {
-	httpService HttpGetter
+	httpClient HttpClient
+	cfg        Config
+	breaker    *breaker
+	sleep      func(ctx context.Context, d time.Duration) error
 }
 
 type dagsmartItem struct {
@@ -41,9 +111,25 @@ type dagsmartItem struct {
 	} `json:"name"`
 }
 
+var (
+	// ErrCircuitOpen is returned without contacting the API while the circuit breaker is open.
+	ErrCircuitOpen = errors.New("dagsmart circuit breaker is open")
+
+	errInvalidResponse = errors.New("failed to unmarshal JSON response")
+)
+
+// statusError is returned when the API responds with a non-2xx status code.
+type statusError struct {
+	code int
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("unexpected status code %d", e.code)
+}
+
 // Get fetches and returns a list of public holiday dates as strings. Returns an error if fetching fails.
-func (s *svc) Get(year int) ([]string, error) {
-	items, err := s.getItems(year)
+func (s *svc) Get(ctx context.Context, year int) ([]string, error) {
+	items, err := s.getItems(ctx, year)
 	if err != nil {
 		return nil, err
 	}
@@ -60,10 +146,51 @@ func (s *svc) Get(year int) ([]string, error) {
 	return dates, nil
 }
 
-func (s *svc) getItems(year int) ([]dagsmartItem, error) {
+// getItems fetches the holidays of the year, retrying transient failures with jittered exponential backoff.
+func (s *svc) getItems(ctx context.Context, year int) ([]dagsmartItem, error) {
 	slog.Info("fetching holidays", slog.Int("year", year))
 
-	res, err := s.httpService.Get(fmt.Sprintf("https://api.dagsmart.se/holidays?weekends=false&year=%d", year))
+	var err error
+	for attempt := 0; attempt <= *s.cfg.MaxRetries; attempt++ {
+		if attempt > 0 {
+			backoff := s.backoff(attempt)
+			slog.Warn("retrying holiday fetch", "year", year, "attempt", attempt, "backoff", backoff, "error", err)
+			if sleepErr := s.sleep(ctx, backoff); sleepErr != nil {
+				return nil, sleepErr
+			}
+		}
+
+		if !s.breaker.allow() {
+			return nil, ErrCircuitOpen
+		}
+
+		var items []dagsmartItem
+		items, err = s.fetch(ctx, year)
+		if err == nil || !isTransient(ctx, err) {
+			s.breaker.record(err == nil)
+			return items, err
+		}
+
+		if s.breaker.record(false) {
+			slog.Error("dagsmart circuit breaker opened", "cooldown", s.cfg.BreakerCooldown, "error", err)
+		}
+	}
+
+	return nil, err
+}
+
+// fetch performs a single attempt within its own deadline.
+func (s *svc) fetch(ctx context.Context, year int) ([]dagsmartItem, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.RequestTimeout)
+	defer cancel()
+
+	url := fmt.Sprintf("%s/holidays?weekends=false&year=%d", s.cfg.BaseURL, year)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.httpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -74,6 +201,10 @@ func (s *svc) getItems(year int) ([]dagsmartItem, error) {
 		}
 	}()
 
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return nil, &statusError{code: res.StatusCode}
+	}
+
 	b, err := io.ReadAll(res.Body)
 	if err != nil {
 		return nil, err
@@ -82,12 +213,49 @@ func (s *svc) getItems(year int) ([]dagsmartItem, error) {
 	var items []dagsmartItem
 	err = json.Unmarshal(b, &items)
 	if err != nil {
-		return nil, errors.New("failed to unmarshal JSON response")
+		return nil, errInvalidResponse
 	}
 
 	return items, nil
 }
 
+// isTransient reports whether a failed attempt is worth retrying. Server errors, rate limiting and transport
+// errors are retried, unless the caller's context is done.
+func isTransient(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var se *statusError
+	if errors.As(err, &se) {
+		return se.code >= 500 || se.code == http.StatusTooManyRequests
+	}
+
+	return !errors.Is(err, errInvalidResponse)
+}
+
+// backoff returns a random duration between zero and the exponential backoff of the attempt ("full jitter").
+func (s *svc) backoff(attempt int) time.Duration {
+	d := s.cfg.BackoffBase << (attempt - 1)
+	if d <= 0 || d > s.cfg.BackoffMax {
+		d = s.cfg.BackoffMax
+	}
+
+	return rand.N(d) + 1
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
 func (s *svc) validateDates(dates []string) bool {
 	for _, v := range dates {
 		if _, err := time.Parse(models.PUBLIC_HOLIDAY_DATE_FORMAT, v); err != nil {
@@ -1,102 +1,169 @@
 package dagsmart
 
 import (
+	"context"
 	"errors"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"testing/iotest"
+	"time"
 
 	mock_dagsmart "afry-toll-calculator/mocks/afry-toll-calculator/integrations/dagsmart"
 	"github.com/stretchr/testify/mock"
 )
 
+func newTestSvc(t *testing.T, httpClient HttpClient, cfg Config) *svc {
+	t.Helper()
+
+	s, err := New(httpClient, cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	return s.(*svc)
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
 func Test_svc_Get(t *testing.T) {
-	validAPIResponse := &http.Response{
-		Body: io.NopCloser(strings.NewReader(`[
+	response := func(code int, body string) *http.Response {
+		return &http.Response{
+			StatusCode: code,
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}
+	}
+	validAPIResponseBody := `[
 			{"date":"2013-01-01","code":"newYearsDay","name":{"en":"New Year's Day","sv":"nyårsdagen"}},
 			{"date":"2013-01-06","code":"epiphany","name":{"en":"Epiphany","sv":"trettondedag jul"}}
-		]`)),
-	}
+		]`
 	validAPIResponseItems := []string{"2013-01-01", "2013-01-06"}
 
 	tests := []struct {
 		name        string
 		year        int
-		mocks       func(*mock_dagsmart.MockHttpGetter)
+		mocks       func(*mock_dagsmart.MockHttpClient)
 		want        []string
 		wantErr     bool
 		wantErrText string
 	}{
 		{
-			name: "API error",
-			mocks: func(getter *mock_dagsmart.MockHttpGetter) {
-				getter.EXPECT().
-					Get(mock.Anything).
-					Return(nil, errors.New("foo"))
+			name: "API error is retried",
+			mocks: func(client *mock_dagsmart.MockHttpClient) {
+				client.EXPECT().
+					Do(mock.Anything).
+					Return(nil, errors.New("foo")).
+					Times(4)
 			},
 			wantErr:     true,
 			wantErrText: "foo",
 		},
 		{
 			name: "API response bad data",
-			mocks: func(getter *mock_dagsmart.MockHttpGetter) {
-				getter.EXPECT().
-					Get(mock.Anything).
-					Return(&http.Response{
-						Body: io.NopCloser(strings.NewReader("foo-data")),
-					}, nil)
+			mocks: func(client *mock_dagsmart.MockHttpClient) {
+				client.EXPECT().
+					Do(mock.Anything).
+					Return(response(http.StatusOK, "foo-data"), nil).
+					Once()
 			},
 			wantErr:     true,
 			wantErrText: "failed to unmarshal JSON response",
 		},
 		{
 			name: "API response date format validation error",
-			mocks: func(getter *mock_dagsmart.MockHttpGetter) {
-				getter.EXPECT().
-					Get(mock.Anything).
-					Return(&http.Response{
-						Body: io.NopCloser(strings.NewReader(`[{"date":"01-01-2013","code":"newYearsDay","name":{"en":"New Year's Day","sv":"nyårsdagen"}}]`)),
-					}, nil)
+			mocks: func(client *mock_dagsmart.MockHttpClient) {
+				client.EXPECT().
+					Do(mock.Anything).
+					Return(response(http.StatusOK, `[{"date":"01-01-2013","code":"newYearsDay","name":{"en":"New Year's Day","sv":"nyårsdagen"}}]`), nil)
 			},
 			wantErr:     true,
 			wantErrText: "failed to validate item date format",
 		},
 		{
-			name: "API response date format validation error",
-			mocks: func(getter *mock_dagsmart.MockHttpGetter) {
-				getter.EXPECT().
-					Get("https://api.dagsmart.se/holidays?weekends=false&year=1337").
-					Return(validAPIResponse, nil)
+			name: "API response is returned",
+			mocks: func(client *mock_dagsmart.MockHttpClient) {
+				client.EXPECT().
+					Do(mock.MatchedBy(func(req *http.Request) bool {
+						return req.URL.String() == "https://api.dagsmart.se/holidays?weekends=false&year=1337"
+					})).
+					Return(response(http.StatusOK, validAPIResponseBody), nil)
 			},
 			year:    1337,
 			wantErr: false,
 			want:    validAPIResponseItems,
 		},
 		{
-			name: "read body error",
-			mocks: func(getter *mock_dagsmart.MockHttpGetter) {
-				getter.EXPECT().
-					Get(mock.Anything).
-					Return(&http.Response{
-						Body: io.NopCloser(iotest.ErrReader(errors.New("reader error")))}, nil)
+			name: "read body error is retried",
+			mocks: func(client *mock_dagsmart.MockHttpClient) {
+				client.EXPECT().
+					Do(mock.Anything).
+					RunAndReturn(func(*http.Request) (*http.Response, error) {
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Body:       io.NopCloser(iotest.ErrReader(errors.New("reader error"))),
+						}, nil
+					}).
+					Times(4)
 			},
 			wantErr:     true,
 			wantErrText: "reader error",
 		},
+		{
+			name: "server error is retried until it succeeds",
+			mocks: func(client *mock_dagsmart.MockHttpClient) {
+				client.EXPECT().
+					Do(mock.Anything).
+					Return(response(http.StatusBadGateway, "<html>bad gateway</html>"), nil).
+					Once()
+				client.EXPECT().
+					Do(mock.Anything).
+					Return(response(http.StatusTooManyRequests, ""), nil).
+					Once()
+				client.EXPECT().
+					Do(mock.Anything).
+					Return(response(http.StatusOK, validAPIResponseBody), nil).
+					Once()
+			},
+			want: validAPIResponseItems,
+		},
+		{
+			name: "server error is reported with its status code",
+			mocks: func(client *mock_dagsmart.MockHttpClient) {
+				client.EXPECT().
+					Do(mock.Anything).
+					Return(response(http.StatusInternalServerError, "<html>error</html>"), nil).
+					Times(4)
+			},
+			wantErr:     true,
+			wantErrText: "unexpected status code 500",
+		},
+		{
+			name: "client error is not retried",
+			mocks: func(client *mock_dagsmart.MockHttpClient) {
+				client.EXPECT().
+					Do(mock.Anything).
+					Return(response(http.StatusNotFound, "<html>not found</html>"), nil).
+					Once()
+			},
+			wantErr:     true,
+			wantErrText: "unexpected status code 404",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			httpGetter := mock_dagsmart.NewMockHttpGetter(t)
-			tt.mocks(httpGetter)
+			httpClient := mock_dagsmart.NewMockHttpClient(t)
+			tt.mocks(httpClient)
 
-			s := &svc{
-				httpService: httpGetter,
-			}
+			s := newTestSvc(t, httpClient, Config{})
+			s.sleep = func(context.Context, time.Duration) error { return nil }
 
-			got, err := s.Get(tt.year)
+			got, err := s.Get(context.Background(), tt.year)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Get() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -111,3 +178,127 @@ func Test_svc_Get(t *testing.T) {
 		})
 	}
 }
+
+func Test_svc_Get_circuitBreaker(t *testing.T) {
+	httpClient := mock_dagsmart.NewMockHttpClient(t)
+	httpClient.EXPECT().
+		Do(mock.Anything).
+		Return(nil, errors.New("connection refused")).
+		Times(3)
+
+	s := newTestSvc(t, httpClient, Config{MaxRetries: intPtr(5), BreakerThreshold: 3, BreakerCooldown: time.Minute})
+	s.sleep = func(context.Context, time.Duration) error { return nil }
+
+	_, err := s.Get(context.Background(), 2025)
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Get() error = %v, want %v", err, ErrCircuitOpen)
+	}
+
+	// The breaker stays open, so the API is not contacted again.
+	_, err = s.Get(context.Background(), 2025)
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Get() error = %v, want %v", err, ErrCircuitOpen)
+	}
+}
+
+func Test_svc_Get_contextCanceled(t *testing.T) {
+	httpClient := mock_dagsmart.NewMockHttpClient(t)
+	httpClient.EXPECT().
+		Do(mock.Anything).
+		Return(nil, errors.New("connection refused")).
+		Once()
+
+	s := newTestSvc(t, httpClient, Config{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.sleep = func(ctx context.Context, d time.Duration) error {
+		cancel()
+		return sleep(ctx, d)
+	}
+
+	_, err := s.Get(ctx, 2025)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Get() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func Test_svc_Get_localStub(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if r.URL.Path != "/holidays" || r.URL.Query().Get("year") != "2025" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`[{"date":"2025-01-01","code":"newYearsDay","name":{"en":"New Year's Day","sv":"nyårsdagen"}}]`))
+	}))
+	defer server.Close()
+
+	s := newTestSvc(t, server.Client(), Config{BaseURL: server.URL, BackoffBase: time.Millisecond})
+
+	got, err := s.Get(context.Background(), 2025)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, []string{"2025-01-01"}) {
+		t.Errorf("Get() got = %v", got)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("stub was called %d times, want 2", calls.Load())
+	}
+}
+
+func Test_svc_Get_requestTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	s := newTestSvc(t, server.Client(), Config{
+		BaseURL:        server.URL,
+		RequestTimeout: 10 * time.Millisecond,
+		MaxRetries:     intPtr(0),
+	})
+
+	_, err := s.Get(context.Background(), 2025)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Get() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func Test_svc_Get_maxRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		maxRetries   *int
+		wantAttempts int
+	}{
+		{name: "default", maxRetries: nil, wantAttempts: 4},
+		{name: "zero disables retries", maxRetries: intPtr(0), wantAttempts: 1},
+		{name: "one retry", maxRetries: intPtr(1), wantAttempts: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpClient := mock_dagsmart.NewMockHttpClient(t)
+			httpClient.EXPECT().
+				Do(mock.Anything).
+				Return(nil, errors.New("connection refused")).
+				Times(tt.wantAttempts)
+
+			s := newTestSvc(t, httpClient, Config{MaxRetries: tt.maxRetries, BreakerThreshold: 10})
+			s.sleep = func(context.Context, time.Duration) error { return nil }
+
+			if _, err := s.Get(context.Background(), 2025); err == nil {
+				t.Error("Get() expected error")
+			}
+		})
+	}
+}
+
+func TestNew_negativeMaxRetries(t *testing.T) {
+	if _, err := New(mock_dagsmart.NewMockHttpClient(t), Config{MaxRetries: intPtr(-1)}); err == nil {
+		t.Error("New() expected error for negative max retries")
+	}
+}
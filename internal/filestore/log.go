@@ -0,0 +1,109 @@
+// Package filestore provides the file handling shared by the file-backed stores: an append-only log of JSON
+// lines and writing a whole file so a crash never leaves it partially written.
+package filestore
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+)
+
+// Log is an append-only file with one JSON value per line. Every value is synced to disk before Append returns.
+// It is not safe for concurrent use; the stores built on it serialize access themselves.
+type Log[T any] struct {
+	file *os.File
+	size int64
+	// kind names the values in log messages, e.g. "invoice".
+	kind string
+}
+
+// OpenLog opens the log at path, creating it if it does not exist, and passes every value it holds to replay, in
+// order. A last line that was only partially written, as after a crash, is discarded. An error of replay stops
+// opening the log and is returned with the path and line of the value.
+func OpenLog[T any](path, kind string, replay func(v T) error) (*Log[T], error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &Log[T]{file: file, kind: kind}
+	if err := l.replay(path, replay); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return l, nil
+}
+
+func (l *Log[T]) replay(path string, replay func(v T) error) error {
+	data, err := io.ReadAll(l.file)
+	if err != nil {
+		return err
+	}
+
+	valid := 0
+	for line := 1; len(data[valid:]) > 0; line++ {
+		rest := data[valid:]
+		end := bytes.IndexByte(rest, '\n')
+		if end < 0 {
+			slog.Warn("discarding partially written "+l.kind, "file", path, "line", line)
+			break
+		}
+
+		var v T
+		if err := json.Unmarshal(rest[:end], &v); err != nil {
+			return fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		if err := replay(v); err != nil {
+			return fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		valid += end + 1
+	}
+
+	return l.truncate(int64(valid))
+}
+
+// Append writes v as the next line of the log and syncs it to disk. If v cannot be written completely, the file is
+// cut back so the next value does not follow a partial line.
+func (l *Log[T]) Append(v T) error {
+	line, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	n, err := l.file.Write(append(line, '\n'))
+	if err == nil {
+		err = l.file.Sync()
+	}
+	if err != nil {
+		if errTruncate := l.truncate(l.size); errTruncate != nil {
+			slog.Error("failed to discard partial "+l.kind, "error", errTruncate)
+		}
+		return err
+	}
+
+	l.size += int64(n)
+
+	return nil
+}
+
+// truncate cuts the file to size and positions the next write at its end.
+func (l *Log[T]) truncate(size int64) error {
+	if err := l.file.Truncate(size); err != nil {
+		return err
+	}
+	if _, err := l.file.Seek(size, io.SeekStart); err != nil {
+		return err
+	}
+	l.size = size
+
+	return nil
+}
+
+// Close closes the log file.
+func (l *Log[T]) Close() error {
+	return l.file.Close()
+}
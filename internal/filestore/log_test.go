@@ -0,0 +1,114 @@
+package filestore
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type record struct {
+	N int `json:"n"`
+}
+
+func openTestLog(t *testing.T, path string) (*Log[record], []record) {
+	t.Helper()
+
+	var replayed []record
+	l, err := OpenLog(path, "record", func(r record) error {
+		replayed = append(replayed, r)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("OpenLog() error = %v", err)
+	}
+	t.Cleanup(func() { l.Close() })
+
+	return l, replayed
+}
+
+func TestLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "records.jsonl")
+	l, replayed := openTestLog(t, path)
+	if len(replayed) != 0 {
+		t.Errorf("OpenLog() of a new file replayed %v", replayed)
+	}
+
+	for n := 1; n <= 2; n++ {
+		if err := l.Append(record{N: n}); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+	l.Close()
+
+	// Simulate a crash in the middle of writing the third record.
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString(`{"n":`); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	want := []record{{N: 1}, {N: 2}}
+	l, replayed = openTestLog(t, path)
+	if !reflect.DeepEqual(replayed, want) {
+		t.Errorf("OpenLog() replayed %v, want %v", replayed, want)
+	}
+
+	// The partial line is discarded, so the next record starts a line of its own.
+	if err := l.Append(record{N: 3}); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	l.Close()
+
+	want = append(want, record{N: 3})
+	if _, replayed = openTestLog(t, path); !reflect.DeepEqual(replayed, want) {
+		t.Errorf("OpenLog() after restart replayed %v, want %v", replayed, want)
+	}
+}
+
+func TestOpenLog_errors(t *testing.T) {
+	errReplay := errors.New("replay failed")
+
+	tests := []struct {
+		name    string
+		content string
+		replay  func(r record) error
+		wantErr string
+	}{
+		{
+			name:    "corrupt line",
+			content: "{\"n\":1}\nnot json\n",
+			replay:  func(r record) error { return nil },
+			wantErr: "records.jsonl:2: ",
+		},
+		{
+			name:    "replay error",
+			content: "{\"n\":1}\n{\"n\":2}\n",
+			replay: func(r record) error {
+				if r.N == 2 {
+					return errReplay
+				}
+				return nil
+			},
+			wantErr: "records.jsonl:2: replay failed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "records.jsonl")
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := OpenLog(path, "record", tt.replay)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("OpenLog() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -10,13 +10,22 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+	_ "time/tzdata" // embed the time zone database, the container image does not provide one
 
 	"github.com/kelseyhightower/envconfig"
 
 	"afry-toll-calculator/integrations/dagsmart"
+	"afry-toll-calculator/services/dispute"
+	"afry-toll-calculator/services/exemption"
 	"afry-toll-calculator/services/fee"
+	"afry-toll-calculator/services/gantry"
+	"afry-toll-calculator/services/holidays"
+	"afry-toll-calculator/services/invoice"
+	"afry-toll-calculator/services/passagelog"
 	"afry-toll-calculator/services/pricelist"
 	"afry-toll-calculator/services/vehiclelist"
+	"afry-toll-calculator/services/vehicleregistry"
+	"afry-toll-calculator/services/zone"
 )
 
 func main() {
@@ -35,12 +44,164 @@ func main() {
 	}))
 	slog.SetDefault(logger.With(slog.String("service", "toll-calculator")))
 
+	location, err := time.LoadLocation(cfg.BillingTimezone)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to load billing timezone", "timezone", cfg.BillingTimezone, "error", err)
+		panic(err)
+	}
+
+	tariff, err := newTariff(cfg.TariffFile, location)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to load tariff", "file", cfg.TariffFile, "error", err)
+		panic(err)
+	}
+
+	holidaysGetter, err := newHolidaysGetter(cfg)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to configure holiday source", "error", err)
+		panic(err)
+	}
+
+	holidayCache, err := newHolidayCache(cfg, holidaysGetter)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to configure holiday cache", "error", err)
+		panic(err)
+	}
+
+	year := time.Now().In(location).Year()
+	if err := holidayCache.Prewarm(ctx, year, year+1); err != nil {
+		// Not fatal, the years are fetched again on the first request that needs them.
+		slog.WarnContext(ctx, "failed to prewarm holiday cache", "error", err)
+	}
+
+	vehiclesGetter := vehiclelist.NewHardcodedGetter()
+	var vehicleFile *vehiclelist.FileGetter
+	if cfg.VehicleListFile != "" {
+		vehicleFile, err = vehiclelist.NewFileGetter(cfg.VehicleListFile, location)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to load vehicle list", "file", cfg.VehicleListFile, "error", err)
+			panic(err)
+		}
+		vehiclesGetter = vehicleFile
+	}
 	feeService := fee.New(
-		vehiclelist.NewHardcodedGetter(),
-		dagsmart.New(http.DefaultClient),
-		pricelist.New(&pricelist.HardcodedPriceBlocksGetter{}),
+		vehiclesGetter,
+		holidayCache,
+		tariff,
+		location,
 	)
 
+	var gantryStore *gantry.FileStore
+	if cfg.GantryFile != "" {
+		gantryStore, err = gantry.NewFileStore(cfg.GantryFile)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to load gantries", "file", cfg.GantryFile, "error", err)
+			panic(err)
+		}
+	}
+
+	var gantryResolver zone.Resolver
+	if gantryStore != nil {
+		gantryResolver = gantryStore
+	}
+	zoneService, zoneTariffs, err := newZoneService(cfg.ZonesFile, gantryResolver, vehiclesGetter, holidayCache, location)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to load zones", "file", cfg.ZonesFile, "error", err)
+		panic(err)
+	}
+
+	var vehicleRegistry *vehicleregistry.FileStore
+	if cfg.VehicleRegistryFile != "" {
+		vehicleRegistry, err = vehicleregistry.NewFileStore(cfg.VehicleRegistryFile)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to load vehicle registry", "file", cfg.VehicleRegistryFile, "error", err)
+			panic(err)
+		}
+	}
+
+	var exemptionStore *exemption.FileStore
+	if cfg.ExemptionFile != "" {
+		exemptionStore, err = exemption.NewFileStore(cfg.ExemptionFile)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to load exemptions", "file", cfg.ExemptionFile, "error", err)
+			panic(err)
+		}
+		defer exemptionStore.Close()
+	}
+
+	var passageLog *passagelog.SegmentLog
+	if cfg.PassageLogDir != "" {
+		passageLog, err = passagelog.Open(cfg.PassageLogDir, cfg.PassageLogSegmentSize, cfg.PassageLogDedupWindow)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to open passage log", "dir", cfg.PassageLogDir, "error", err)
+			panic(err)
+		}
+		defer passageLog.Close()
+	}
+
+	var disputeStore *dispute.FileStore
+	if cfg.DisputeFile != "" {
+		if passageLog == nil {
+			err := errors.New("disputes require a passage log")
+			slog.ErrorContext(ctx, "failed to configure disputes", "error", err)
+			panic(err)
+		}
+		disputeStore, err = dispute.NewFileStore(cfg.DisputeFile)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to load disputes", "file", cfg.DisputeFile, "error", err)
+			panic(err)
+		}
+		defer disputeStore.Close()
+	}
+
+	var invoiceService invoice.Service
+	if cfg.InvoiceFile != "" {
+		invoiceStore, err := invoice.NewFileStore(cfg.InvoiceFile)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to load invoices", "file", cfg.InvoiceFile, "error", err)
+			panic(err)
+		}
+		defer invoiceStore.Close()
+
+		invoiceService, err = newInvoiceService(
+			cfg, passageLog, disputeStore, vehicleRegistry, exemptionStore, feeService, zoneService, invoiceStore, location)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to configure invoicing", "error", err)
+			panic(err)
+		}
+	}
+
+	var disputeService dispute.Service
+	if disputeStore != nil {
+		disputeService = dispute.New(passageLog, disputeStore, invoiceService, location)
+	}
+
+	configReloader := &reloader{
+		tariffFile:      cfg.TariffFile,
+		location:        location,
+		holidays:        holidayCache,
+		feeService:      feeService,
+		zones:           zoneTariffs,
+		vehicles:        vehiclesGetter,
+		vehicleList:     vehicleFile,
+		vehicleRegistry: vehicleRegistry,
+	}
+	if cfg.AdminToken == "" {
+		slog.Warn("admin token is not configured, admin endpoints are disabled")
+	}
+
+	handler := routes(
+		cfg,
+		feeService,
+		zoneService,
+		gantryStore,
+		vehicleRegistry,
+		exemptionStore,
+		passageLog,
+		invoiceService,
+		disputeService,
+		configReloader,
+	)
 	s := &http.Server{
 		Addr:              fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
 		ReadTimeout:       15 * time.Second,
@@ -48,7 +209,7 @@ func main() {
 		WriteTimeout:      15 * time.Second,
 		IdleTimeout:       120 * time.Second,
 		MaxHeaderBytes:    50 << 10, // 50KB
-		Handler:           routes(feeService),
+		Handler:           handler,
 	}
 
 	serverErrors := make(chan error)
@@ -59,6 +220,15 @@ func main() {
 		serverErrors <- s.ListenAndServe()
 	}()
 
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	go func() {
+		for sig := range reload {
+			slog.Info("reload signal received", "signal", sig)
+			_ = configReloader.Reload() // failures are logged and recorded by the reloader
+		}
+	}()
+
 	shutdown := make(chan os.Signal, 1)
 	signal.Notify(shutdown, os.Interrupt, syscall.SIGTERM)
 
@@ -88,6 +258,168 @@ func main() {
 	}
 }
 
+// newTariff returns the tariff defined by the tariff document at tariffFile, or the hardcoded tariff with
+// the default calendar if no file is configured.
+func newTariff(tariffFile string, location *time.Location) (fee.Tariff, error) {
+	if tariffFile == "" {
+		return fee.Tariff{
+			Version:   "builtin",
+			Currency:  "SEK",
+			PriceList: pricelist.New(&pricelist.HardcodedPriceBlocksGetter{}, location),
+		}, nil
+	}
+
+	getter, err := pricelist.NewFileGetter(tariffFile, location)
+	if err != nil {
+		return fee.Tariff{}, err
+	}
+
+	slog.Info("loaded tariff", "file", tariffFile, "name", getter.Name(), "currency", getter.Currency())
+
+	priceListService, err := pricelist.NewVersioned(getter, location)
+	if err != nil {
+		return fee.Tariff{}, err
+	}
+
+	return fee.Tariff{
+		Version:        getter.Version(),
+		Currency:       getter.Currency(),
+		PriceList:      priceListService,
+		Calendar:       getter.Calendar(),
+		DailyCap:       getter.DailyCap(),
+		Window:         getter.Window(),
+		WindowStrategy: getter.WindowStrategy(),
+		Multipliers:    getter.Multipliers(),
+		Rounding:       getter.Rounding(),
+		DedupWindow:    getter.DedupWindow(),
+	}, nil
+}
+
+// newZoneService returns a zone service with a fee service for every zone of the zones document at zonesFile,
+// or nil if no zones document is configured. Gantries are resolved with resolver if it is not nil, and with the
+// gantries listed in the zones document otherwise.
+func newZoneService(
+	zonesFile string,
+	resolver zone.Resolver,
+	vehiclesGetter vehiclelist.Getter,
+	holidayCache *holidays.Cache,
+	location *time.Location,
+) (zone.Service, []zoneTariff, error) {
+	if zonesFile == "" {
+		return nil, nil, nil
+	}
+
+	zones, err := zone.LoadConfig(zonesFile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	feeServices := map[string]fee.Service{}
+	zoneTariffs := make([]zoneTariff, 0, len(zones))
+	for _, z := range zones {
+		tariff, err := newTariff(z.TariffFile, location)
+		if err != nil {
+			return nil, nil, fmt.Errorf("zone %q: %w", z.Name, err)
+		}
+
+		feeServices[z.Name] = fee.New(vehiclesGetter, holidayCache, tariff, location)
+		zoneTariffs = append(zoneTariffs, zoneTariff{name: z.Name, tariffFile: z.TariffFile, feeService: feeServices[z.Name]})
+	}
+
+	slog.Info("loaded zones", "file", zonesFile, "zones", len(zones))
+
+	if resolver == nil {
+		resolver = zone.NewRegistry(zone.GantryZones(zones))
+	}
+
+	return zone.New(resolver, feeServices), zoneTariffs, nil
+}
+
+// newInvoiceService returns an invoice service for the passages in passageLog, which requires the passage log and
+// the vehicle registry to be configured. Passages of accepted disputes in disputeStore are not invoiced. If
+// zoneService is not nil, passages are priced with the tariff of their zone.
+func newInvoiceService(
+	cfg config,
+	passageLog *passagelog.SegmentLog,
+	disputeStore *dispute.FileStore,
+	vehicleRegistry *vehicleregistry.FileStore,
+	exemptionStore *exemption.FileStore,
+	feeService fee.Service,
+	zoneService zone.Service,
+	invoiceStore invoice.Store,
+	location *time.Location,
+) (invoice.Service, error) {
+	if passageLog == nil || vehicleRegistry == nil {
+		return nil, errors.New("invoicing requires a passage log and a vehicle registry")
+	}
+
+	var passages passagelog.Log = passageLog
+	if disputeStore != nil {
+		passages = dispute.ExcludeAccepted(passageLog, disputeStore)
+	}
+	var exemptions exemption.Store
+	if exemptionStore != nil {
+		exemptions = exemptionStore
+	}
+
+	return invoice.New(
+		passages,
+		vehicleRegistry,
+		exemptions,
+		feeService,
+		zoneService,
+		invoiceStore,
+		location,
+		cfg.InvoicePaymentDays,
+	), nil
+}
+
+// newHolidaysGetter returns the holiday source selected by the HOLIDAY_SOURCE setting.
+func newHolidaysGetter(cfg config) (holidays.Getter, error) {
+	newDagsmart := func() (dagsmart.Service, error) {
+		service, err := dagsmart.New(&http.Client{}, dagsmart.Config{
+			BaseURL:          cfg.DagsmartURL,
+			RequestTimeout:   cfg.DagsmartTimeout,
+			MaxRetries:       &cfg.DagsmartMaxRetries,
+			BreakerThreshold: cfg.DagsmartBreakerThreshold,
+			BreakerCooldown:  cfg.DagsmartBreakerCooldown,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("dagsmart: %w", err)
+		}
+		return service, nil
+	}
+
+	switch cfg.HolidaySource {
+	case "dagsmart":
+		return newDagsmart()
+	case "offline":
+		return holidays.NewSwedishCalendar(), nil
+	case "dagsmart-with-fallback":
+		dagsmartService, err := newDagsmart()
+		if err != nil {
+			return nil, err
+		}
+		return holidays.NewFallback(dagsmartService, holidays.NewSwedishCalendar()), nil
+	default:
+		return nil, fmt.Errorf("unknown holiday source %q", cfg.HolidaySource)
+	}
+}
+
+// newHolidayCache returns a holiday cache in front of getter, persisted in HOLIDAY_CACHE_DIR if it is set.
+func newHolidayCache(cfg config, getter holidays.Getter) (*holidays.Cache, error) {
+	if cfg.HolidayCacheDir == "" {
+		return holidays.NewCache(getter, cfg.HolidayCacheTTL), nil
+	}
+
+	store, err := holidays.NewFileStore(cfg.HolidayCacheDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return holidays.NewPersistentCache(getter, cfg.HolidayCacheTTL, store, cfg.HolidaySource), nil
+}
+
 func getLogLevel(level string) slog.Level {
 	switch level {
 	case "DEBUG":
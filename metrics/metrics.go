@@ -3,6 +3,7 @@ package metrics
 import (
 	"net/http"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -45,8 +46,76 @@ var (
 		},
 		[]string{"vehicle_type"},
 	)
+
+	passagesCollapsedTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "passages_collapsed_total",
+			Help: "Total number of passages collapsed as duplicates by fee requests",
+		},
+	)
+
+	passagesIngestedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "passages_ingested_total",
+			Help: "Total number of passage events received, by result",
+		},
+		[]string{"result"},
+	)
+
+	// Configuration metrics
+	configReloadsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "config_reloads_total",
+			Help: "Total number of configuration reloads",
+		},
+		[]string{"status"},
+	)
+
+	configLastReloadSuccess = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "config_last_reload_success_timestamp_seconds",
+			Help: "Timestamp of the last successful configuration reload",
+		},
+	)
 )
 
+func init() {
+	prometheus.MustRegister(holidayData)
+}
+
+// holidayData reports the age of the holiday data in use for each year. The age is computed when scraped, so
+// it keeps growing while refreshes fail.
+var holidayData = &holidayDataCollector{
+	desc: prometheus.NewDesc(
+		"holiday_data_age_seconds",
+		"Age of the holiday data in use, per year",
+		[]string{"year"}, nil,
+	),
+	fetchedAt: map[int]time.Time{},
+}
+
+type holidayDataCollector struct {
+	desc *prometheus.Desc
+
+	mu        sync.Mutex
+	fetchedAt map[int]time.Time
+}
+
+func (c *holidayDataCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *holidayDataCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for year, fetchedAt := range c.fetchedAt {
+		ch <- prometheus.MustNewConstMetric(
+			c.desc, prometheus.GaugeValue, time.Since(fetchedAt).Seconds(), strconv.Itoa(year),
+		)
+	}
+}
+
 // Middleware wraps an http.Handler and records metrics
 func Middleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -89,3 +158,33 @@ func RecordFeeCalculation(vehicleType string, fee int, err error) {
 		feeAmount.WithLabelValues(vehicleType).Observe(float64(fee))
 	}
 }
+
+// RecordConfigReload records the outcome of a configuration reload
+func RecordConfigReload(err error) {
+	if err != nil {
+		configReloadsTotal.WithLabelValues("error").Inc()
+		return
+	}
+
+	configReloadsTotal.WithLabelValues("success").Inc()
+	configLastReloadSuccess.SetToCurrentTime()
+}
+
+// RecordHolidayData records when the holiday data now in use for a year was fetched from its source
+func RecordHolidayData(year int, fetchedAt time.Time) {
+	holidayData.mu.Lock()
+	defer holidayData.mu.Unlock()
+
+	holidayData.fetchedAt[year] = fetchedAt
+}
+
+// RecordPassageIngested records the result of receiving a passage event: accepted, duplicate, rejected or error
+func RecordPassageIngested(result string) {
+	passagesIngestedTotal.WithLabelValues(result).Inc()
+}
+
+// RecordCollapsedPassages records passages that the fee calculation of a request collapsed as duplicates. It is
+// only called for fee requests, so stored passages that invoicing prices again are not counted again.
+func RecordCollapsedPassages(n int) {
+	passagesCollapsedTotal.Add(float64(n))
+}
@@ -0,0 +1,93 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mock_handlers
+
+import (
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockGantryResolver is an autogenerated mock type for the GantryResolver type
+type MockGantryResolver struct {
+	mock.Mock
+}
+
+type MockGantryResolver_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockGantryResolver) EXPECT() *MockGantryResolver_Expecter {
+	return &MockGantryResolver_Expecter{mock: &_m.Mock}
+}
+
+// Zone provides a mock function with given fields: gantry, at
+func (_m *MockGantryResolver) Zone(gantry string, at time.Time) (string, bool) {
+	ret := _m.Called(gantry, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Zone")
+	}
+
+	var r0 string
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(string, time.Time) (string, bool)); ok {
+		return rf(gantry, at)
+	}
+	if rf, ok := ret.Get(0).(func(string, time.Time) string); ok {
+		r0 = rf(gantry, at)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, time.Time) bool); ok {
+		r1 = rf(gantry, at)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// MockGantryResolver_Zone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Zone'
+type MockGantryResolver_Zone_Call struct {
+	*mock.Call
+}
+
+// Zone is a helper method to define mock.On call
+//   - gantry string
+//   - at time.Time
+func (_e *MockGantryResolver_Expecter) Zone(gantry interface{}, at interface{}) *MockGantryResolver_Zone_Call {
+	return &MockGantryResolver_Zone_Call{Call: _e.mock.On("Zone", gantry, at)}
+}
+
+func (_c *MockGantryResolver_Zone_Call) Run(run func(gantry string, at time.Time)) *MockGantryResolver_Zone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockGantryResolver_Zone_Call) Return(_a0 string, _a1 bool) *MockGantryResolver_Zone_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGantryResolver_Zone_Call) RunAndReturn(run func(string, time.Time) (string, bool)) *MockGantryResolver_Zone_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockGantryResolver creates a new instance of MockGantryResolver. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockGantryResolver(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockGantryResolver {
+	mock := &MockGantryResolver{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
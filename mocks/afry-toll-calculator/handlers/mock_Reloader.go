@@ -0,0 +1,77 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mock_handlers
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockReloader is an autogenerated mock type for the Reloader type
+type MockReloader struct {
+	mock.Mock
+}
+
+type MockReloader_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockReloader) EXPECT() *MockReloader_Expecter {
+	return &MockReloader_Expecter{mock: &_m.Mock}
+}
+
+// Reload provides a mock function with no fields
+func (_m *MockReloader) Reload() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reload")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockReloader_Reload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reload'
+type MockReloader_Reload_Call struct {
+	*mock.Call
+}
+
+// Reload is a helper method to define mock.On call
+func (_e *MockReloader_Expecter) Reload() *MockReloader_Reload_Call {
+	return &MockReloader_Reload_Call{Call: _e.mock.On("Reload")}
+}
+
+func (_c *MockReloader_Reload_Call) Run(run func()) *MockReloader_Reload_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockReloader_Reload_Call) Return(_a0 error) *MockReloader_Reload_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockReloader_Reload_Call) RunAndReturn(run func() error) *MockReloader_Reload_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockReloader creates a new instance of MockReloader. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockReloader(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockReloader {
+	mock := &MockReloader{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
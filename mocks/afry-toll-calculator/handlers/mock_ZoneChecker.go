@@ -0,0 +1,78 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mock_handlers
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockZoneChecker is an autogenerated mock type for the ZoneChecker type
+type MockZoneChecker struct {
+	mock.Mock
+}
+
+type MockZoneChecker_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockZoneChecker) EXPECT() *MockZoneChecker_Expecter {
+	return &MockZoneChecker_Expecter{mock: &_m.Mock}
+}
+
+// HasZone provides a mock function with given fields: name
+func (_m *MockZoneChecker) HasZone(name string) bool {
+	ret := _m.Called(name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasZone")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// MockZoneChecker_HasZone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasZone'
+type MockZoneChecker_HasZone_Call struct {
+	*mock.Call
+}
+
+// HasZone is a helper method to define mock.On call
+//   - name string
+func (_e *MockZoneChecker_Expecter) HasZone(name interface{}) *MockZoneChecker_HasZone_Call {
+	return &MockZoneChecker_HasZone_Call{Call: _e.mock.On("HasZone", name)}
+}
+
+func (_c *MockZoneChecker_HasZone_Call) Run(run func(name string)) *MockZoneChecker_HasZone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockZoneChecker_HasZone_Call) Return(_a0 bool) *MockZoneChecker_HasZone_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockZoneChecker_HasZone_Call) RunAndReturn(run func(string) bool) *MockZoneChecker_HasZone_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockZoneChecker creates a new instance of MockZoneChecker. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockZoneChecker(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockZoneChecker {
+	mock := &MockZoneChecker{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -2,7 +2,11 @@
 
 package mock_dagsmart
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // MockService is an autogenerated mock type for the Service type
 type MockService struct {
@@ -17,9 +21,9 @@ func (_m *MockService) EXPECT() *MockService_Expecter {
 	return &MockService_Expecter{mock: &_m.Mock}
 }
 
-// Get provides a mock function with given fields: year
-func (_m *MockService) Get(year int) ([]string, error) {
-	ret := _m.Called(year)
+// Get provides a mock function with given fields: ctx, year
+func (_m *MockService) Get(ctx context.Context, year int) ([]string, error) {
+	ret := _m.Called(ctx, year)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Get")
@@ -27,19 +31,19 @@ func (_m *MockService) Get(year int) ([]string, error) {
 
 	var r0 []string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int) ([]string, error)); ok {
-		return rf(year)
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]string, error)); ok {
+		return rf(ctx, year)
 	}
-	if rf, ok := ret.Get(0).(func(int) []string); ok {
-		r0 = rf(year)
+	if rf, ok := ret.Get(0).(func(context.Context, int) []string); ok {
+		r0 = rf(ctx, year)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]string)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(year)
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, year)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -53,14 +57,15 @@ type MockService_Get_Call struct {
 }
 
 // Get is a helper method to define mock.On call
+//   - ctx context.Context
 //   - year int
-func (_e *MockService_Expecter) Get(year interface{}) *MockService_Get_Call {
-	return &MockService_Get_Call{Call: _e.mock.On("Get", year)}
+func (_e *MockService_Expecter) Get(ctx interface{}, year interface{}) *MockService_Get_Call {
+	return &MockService_Get_Call{Call: _e.mock.On("Get", ctx, year)}
 }
 
-func (_c *MockService_Get_Call) Run(run func(year int)) *MockService_Get_Call {
+func (_c *MockService_Get_Call) Run(run func(ctx context.Context, year int)) *MockService_Get_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(int))
+		run(args[0].(context.Context), args[1].(int))
 	})
 	return _c
 }
@@ -70,7 +75,7 @@ func (_c *MockService_Get_Call) Return(_a0 []string, _a1 error) *MockService_Get
 	return _c
 }
 
-func (_c *MockService_Get_Call) RunAndReturn(run func(int) ([]string, error)) *MockService_Get_Call {
+func (_c *MockService_Get_Call) RunAndReturn(run func(context.Context, int) ([]string, error)) *MockService_Get_Call {
 	_c.Call.Return(run)
 	return _c
 }
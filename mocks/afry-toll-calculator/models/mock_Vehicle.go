@@ -4,6 +4,7 @@ package mock_models
 
 import (
 	models "afry-toll-calculator/models"
+	time "time"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -21,6 +22,51 @@ func (_m *MockVehicle) EXPECT() *MockVehicle_Expecter {
 	return &MockVehicle_Expecter{mock: &_m.Mock}
 }
 
+// GetEmissionClass provides a mock function with no fields
+func (_m *MockVehicle) GetEmissionClass() models.EmissionClass {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEmissionClass")
+	}
+
+	var r0 models.EmissionClass
+	if rf, ok := ret.Get(0).(func() models.EmissionClass); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(models.EmissionClass)
+	}
+
+	return r0
+}
+
+// MockVehicle_GetEmissionClass_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEmissionClass'
+type MockVehicle_GetEmissionClass_Call struct {
+	*mock.Call
+}
+
+// GetEmissionClass is a helper method to define mock.On call
+func (_e *MockVehicle_Expecter) GetEmissionClass() *MockVehicle_GetEmissionClass_Call {
+	return &MockVehicle_GetEmissionClass_Call{Call: _e.mock.On("GetEmissionClass")}
+}
+
+func (_c *MockVehicle_GetEmissionClass_Call) Run(run func()) *MockVehicle_GetEmissionClass_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockVehicle_GetEmissionClass_Call) Return(_a0 models.EmissionClass) *MockVehicle_GetEmissionClass_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockVehicle_GetEmissionClass_Call) RunAndReturn(run func() models.EmissionClass) *MockVehicle_GetEmissionClass_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetType provides a mock function with no fields
 func (_m *MockVehicle) GetType() models.VehicleType {
 	ret := _m.Called()
@@ -66,6 +112,51 @@ func (_c *MockVehicle_GetType_Call) RunAndReturn(run func() models.VehicleType)
 	return _c
 }
 
+// GetWeightClass provides a mock function with no fields
+func (_m *MockVehicle) GetWeightClass() models.WeightClass {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWeightClass")
+	}
+
+	var r0 models.WeightClass
+	if rf, ok := ret.Get(0).(func() models.WeightClass); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(models.WeightClass)
+	}
+
+	return r0
+}
+
+// MockVehicle_GetWeightClass_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWeightClass'
+type MockVehicle_GetWeightClass_Call struct {
+	*mock.Call
+}
+
+// GetWeightClass is a helper method to define mock.On call
+func (_e *MockVehicle_Expecter) GetWeightClass() *MockVehicle_GetWeightClass_Call {
+	return &MockVehicle_GetWeightClass_Call{Call: _e.mock.On("GetWeightClass")}
+}
+
+func (_c *MockVehicle_GetWeightClass_Call) Run(run func()) *MockVehicle_GetWeightClass_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockVehicle_GetWeightClass_Call) Return(_a0 models.WeightClass) *MockVehicle_GetWeightClass_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockVehicle_GetWeightClass_Call) RunAndReturn(run func() models.WeightClass) *MockVehicle_GetWeightClass_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // IsTollFree provides a mock function with no fields
 func (_m *MockVehicle) IsTollFree() bool {
 	ret := _m.Called()
@@ -111,6 +202,52 @@ func (_c *MockVehicle_IsTollFree_Call) RunAndReturn(run func() bool) *MockVehicl
 	return _c
 }
 
+// IsTollFreeAt provides a mock function with given fields: t
+func (_m *MockVehicle) IsTollFreeAt(t time.Time) bool {
+	ret := _m.Called(t)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsTollFreeAt")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(time.Time) bool); ok {
+		r0 = rf(t)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// MockVehicle_IsTollFreeAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsTollFreeAt'
+type MockVehicle_IsTollFreeAt_Call struct {
+	*mock.Call
+}
+
+// IsTollFreeAt is a helper method to define mock.On call
+//   - t time.Time
+func (_e *MockVehicle_Expecter) IsTollFreeAt(t interface{}) *MockVehicle_IsTollFreeAt_Call {
+	return &MockVehicle_IsTollFreeAt_Call{Call: _e.mock.On("IsTollFreeAt", t)}
+}
+
+func (_c *MockVehicle_IsTollFreeAt_Call) Run(run func(t time.Time)) *MockVehicle_IsTollFreeAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockVehicle_IsTollFreeAt_Call) Return(_a0 bool) *MockVehicle_IsTollFreeAt_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockVehicle_IsTollFreeAt_Call) RunAndReturn(run func(time.Time) bool) *MockVehicle_IsTollFreeAt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockVehicle creates a new instance of MockVehicle. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockVehicle(t interface {
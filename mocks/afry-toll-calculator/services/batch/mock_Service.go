@@ -0,0 +1,86 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mock_batch
+
+import (
+	batch "afry-toll-calculator/services/batch"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockService is an autogenerated mock type for the Service type
+type MockService struct {
+	mock.Mock
+}
+
+type MockService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockService) EXPECT() *MockService_Expecter {
+	return &MockService_Expecter{mock: &_m.Mock}
+}
+
+// Calculate provides a mock function with given fields: ctx, items
+func (_m *MockService) Calculate(ctx context.Context, items []batch.Item) []batch.Result {
+	ret := _m.Called(ctx, items)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Calculate")
+	}
+
+	var r0 []batch.Result
+	if rf, ok := ret.Get(0).(func(context.Context, []batch.Item) []batch.Result); ok {
+		r0 = rf(ctx, items)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]batch.Result)
+		}
+	}
+
+	return r0
+}
+
+// MockService_Calculate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Calculate'
+type MockService_Calculate_Call struct {
+	*mock.Call
+}
+
+// Calculate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - items []batch.Item
+func (_e *MockService_Expecter) Calculate(ctx interface{}, items interface{}) *MockService_Calculate_Call {
+	return &MockService_Calculate_Call{Call: _e.mock.On("Calculate", ctx, items)}
+}
+
+func (_c *MockService_Calculate_Call) Run(run func(ctx context.Context, items []batch.Item)) *MockService_Calculate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]batch.Item))
+	})
+	return _c
+}
+
+func (_c *MockService_Calculate_Call) Return(_a0 []batch.Result) *MockService_Calculate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockService_Calculate_Call) RunAndReturn(run func(context.Context, []batch.Item) []batch.Result) *MockService_Calculate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockService creates a new instance of MockService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockService {
+	mock := &MockService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,94 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mock_calendar
+
+import (
+	context "context"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockHolidays is an autogenerated mock type for the Holidays type
+type MockHolidays struct {
+	mock.Mock
+}
+
+type MockHolidays_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockHolidays) EXPECT() *MockHolidays_Expecter {
+	return &MockHolidays_Expecter{mock: &_m.Mock}
+}
+
+// IsHoliday provides a mock function with given fields: ctx, t
+func (_m *MockHolidays) IsHoliday(ctx context.Context, t time.Time) (bool, error) {
+	ret := _m.Called(ctx, t)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsHoliday")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (bool, error)); ok {
+		return rf(ctx, t)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) bool); ok {
+		r0 = rf(ctx, t)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, t)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockHolidays_IsHoliday_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsHoliday'
+type MockHolidays_IsHoliday_Call struct {
+	*mock.Call
+}
+
+// IsHoliday is a helper method to define mock.On call
+//   - ctx context.Context
+//   - t time.Time
+func (_e *MockHolidays_Expecter) IsHoliday(ctx interface{}, t interface{}) *MockHolidays_IsHoliday_Call {
+	return &MockHolidays_IsHoliday_Call{Call: _e.mock.On("IsHoliday", ctx, t)}
+}
+
+func (_c *MockHolidays_IsHoliday_Call) Run(run func(ctx context.Context, t time.Time)) *MockHolidays_IsHoliday_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockHolidays_IsHoliday_Call) Return(_a0 bool, _a1 error) *MockHolidays_IsHoliday_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockHolidays_IsHoliday_Call) RunAndReturn(run func(context.Context, time.Time) (bool, error)) *MockHolidays_IsHoliday_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockHolidays creates a new instance of MockHolidays. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockHolidays(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockHolidays {
+	mock := &MockHolidays{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
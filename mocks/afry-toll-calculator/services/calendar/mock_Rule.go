@@ -0,0 +1,141 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mock_calendar
+
+import (
+	calendar "afry-toll-calculator/services/calendar"
+	context "context"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRule is an autogenerated mock type for the Rule type
+type MockRule struct {
+	mock.Mock
+}
+
+type MockRule_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRule) EXPECT() *MockRule_Expecter {
+	return &MockRule_Expecter{mock: &_m.Mock}
+}
+
+// Exempt provides a mock function with given fields: ctx, t, holidays
+func (_m *MockRule) Exempt(ctx context.Context, t time.Time, holidays calendar.Holidays) (bool, error) {
+	ret := _m.Called(ctx, t, holidays)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exempt")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, calendar.Holidays) (bool, error)); ok {
+		return rf(ctx, t, holidays)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, calendar.Holidays) bool); ok {
+		r0 = rf(ctx, t, holidays)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, calendar.Holidays) error); ok {
+		r1 = rf(ctx, t, holidays)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRule_Exempt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exempt'
+type MockRule_Exempt_Call struct {
+	*mock.Call
+}
+
+// Exempt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - t time.Time
+//   - holidays calendar.Holidays
+func (_e *MockRule_Expecter) Exempt(ctx interface{}, t interface{}, holidays interface{}) *MockRule_Exempt_Call {
+	return &MockRule_Exempt_Call{Call: _e.mock.On("Exempt", ctx, t, holidays)}
+}
+
+func (_c *MockRule_Exempt_Call) Run(run func(ctx context.Context, t time.Time, holidays calendar.Holidays)) *MockRule_Exempt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(calendar.Holidays))
+	})
+	return _c
+}
+
+func (_c *MockRule_Exempt_Call) Return(_a0 bool, _a1 error) *MockRule_Exempt_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRule_Exempt_Call) RunAndReturn(run func(context.Context, time.Time, calendar.Holidays) (bool, error)) *MockRule_Exempt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Reason provides a mock function with no fields
+func (_m *MockRule) Reason() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reason")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MockRule_Reason_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reason'
+type MockRule_Reason_Call struct {
+	*mock.Call
+}
+
+// Reason is a helper method to define mock.On call
+func (_e *MockRule_Expecter) Reason() *MockRule_Reason_Call {
+	return &MockRule_Reason_Call{Call: _e.mock.On("Reason")}
+}
+
+func (_c *MockRule_Reason_Call) Run(run func()) *MockRule_Reason_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockRule_Reason_Call) Return(_a0 string) *MockRule_Reason_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRule_Reason_Call) RunAndReturn(run func() string) *MockRule_Reason_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRule creates a new instance of MockRule. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRule(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRule {
+	mock := &MockRule{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
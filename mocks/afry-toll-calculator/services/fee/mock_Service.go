@@ -4,10 +4,11 @@ package mock_fee
 
 import (
 	models "afry-toll-calculator/models"
+	fee "afry-toll-calculator/services/fee"
+	holidays "afry-toll-calculator/services/holidays"
+	time "time"
 
 	mock "github.com/stretchr/testify/mock"
-
-	time "time"
 )
 
 // MockService is an autogenerated mock type for the Service type
@@ -23,6 +24,177 @@ func (_m *MockService) EXPECT() *MockService_Expecter {
 	return &MockService_Expecter{mock: &_m.Mock}
 }
 
+// Explain provides a mock function with given fields: vehicleType, entryDates
+func (_m *MockService) Explain(vehicleType models.VehicleType, entryDates []time.Time) (fee.Explanation, error) {
+	ret := _m.Called(vehicleType, entryDates)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Explain")
+	}
+
+	var r0 fee.Explanation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(models.VehicleType, []time.Time) (fee.Explanation, error)); ok {
+		return rf(vehicleType, entryDates)
+	}
+	if rf, ok := ret.Get(0).(func(models.VehicleType, []time.Time) fee.Explanation); ok {
+		r0 = rf(vehicleType, entryDates)
+	} else {
+		r0 = ret.Get(0).(fee.Explanation)
+	}
+
+	if rf, ok := ret.Get(1).(func(models.VehicleType, []time.Time) error); ok {
+		r1 = rf(vehicleType, entryDates)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_Explain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Explain'
+type MockService_Explain_Call struct {
+	*mock.Call
+}
+
+// Explain is a helper method to define mock.On call
+//   - vehicleType models.VehicleType
+//   - entryDates []time.Time
+func (_e *MockService_Expecter) Explain(vehicleType interface{}, entryDates interface{}) *MockService_Explain_Call {
+	return &MockService_Explain_Call{Call: _e.mock.On("Explain", vehicleType, entryDates)}
+}
+
+func (_c *MockService_Explain_Call) Run(run func(vehicleType models.VehicleType, entryDates []time.Time)) *MockService_Explain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(models.VehicleType), args[1].([]time.Time))
+	})
+	return _c
+}
+
+func (_c *MockService_Explain_Call) Return(_a0 fee.Explanation, _a1 error) *MockService_Explain_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_Explain_Call) RunAndReturn(run func(models.VehicleType, []time.Time) (fee.Explanation, error)) *MockService_Explain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExplainPassages provides a mock function with given fields: vehicle, passages
+func (_m *MockService) ExplainPassages(vehicle models.Vehicle, passages []models.Passage) (fee.Explanation, error) {
+	ret := _m.Called(vehicle, passages)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExplainPassages")
+	}
+
+	var r0 fee.Explanation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(models.Vehicle, []models.Passage) (fee.Explanation, error)); ok {
+		return rf(vehicle, passages)
+	}
+	if rf, ok := ret.Get(0).(func(models.Vehicle, []models.Passage) fee.Explanation); ok {
+		r0 = rf(vehicle, passages)
+	} else {
+		r0 = ret.Get(0).(fee.Explanation)
+	}
+
+	if rf, ok := ret.Get(1).(func(models.Vehicle, []models.Passage) error); ok {
+		r1 = rf(vehicle, passages)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_ExplainPassages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExplainPassages'
+type MockService_ExplainPassages_Call struct {
+	*mock.Call
+}
+
+// ExplainPassages is a helper method to define mock.On call
+//   - vehicle models.Vehicle
+//   - passages []models.Passage
+func (_e *MockService_Expecter) ExplainPassages(vehicle interface{}, passages interface{}) *MockService_ExplainPassages_Call {
+	return &MockService_ExplainPassages_Call{Call: _e.mock.On("ExplainPassages", vehicle, passages)}
+}
+
+func (_c *MockService_ExplainPassages_Call) Run(run func(vehicle models.Vehicle, passages []models.Passage)) *MockService_ExplainPassages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(models.Vehicle), args[1].([]models.Passage))
+	})
+	return _c
+}
+
+func (_c *MockService_ExplainPassages_Call) Return(_a0 fee.Explanation, _a1 error) *MockService_ExplainPassages_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_ExplainPassages_Call) RunAndReturn(run func(models.Vehicle, []models.Passage) (fee.Explanation, error)) *MockService_ExplainPassages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExplainVehicle provides a mock function with given fields: vehicle, entryDates
+func (_m *MockService) ExplainVehicle(vehicle models.Vehicle, entryDates []time.Time) (fee.Explanation, error) {
+	ret := _m.Called(vehicle, entryDates)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExplainVehicle")
+	}
+
+	var r0 fee.Explanation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(models.Vehicle, []time.Time) (fee.Explanation, error)); ok {
+		return rf(vehicle, entryDates)
+	}
+	if rf, ok := ret.Get(0).(func(models.Vehicle, []time.Time) fee.Explanation); ok {
+		r0 = rf(vehicle, entryDates)
+	} else {
+		r0 = ret.Get(0).(fee.Explanation)
+	}
+
+	if rf, ok := ret.Get(1).(func(models.Vehicle, []time.Time) error); ok {
+		r1 = rf(vehicle, entryDates)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_ExplainVehicle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExplainVehicle'
+type MockService_ExplainVehicle_Call struct {
+	*mock.Call
+}
+
+// ExplainVehicle is a helper method to define mock.On call
+//   - vehicle models.Vehicle
+//   - entryDates []time.Time
+func (_e *MockService_Expecter) ExplainVehicle(vehicle interface{}, entryDates interface{}) *MockService_ExplainVehicle_Call {
+	return &MockService_ExplainVehicle_Call{Call: _e.mock.On("ExplainVehicle", vehicle, entryDates)}
+}
+
+func (_c *MockService_ExplainVehicle_Call) Run(run func(vehicle models.Vehicle, entryDates []time.Time)) *MockService_ExplainVehicle_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(models.Vehicle), args[1].([]time.Time))
+	})
+	return _c
+}
+
+func (_c *MockService_ExplainVehicle_Call) Return(_a0 fee.Explanation, _a1 error) *MockService_ExplainVehicle_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_ExplainVehicle_Call) RunAndReturn(run func(models.Vehicle, []time.Time) (fee.Explanation, error)) *MockService_ExplainVehicle_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetFee provides a mock function with given fields: vehicleType, entryDates
 func (_m *MockService) GetFee(vehicleType models.VehicleType, entryDates []time.Time) (int, error) {
 	ret := _m.Called(vehicleType, entryDates)
@@ -80,6 +252,180 @@ func (_c *MockService_GetFee_Call) RunAndReturn(run func(models.VehicleType, []t
 	return _c
 }
 
+// GetFees provides a mock function with given fields: vehicleType, entryDates
+func (_m *MockService) GetFees(vehicleType models.VehicleType, entryDates []time.Time) (fee.Summary, error) {
+	ret := _m.Called(vehicleType, entryDates)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFees")
+	}
+
+	var r0 fee.Summary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(models.VehicleType, []time.Time) (fee.Summary, error)); ok {
+		return rf(vehicleType, entryDates)
+	}
+	if rf, ok := ret.Get(0).(func(models.VehicleType, []time.Time) fee.Summary); ok {
+		r0 = rf(vehicleType, entryDates)
+	} else {
+		r0 = ret.Get(0).(fee.Summary)
+	}
+
+	if rf, ok := ret.Get(1).(func(models.VehicleType, []time.Time) error); ok {
+		r1 = rf(vehicleType, entryDates)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_GetFees_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFees'
+type MockService_GetFees_Call struct {
+	*mock.Call
+}
+
+// GetFees is a helper method to define mock.On call
+//   - vehicleType models.VehicleType
+//   - entryDates []time.Time
+func (_e *MockService_Expecter) GetFees(vehicleType interface{}, entryDates interface{}) *MockService_GetFees_Call {
+	return &MockService_GetFees_Call{Call: _e.mock.On("GetFees", vehicleType, entryDates)}
+}
+
+func (_c *MockService_GetFees_Call) Run(run func(vehicleType models.VehicleType, entryDates []time.Time)) *MockService_GetFees_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(models.VehicleType), args[1].([]time.Time))
+	})
+	return _c
+}
+
+func (_c *MockService_GetFees_Call) Return(_a0 fee.Summary, _a1 error) *MockService_GetFees_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_GetFees_Call) RunAndReturn(run func(models.VehicleType, []time.Time) (fee.Summary, error)) *MockService_GetFees_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPassageFees provides a mock function with given fields: vehicleType, passages
+func (_m *MockService) GetPassageFees(vehicleType models.VehicleType, passages []models.Passage) (fee.Summary, error) {
+	ret := _m.Called(vehicleType, passages)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPassageFees")
+	}
+
+	var r0 fee.Summary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(models.VehicleType, []models.Passage) (fee.Summary, error)); ok {
+		return rf(vehicleType, passages)
+	}
+	if rf, ok := ret.Get(0).(func(models.VehicleType, []models.Passage) fee.Summary); ok {
+		r0 = rf(vehicleType, passages)
+	} else {
+		r0 = ret.Get(0).(fee.Summary)
+	}
+
+	if rf, ok := ret.Get(1).(func(models.VehicleType, []models.Passage) error); ok {
+		r1 = rf(vehicleType, passages)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_GetPassageFees_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPassageFees'
+type MockService_GetPassageFees_Call struct {
+	*mock.Call
+}
+
+// GetPassageFees is a helper method to define mock.On call
+//   - vehicleType models.VehicleType
+//   - passages []models.Passage
+func (_e *MockService_Expecter) GetPassageFees(vehicleType interface{}, passages interface{}) *MockService_GetPassageFees_Call {
+	return &MockService_GetPassageFees_Call{Call: _e.mock.On("GetPassageFees", vehicleType, passages)}
+}
+
+func (_c *MockService_GetPassageFees_Call) Run(run func(vehicleType models.VehicleType, passages []models.Passage)) *MockService_GetPassageFees_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(models.VehicleType), args[1].([]models.Passage))
+	})
+	return _c
+}
+
+func (_c *MockService_GetPassageFees_Call) Return(_a0 fee.Summary, _a1 error) *MockService_GetPassageFees_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_GetPassageFees_Call) RunAndReturn(run func(models.VehicleType, []models.Passage) (fee.Summary, error)) *MockService_GetPassageFees_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PrepareReload provides a mock function with given fields: tariff, vehicles, holidayCache
+func (_m *MockService) PrepareReload(tariff fee.Tariff, vehicles []models.Vehicle, holidayCache *holidays.Cache) (func(), error) {
+	ret := _m.Called(tariff, vehicles, holidayCache)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PrepareReload")
+	}
+
+	var r0 func()
+	var r1 error
+	if rf, ok := ret.Get(0).(func(fee.Tariff, []models.Vehicle, *holidays.Cache) (func(), error)); ok {
+		return rf(tariff, vehicles, holidayCache)
+	}
+	if rf, ok := ret.Get(0).(func(fee.Tariff, []models.Vehicle, *holidays.Cache) func()); ok {
+		r0 = rf(tariff, vehicles, holidayCache)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(func())
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(fee.Tariff, []models.Vehicle, *holidays.Cache) error); ok {
+		r1 = rf(tariff, vehicles, holidayCache)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_PrepareReload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PrepareReload'
+type MockService_PrepareReload_Call struct {
+	*mock.Call
+}
+
+// PrepareReload is a helper method to define mock.On call
+//   - tariff fee.Tariff
+//   - vehicles []models.Vehicle
+//   - holidayCache *holidays.Cache
+func (_e *MockService_Expecter) PrepareReload(tariff interface{}, vehicles interface{}, holidayCache interface{}) *MockService_PrepareReload_Call {
+	return &MockService_PrepareReload_Call{Call: _e.mock.On("PrepareReload", tariff, vehicles, holidayCache)}
+}
+
+func (_c *MockService_PrepareReload_Call) Run(run func(tariff fee.Tariff, vehicles []models.Vehicle, holidayCache *holidays.Cache)) *MockService_PrepareReload_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(fee.Tariff), args[1].([]models.Vehicle), args[2].(*holidays.Cache))
+	})
+	return _c
+}
+
+func (_c *MockService_PrepareReload_Call) Return(_a0 func(), _a1 error) *MockService_PrepareReload_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_PrepareReload_Call) RunAndReturn(run func(fee.Tariff, []models.Vehicle, *holidays.Cache) (func(), error)) *MockService_PrepareReload_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockService creates a new instance of MockService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockService(t interface {
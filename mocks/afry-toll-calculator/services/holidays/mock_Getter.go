@@ -2,7 +2,11 @@
 
 package mock_holidays
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // MockGetter is an autogenerated mock type for the Getter type
 type MockGetter struct {
@@ -17,9 +21,9 @@ func (_m *MockGetter) EXPECT() *MockGetter_Expecter {
 	return &MockGetter_Expecter{mock: &_m.Mock}
 }
 
-// Get provides a mock function with given fields: year
-func (_m *MockGetter) Get(year int) ([]string, error) {
-	ret := _m.Called(year)
+// Get provides a mock function with given fields: ctx, year
+func (_m *MockGetter) Get(ctx context.Context, year int) ([]string, error) {
+	ret := _m.Called(ctx, year)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Get")
@@ -27,19 +31,19 @@ func (_m *MockGetter) Get(year int) ([]string, error) {
 
 	var r0 []string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int) ([]string, error)); ok {
-		return rf(year)
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]string, error)); ok {
+		return rf(ctx, year)
 	}
-	if rf, ok := ret.Get(0).(func(int) []string); ok {
-		r0 = rf(year)
+	if rf, ok := ret.Get(0).(func(context.Context, int) []string); ok {
+		r0 = rf(ctx, year)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]string)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(year)
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, year)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -53,14 +57,15 @@ type MockGetter_Get_Call struct {
 }
 
 // Get is a helper method to define mock.On call
+//   - ctx context.Context
 //   - year int
-func (_e *MockGetter_Expecter) Get(year interface{}) *MockGetter_Get_Call {
-	return &MockGetter_Get_Call{Call: _e.mock.On("Get", year)}
+func (_e *MockGetter_Expecter) Get(ctx interface{}, year interface{}) *MockGetter_Get_Call {
+	return &MockGetter_Get_Call{Call: _e.mock.On("Get", ctx, year)}
 }
 
-func (_c *MockGetter_Get_Call) Run(run func(year int)) *MockGetter_Get_Call {
+func (_c *MockGetter_Get_Call) Run(run func(ctx context.Context, year int)) *MockGetter_Get_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(int))
+		run(args[0].(context.Context), args[1].(int))
 	})
 	return _c
 }
@@ -70,7 +75,7 @@ func (_c *MockGetter_Get_Call) Return(_a0 []string, _a1 error) *MockGetter_Get_C
 	return _c
 }
 
-func (_c *MockGetter_Get_Call) RunAndReturn(run func(int) ([]string, error)) *MockGetter_Get_Call {
+func (_c *MockGetter_Get_Call) RunAndReturn(run func(context.Context, int) ([]string, error)) *MockGetter_Get_Call {
 	_c.Call.Return(run)
 	return _c
 }
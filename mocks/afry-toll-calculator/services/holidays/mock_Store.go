@@ -0,0 +1,145 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mock_holidays
+
+import (
+	models "afry-toll-calculator/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockStore is an autogenerated mock type for the Store type
+type MockStore struct {
+	mock.Mock
+}
+
+type MockStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockStore) EXPECT() *MockStore_Expecter {
+	return &MockStore_Expecter{mock: &_m.Mock}
+}
+
+// Load provides a mock function with given fields: year
+func (_m *MockStore) Load(year int) (models.HolidayRecord, bool, error) {
+	ret := _m.Called(year)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Load")
+	}
+
+	var r0 models.HolidayRecord
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(int) (models.HolidayRecord, bool, error)); ok {
+		return rf(year)
+	}
+	if rf, ok := ret.Get(0).(func(int) models.HolidayRecord); ok {
+		r0 = rf(year)
+	} else {
+		r0 = ret.Get(0).(models.HolidayRecord)
+	}
+
+	if rf, ok := ret.Get(1).(func(int) bool); ok {
+		r1 = rf(year)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(int) error); ok {
+		r2 = rf(year)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockStore_Load_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Load'
+type MockStore_Load_Call struct {
+	*mock.Call
+}
+
+// Load is a helper method to define mock.On call
+//   - year int
+func (_e *MockStore_Expecter) Load(year interface{}) *MockStore_Load_Call {
+	return &MockStore_Load_Call{Call: _e.mock.On("Load", year)}
+}
+
+func (_c *MockStore_Load_Call) Run(run func(year int)) *MockStore_Load_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *MockStore_Load_Call) Return(_a0 models.HolidayRecord, _a1 bool, _a2 error) *MockStore_Load_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockStore_Load_Call) RunAndReturn(run func(int) (models.HolidayRecord, bool, error)) *MockStore_Load_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function with given fields: record
+func (_m *MockStore) Save(record models.HolidayRecord) error {
+	ret := _m.Called(record)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(models.HolidayRecord) error); ok {
+		r0 = rf(record)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type MockStore_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - record models.HolidayRecord
+func (_e *MockStore_Expecter) Save(record interface{}) *MockStore_Save_Call {
+	return &MockStore_Save_Call{Call: _e.mock.On("Save", record)}
+}
+
+func (_c *MockStore_Save_Call) Run(run func(record models.HolidayRecord)) *MockStore_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(models.HolidayRecord))
+	})
+	return _c
+}
+
+func (_c *MockStore_Save_Call) Return(_a0 error) *MockStore_Save_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_Save_Call) RunAndReturn(run func(models.HolidayRecord) error) *MockStore_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockStore creates a new instance of MockStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockStore {
+	mock := &MockStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
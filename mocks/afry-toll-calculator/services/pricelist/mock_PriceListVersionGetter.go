@@ -0,0 +1,83 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mock_pricelist
+
+import (
+	pricelist "afry-toll-calculator/services/pricelist"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockPriceListVersionGetter is an autogenerated mock type for the PriceListVersionGetter type
+type MockPriceListVersionGetter struct {
+	mock.Mock
+}
+
+type MockPriceListVersionGetter_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPriceListVersionGetter) EXPECT() *MockPriceListVersionGetter_Expecter {
+	return &MockPriceListVersionGetter_Expecter{mock: &_m.Mock}
+}
+
+// GetPriceListVersions provides a mock function with no fields
+func (_m *MockPriceListVersionGetter) GetPriceListVersions() []pricelist.PriceListVersion {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPriceListVersions")
+	}
+
+	var r0 []pricelist.PriceListVersion
+	if rf, ok := ret.Get(0).(func() []pricelist.PriceListVersion); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]pricelist.PriceListVersion)
+		}
+	}
+
+	return r0
+}
+
+// MockPriceListVersionGetter_GetPriceListVersions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPriceListVersions'
+type MockPriceListVersionGetter_GetPriceListVersions_Call struct {
+	*mock.Call
+}
+
+// GetPriceListVersions is a helper method to define mock.On call
+func (_e *MockPriceListVersionGetter_Expecter) GetPriceListVersions() *MockPriceListVersionGetter_GetPriceListVersions_Call {
+	return &MockPriceListVersionGetter_GetPriceListVersions_Call{Call: _e.mock.On("GetPriceListVersions")}
+}
+
+func (_c *MockPriceListVersionGetter_GetPriceListVersions_Call) Run(run func()) *MockPriceListVersionGetter_GetPriceListVersions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockPriceListVersionGetter_GetPriceListVersions_Call) Return(_a0 []pricelist.PriceListVersion) *MockPriceListVersionGetter_GetPriceListVersions_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPriceListVersionGetter_GetPriceListVersions_Call) RunAndReturn(run func() []pricelist.PriceListVersion) *MockPriceListVersionGetter_GetPriceListVersions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPriceListVersionGetter creates a new instance of MockPriceListVersionGetter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPriceListVersionGetter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPriceListVersionGetter {
+	mock := &MockPriceListVersionGetter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
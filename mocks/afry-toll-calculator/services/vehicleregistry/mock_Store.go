@@ -0,0 +1,139 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mock_vehicleregistry
+
+import (
+	vehicleregistry "afry-toll-calculator/services/vehicleregistry"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockStore is an autogenerated mock type for the Store type
+type MockStore struct {
+	mock.Mock
+}
+
+type MockStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockStore) EXPECT() *MockStore_Expecter {
+	return &MockStore_Expecter{mock: &_m.Mock}
+}
+
+// Lookup provides a mock function with given fields: number, at
+func (_m *MockStore) Lookup(number string, at time.Time) (vehicleregistry.Registration, error) {
+	ret := _m.Called(number, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Lookup")
+	}
+
+	var r0 vehicleregistry.Registration
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, time.Time) (vehicleregistry.Registration, error)); ok {
+		return rf(number, at)
+	}
+	if rf, ok := ret.Get(0).(func(string, time.Time) vehicleregistry.Registration); ok {
+		r0 = rf(number, at)
+	} else {
+		r0 = ret.Get(0).(vehicleregistry.Registration)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, time.Time) error); ok {
+		r1 = rf(number, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_Lookup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Lookup'
+type MockStore_Lookup_Call struct {
+	*mock.Call
+}
+
+// Lookup is a helper method to define mock.On call
+//   - number string
+//   - at time.Time
+func (_e *MockStore_Expecter) Lookup(number interface{}, at interface{}) *MockStore_Lookup_Call {
+	return &MockStore_Lookup_Call{Call: _e.mock.On("Lookup", number, at)}
+}
+
+func (_c *MockStore_Lookup_Call) Run(run func(number string, at time.Time)) *MockStore_Lookup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockStore_Lookup_Call) Return(_a0 vehicleregistry.Registration, _a1 error) *MockStore_Lookup_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_Lookup_Call) RunAndReturn(run func(string, time.Time) (vehicleregistry.Registration, error)) *MockStore_Lookup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Reload provides a mock function with no fields
+func (_m *MockStore) Reload() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reload")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_Reload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reload'
+type MockStore_Reload_Call struct {
+	*mock.Call
+}
+
+// Reload is a helper method to define mock.On call
+func (_e *MockStore_Expecter) Reload() *MockStore_Reload_Call {
+	return &MockStore_Reload_Call{Call: _e.mock.On("Reload")}
+}
+
+func (_c *MockStore_Reload_Call) Run(run func()) *MockStore_Reload_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockStore_Reload_Call) Return(_a0 error) *MockStore_Reload_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_Reload_Call) RunAndReturn(run func() error) *MockStore_Reload_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockStore creates a new instance of MockStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockStore {
+	mock := &MockStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,93 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mock_zone
+
+import (
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockResolver is an autogenerated mock type for the Resolver type
+type MockResolver struct {
+	mock.Mock
+}
+
+type MockResolver_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockResolver) EXPECT() *MockResolver_Expecter {
+	return &MockResolver_Expecter{mock: &_m.Mock}
+}
+
+// Zone provides a mock function with given fields: gantry, at
+func (_m *MockResolver) Zone(gantry string, at time.Time) (string, bool) {
+	ret := _m.Called(gantry, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Zone")
+	}
+
+	var r0 string
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(string, time.Time) (string, bool)); ok {
+		return rf(gantry, at)
+	}
+	if rf, ok := ret.Get(0).(func(string, time.Time) string); ok {
+		r0 = rf(gantry, at)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, time.Time) bool); ok {
+		r1 = rf(gantry, at)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// MockResolver_Zone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Zone'
+type MockResolver_Zone_Call struct {
+	*mock.Call
+}
+
+// Zone is a helper method to define mock.On call
+//   - gantry string
+//   - at time.Time
+func (_e *MockResolver_Expecter) Zone(gantry interface{}, at interface{}) *MockResolver_Zone_Call {
+	return &MockResolver_Zone_Call{Call: _e.mock.On("Zone", gantry, at)}
+}
+
+func (_c *MockResolver_Zone_Call) Run(run func(gantry string, at time.Time)) *MockResolver_Zone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockResolver_Zone_Call) Return(_a0 string, _a1 bool) *MockResolver_Zone_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockResolver_Zone_Call) RunAndReturn(run func(string, time.Time) (string, bool)) *MockResolver_Zone_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockResolver creates a new instance of MockResolver. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockResolver(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockResolver {
+	mock := &MockResolver{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// HolidayRecord is the public holiday data of a single year, together with where and when it was fetched.
+type HolidayRecord struct {
+	Year      int       `json:"year"`
+	Source    string    `json:"source"`
+	FetchedAt time.Time `json:"fetchedAt"`
+	Dates     []string  `json:"dates"`
+}
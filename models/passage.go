@@ -0,0 +1,9 @@
+package models
+
+import "time"
+
+// Passage is a vehicle passing a gantry at a point in time.
+type Passage struct {
+	Gantry    string    `json:"gantry"`
+	Timestamp time.Time `json:"timestamp"`
+}
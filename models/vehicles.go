@@ -1,19 +1,83 @@
 package models
 
+import "time"
+
 type VehicleType string
 
+// WeightClass groups vehicle types by weight, such as WeightClassLight and WeightClassHeavy. Tariffs may define
+// further classes.
+type WeightClass string
+
+const (
+	WeightClassLight WeightClass = "light"
+	WeightClassHeavy WeightClass = "heavy"
+)
+
+// EmissionClass groups vehicle types by fuel and emissions, such as EmissionClassElectric. Tariffs may define
+// further classes, such as Euro emission standards.
+type EmissionClass string
+
+const (
+	EmissionClassElectric EmissionClass = "electric"
+	EmissionClassHybrid   EmissionClass = "hybrid"
+	EmissionClassPetrol   EmissionClass = "petrol"
+	EmissionClassDiesel   EmissionClass = "diesel"
+)
+
+// Exemption is the period in which a toll-free vehicle is exempt, from From (inclusive) until To (exclusive).
+// A zero From or To leaves the period open at that end, so the zero Exemption covers all times.
+type Exemption struct {
+	From time.Time
+	To   time.Time
+}
+
+// Covers reports whether t is within the exemption period.
+func (e Exemption) Covers(t time.Time) bool {
+	return (e.From.IsZero() || !t.Before(e.From)) && (e.To.IsZero() || t.Before(e.To))
+}
+
 type Vehicle interface {
 	GetType() VehicleType
+	// IsTollFree reports whether the vehicle is exempt at all, regardless of the exemption period.
 	IsTollFree() bool
+	// IsTollFreeAt reports whether the vehicle is exempt at t.
+	IsTollFreeAt(t time.Time) bool
+	// GetWeightClass returns the weight class of the vehicle, or an empty class if it is not classified.
+	GetWeightClass() WeightClass
+	// GetEmissionClass returns the emission class of the vehicle, or an empty class if it is not classified.
+	GetEmissionClass() EmissionClass
+}
+
+// ExemptVehicle is a vehicle with exemptions of its own, such as a registered vehicle with a disabled-driver
+// permit, on top of the exemption of its type.
+type ExemptVehicle interface {
+	Vehicle
+	// ExemptionAt returns the reason code of an exemption of the vehicle that is valid at t.
+	ExemptionAt(t time.Time) (string, bool)
 }
 
 type vehicle struct {
-	vehicleType VehicleType
-	tollFree    bool
+	vehicleType   VehicleType
+	tollFree      bool
+	weightClass   WeightClass
+	emissionClass EmissionClass
+	exemption     Exemption
 }
 
 func NewVehicle(vehicleType VehicleType, tollFree bool) Vehicle {
-	return vehicle{vehicleType, tollFree}
+	return vehicle{vehicleType: vehicleType, tollFree: tollFree}
+}
+
+// NewClassifiedVehicle returns a vehicle with a weight and emission class. A toll-free vehicle is only exempt
+// within the exemption period.
+func NewClassifiedVehicle(
+	vehicleType VehicleType,
+	tollFree bool,
+	weightClass WeightClass,
+	emissionClass EmissionClass,
+	exemption Exemption,
+) Vehicle {
+	return vehicle{vehicleType, tollFree, weightClass, emissionClass, exemption}
 }
 
 func (v vehicle) GetType() VehicleType {
@@ -23,3 +87,15 @@ func (v vehicle) GetType() VehicleType {
 func (v vehicle) IsTollFree() bool {
 	return v.tollFree
 }
+
+func (v vehicle) IsTollFreeAt(t time.Time) bool {
+	return v.tollFree && v.exemption.Covers(t)
+}
+
+func (v vehicle) GetWeightClass() WeightClass {
+	return v.weightClass
+}
+
+func (v vehicle) GetEmissionClass() EmissionClass {
+	return v.emissionClass
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"afry-toll-calculator/metrics"
+	"afry-toll-calculator/services/fee"
+	"afry-toll-calculator/services/holidays"
+	"afry-toll-calculator/services/vehiclelist"
+	"afry-toll-calculator/services/vehicleregistry"
+)
+
+// reloader rebuilds the tariff, vehicle lookup and holiday cache and swaps them into the fee service and the
+// fee services of all zones, which share a single holiday cache. Reloads are serialized, so a SIGHUP and an
+// admin request arriving at the same time do not interleave. The vehicle list is read again if it comes from a
+// file, and so is the vehicle registry, if configured. Everything is read and validated before anything is
+// swapped in, so a reload applies completely or not at all. The set of zones and their gantries is only read
+// on startup.
+type reloader struct {
+	mu              sync.Mutex
+	tariffFile      string
+	location        *time.Location
+	holidays        *holidays.Cache
+	feeService      fee.Service
+	zones           []zoneTariff
+	vehicles        vehiclelist.Getter
+	vehicleList     *vehiclelist.FileGetter
+	vehicleRegistry *vehicleregistry.FileStore
+}
+
+// zoneTariff is the tariff document and fee service of a single zone.
+type zoneTariff struct {
+	name       string
+	tariffFile string
+	feeService fee.Service
+}
+
+func (r *reloader) Reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	err := r.reload()
+	metrics.RecordConfigReload(err)
+	if err != nil {
+		slog.Error("configuration reload failed, keeping previous configuration", "error", err)
+		return err
+	}
+
+	slog.Info("configuration reloaded")
+
+	return nil
+}
+
+func (r *reloader) reload() error {
+	tariff, err := newTariff(r.tariffFile, r.location)
+	if err != nil {
+		return err
+	}
+
+	zoneTariffs := make([]fee.Tariff, len(r.zones))
+	for i, z := range r.zones {
+		zoneTariffs[i], err = newTariff(z.tariffFile, r.location)
+		if err != nil {
+			return fmt.Errorf("zone %q: %w", z.name, err)
+		}
+	}
+
+	// Swaps are collected and only run once every part of the configuration has been validated.
+	var commits []func()
+
+	vehicles := r.vehicles.GetVehicleList()
+	if r.vehicleList != nil {
+		var commit func()
+		vehicles, commit, err = r.vehicleList.PrepareReload()
+		if err != nil {
+			return fmt.Errorf("vehicle list: %w", err)
+		}
+		commits = append(commits, commit)
+	}
+
+	// Stale years keep being served if the holiday source is unavailable, so this only fails for years that
+	// were never loaded.
+	year := time.Now().In(r.location).Year()
+	holidayCache, err := r.holidays.Rebuild(context.Background(), year, year+1)
+	if err != nil {
+		return fmt.Errorf("failed to load holidays: %w", err)
+	}
+
+	commit, err := r.feeService.PrepareReload(tariff, vehicles, holidayCache)
+	if err != nil {
+		return err
+	}
+	commits = append(commits, commit)
+	for i, z := range r.zones {
+		commit, err := z.feeService.PrepareReload(zoneTariffs[i], vehicles, holidayCache)
+		if err != nil {
+			return fmt.Errorf("zone %q: %w", z.name, err)
+		}
+		commits = append(commits, commit)
+	}
+
+	if r.vehicleRegistry != nil {
+		commit, err := r.vehicleRegistry.PrepareReload()
+		if err != nil {
+			return fmt.Errorf("vehicle registry: %w", err)
+		}
+		commits = append(commits, commit)
+	}
+
+	for _, commit := range commits {
+		commit()
+	}
+	r.holidays = holidayCache
+
+	return nil
+}
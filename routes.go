@@ -8,12 +8,107 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	"afry-toll-calculator/handlers"
+	"afry-toll-calculator/services/batch"
+	"afry-toll-calculator/services/dispute"
+	"afry-toll-calculator/services/exemption"
 	"afry-toll-calculator/services/fee"
+	"afry-toll-calculator/services/gantry"
+	"afry-toll-calculator/services/invoice"
+	"afry-toll-calculator/services/passagelog"
+	"afry-toll-calculator/services/vehicleregistry"
+	"afry-toll-calculator/services/zone"
 )
 
-func routes(feeService fee.Service) http.Handler {
+func routes(
+	cfg config,
+	feeService fee.Service,
+	zoneService zone.Service,
+	gantryStore *gantry.FileStore,
+	vehicleRegistry *vehicleregistry.FileStore,
+	exemptionStore *exemption.FileStore,
+	passageLog *passagelog.SegmentLog,
+	invoiceService invoice.Service,
+	disputeService dispute.Service,
+	reloader handlers.Reloader,
+) http.Handler {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/fee", handlers.GetFeeHandler(feeService))
+	mux.HandleFunc("/fees", handlers.GetFeesHandler(feeService))
+	mux.HandleFunc("POST /fee/batch",
+		handlers.GetBatchFeesHandler(batch.New(feeService, cfg.FeeBatchWorkers), cfg.FeeBatchMaxItems))
+	if vehicleRegistry != nil {
+		var exemptions exemption.Store
+		if exemptionStore != nil {
+			exemptions = exemptionStore
+		}
+
+		mux.HandleFunc("POST /fee/registration",
+			handlers.GetRegistrationFeeHandler(vehicleRegistry, exemptions, feeService))
+	}
+	if passageLog != nil {
+		var gantries handlers.GantryResolver
+		switch {
+		case gantryStore != nil:
+			gantries = gantryStore
+		case zoneService != nil:
+			gantries = zoneService
+		}
+
+		mux.HandleFunc("POST /passages", handlers.IngestPassageHandler(passageLog, gantries))
+	}
+	if invoiceService != nil {
+		accounts := invoice.SIEAccounts{Receivable: cfg.InvoiceReceivableAccount, Revenue: cfg.InvoiceRevenueAccount}
+
+		mux.HandleFunc("GET /admin/invoices/{id}",
+			handlers.RequireAdminToken(cfg.AdminToken, handlers.GetInvoiceHandler(invoiceService, accounts)))
+		mux.HandleFunc("POST /admin/invoices",
+			handlers.RequireAdminToken(cfg.AdminToken, handlers.GenerateInvoiceHandler(invoiceService)))
+		mux.HandleFunc("POST /admin/recalculations",
+			handlers.RequireAdminToken(cfg.AdminToken, handlers.RecalculateHandler(invoiceService)))
+	}
+	if disputeService != nil {
+		mux.HandleFunc("GET /admin/disputes",
+			handlers.RequireAdminToken(cfg.AdminToken, handlers.ListDisputesHandler(disputeService)))
+		mux.HandleFunc("POST /admin/disputes",
+			handlers.RequireAdminToken(cfg.AdminToken, handlers.OpenDisputeHandler(disputeService)))
+		mux.HandleFunc("GET /admin/disputes/{id}",
+			handlers.RequireAdminToken(cfg.AdminToken, handlers.GetDisputeHandler(disputeService)))
+		mux.HandleFunc("GET /admin/disputes/{id}/audit",
+			handlers.RequireAdminToken(cfg.AdminToken, handlers.DisputeAuditHandler(disputeService)))
+		mux.HandleFunc("POST /admin/disputes/{id}/evidence",
+			handlers.RequireAdminToken(cfg.AdminToken, handlers.AddDisputeEvidenceHandler(disputeService)))
+		mux.HandleFunc("POST /admin/disputes/{id}/transition",
+			handlers.RequireAdminToken(cfg.AdminToken, handlers.TransitionDisputeHandler(disputeService)))
+	}
+	if zoneService != nil {
+		mux.HandleFunc("/zones/fees", handlers.GetZoneFeesHandler(zoneService))
+	}
+	mux.HandleFunc("/admin/reload", handlers.RequireAdminToken(cfg.AdminToken, handlers.ReloadHandler(reloader)))
+	if gantryStore != nil {
+		var zones handlers.ZoneChecker
+		if zoneService != nil {
+			zones = zoneService
+		}
+
+		mux.HandleFunc("GET /admin/gantries",
+			handlers.RequireAdminToken(cfg.AdminToken, handlers.ListGantriesHandler(gantryStore)))
+		mux.HandleFunc("POST /admin/gantries",
+			handlers.RequireAdminToken(cfg.AdminToken, handlers.CreateGantryHandler(gantryStore, zones)))
+		mux.HandleFunc("PUT /admin/gantries/{id}",
+			handlers.RequireAdminToken(cfg.AdminToken, handlers.UpdateGantryHandler(gantryStore, zones)))
+		mux.HandleFunc("POST /admin/gantries/{id}/deactivate",
+			handlers.RequireAdminToken(cfg.AdminToken, handlers.DeactivateGantryHandler(gantryStore)))
+	}
+	if exemptionStore != nil {
+		mux.HandleFunc("GET /admin/exemptions",
+			handlers.RequireAdminToken(cfg.AdminToken, handlers.ListExemptionsHandler(exemptionStore)))
+		mux.HandleFunc("GET /admin/exemptions/audit",
+			handlers.RequireAdminToken(cfg.AdminToken, handlers.ExemptionAuditHandler(exemptionStore)))
+		mux.HandleFunc("POST /admin/exemptions",
+			handlers.RequireAdminToken(cfg.AdminToken, handlers.GrantExemptionHandler(exemptionStore)))
+		mux.HandleFunc("POST /admin/exemptions/{id}/revoke",
+			handlers.RequireAdminToken(cfg.AdminToken, handlers.RevokeExemptionHandler(exemptionStore)))
+	}
 	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
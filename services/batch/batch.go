@@ -0,0 +1,103 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"afry-toll-calculator/metrics"
+	"afry-toll-calculator/models"
+	"afry-toll-calculator/services/fee"
+)
+
+var ErrInvalid = errors.New("invalid batch item")
+
+// Item is the fee calculation of a single day for a vehicle type, identified by an ID chosen by the client.
+type Item struct {
+	ID          string
+	VehicleType models.VehicleType
+	Timestamps  []time.Time
+}
+
+// Result is the fee of an item, or the error that prevented calculating it.
+type Result struct {
+	ID  string
+	Fee int
+	Err error
+}
+
+type Service interface {
+	// Calculate calculates the fees of independent items concurrently and returns a result for every item, in the
+	// order of the items. An item that fails does not affect the others. Once ctx is done, the items that have
+	// not been calculated yet fail with its error.
+	Calculate(ctx context.Context, items []Item) []Result
+}
+
+// New initializes and returns a new Service implementation, which calculates fees with feeService on at most
+// workers items at a time.
+func New(feeService fee.Service, workers int) Service {
+	return &batchService{
+		feeService: feeService,
+		workers:    max(workers, 1),
+	}
+}
+
+type batchService struct {
+	feeService fee.Service
+	workers    int
+}
+
+func (s *batchService) Calculate(ctx context.Context, items []Item) []Result {
+	results := make([]Result, len(items))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for range min(s.workers, len(items)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = s.calculate(ctx, items[i])
+			}
+		}()
+	}
+
+	seen := make(map[string]bool, len(items))
+	for i, item := range items {
+		// IDs identify the results, so every ID after the first of its kind fails without being calculated.
+		if item.ID != "" && seen[item.ID] {
+			results[i] = Result{ID: item.ID, Err: fmt.Errorf("%w: duplicate id %q", ErrInvalid, item.ID)}
+			continue
+		}
+		seen[item.ID] = true
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+func (s *batchService) calculate(ctx context.Context, item Item) Result {
+	result := Result{ID: item.ID}
+	switch {
+	case item.ID == "":
+		result.Err = fmt.Errorf("%w: missing id", ErrInvalid)
+	case item.VehicleType == "":
+		result.Err = fmt.Errorf("%w: missing vehicle type", ErrInvalid)
+	case len(item.Timestamps) == 0:
+		result.Err = fmt.Errorf("%w: missing timestamps array", ErrInvalid)
+	case ctx.Err() != nil:
+		return Result{ID: item.ID, Err: ctx.Err()}
+	default:
+		var explanation fee.Explanation
+		explanation, result.Err = s.feeService.Explain(item.VehicleType, item.Timestamps)
+		result.Fee = explanation.Fee
+		metrics.RecordCollapsedPassages(explanation.Collapsed)
+	}
+	metrics.RecordFeeCalculation(string(item.VehicleType), result.Fee, result.Err)
+
+	return result
+}
@@ -0,0 +1,109 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	mock_fee "afry-toll-calculator/mocks/afry-toll-calculator/services/fee"
+	"afry-toll-calculator/models"
+	"afry-toll-calculator/services/fee"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_batchService_Calculate(t *testing.T) {
+	day := []time.Time{time.Date(2025, 3, 4, 7, 0, 0, 0, time.UTC)}
+	twoDays := []time.Time{day[0], day[0].AddDate(0, 0, 1)}
+	errHolidays := errors.New("holiday source unavailable")
+
+	feeService := mock_fee.NewMockService(t)
+	feeService.EXPECT().Explain(models.VehicleType("car"), day).Return(fee.Explanation{Fee: 18}, nil)
+	feeService.EXPECT().Explain(models.VehicleType("car"), twoDays).Return(fee.Explanation{}, fee.ErrMultipleDays)
+	feeService.EXPECT().Explain(models.VehicleType("boat"), day).Return(fee.Explanation{}, fee.ErrUnknownVehicleType)
+	feeService.EXPECT().Explain(models.VehicleType("truck"), day).Return(fee.Explanation{}, errHolidays)
+
+	items := []Item{
+		{ID: "a", VehicleType: "car", Timestamps: day},
+		{ID: "b", VehicleType: "car", Timestamps: twoDays},
+		{ID: "c", VehicleType: "boat", Timestamps: day},
+		{ID: "d", VehicleType: "truck", Timestamps: day},
+		{ID: "e", Timestamps: day},
+		{ID: "f", VehicleType: "car"},
+		{VehicleType: "car", Timestamps: day},
+		{ID: "a", VehicleType: "car", Timestamps: day},
+		{ID: "g", VehicleType: "car", Timestamps: day},
+	}
+	want := []struct {
+		fee int
+		err error
+	}{
+		{fee: 18},
+		{err: fee.ErrMultipleDays},
+		{err: fee.ErrUnknownVehicleType},
+		{err: errHolidays},
+		{err: ErrInvalid},
+		{err: ErrInvalid},
+		{err: ErrInvalid},
+		{err: ErrInvalid},
+		{fee: 18},
+	}
+
+	got := New(feeService, 3).Calculate(context.Background(), items)
+	if len(got) != len(items) {
+		t.Fatalf("Calculate() got %d results, want %d", len(got), len(items))
+	}
+	for i, result := range got {
+		if result.ID != items[i].ID || result.Fee != want[i].fee || !errors.Is(result.Err, want[i].err) {
+			t.Errorf("Calculate() result %d = %+v, want id %q, fee %d, error %v", i, result, items[i].ID, want[i].fee, want[i].err)
+		}
+	}
+}
+
+func Test_batchService_Calculate_boundedWorkers(t *testing.T) {
+	const workers = 4
+	var running, peak atomic.Int32
+
+	feeService := mock_fee.NewMockService(t)
+	feeService.EXPECT().Explain(mock.Anything, mock.Anything).RunAndReturn(
+		func(models.VehicleType, []time.Time) (fee.Explanation, error) {
+			n := running.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			running.Add(-1)
+			return fee.Explanation{Fee: 9}, nil
+		})
+
+	items := make([]Item, 50)
+	for i := range items {
+		items[i] = Item{ID: fmt.Sprint(i), VehicleType: "car", Timestamps: []time.Time{time.Now()}}
+	}
+
+	for i, result := range New(feeService, workers).Calculate(context.Background(), items) {
+		if result.Err != nil || result.ID != items[i].ID {
+			t.Errorf("Calculate() result %d = %+v", i, result)
+		}
+	}
+	if p := peak.Load(); p > workers {
+		t.Errorf("Calculate() ran %d items at a time, want at most %d", p, workers)
+	}
+}
+
+func Test_batchService_Calculate_canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// The fee service is not called for a canceled batch.
+	items := []Item{{ID: "a", VehicleType: "car", Timestamps: []time.Time{time.Now()}}}
+	got := New(mock_fee.NewMockService(t), 2).Calculate(ctx, items)
+	if len(got) != 1 || !errors.Is(got[0].Err, context.Canceled) {
+		t.Errorf("Calculate() got = %+v, want %v", got, context.Canceled)
+	}
+}
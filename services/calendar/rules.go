@@ -0,0 +1,139 @@
+package calendar
+
+import (
+	"context"
+	"time"
+
+	"afry-toll-calculator/models"
+)
+
+// Reasons reported by the built-in rules for the days they exempt.
+const (
+	ReasonWeekend                = "weekend"
+	ReasonPublicHoliday          = "public_holiday"
+	ReasonDayBeforePublicHoliday = "day_before_public_holiday"
+	ReasonExemptMonth            = "exempt_month"
+	ReasonExemptDate             = "exempt_date"
+)
+
+// Holidays reports whether the calendar date of a time is a public holiday.
+type Holidays interface {
+	IsHoliday(ctx context.Context, t time.Time) (bool, error)
+}
+
+// Rule decides whether a day is toll-free. Rules are evaluated on times already converted to the billing
+// location, so the calendar date of t is the billing day.
+type Rule interface {
+	// Exempt reports whether the calendar date of t is toll-free under the rule.
+	Exempt(ctx context.Context, t time.Time, holidays Holidays) (bool, error)
+	// Reason identifies the rule in fee explanations.
+	Reason() string
+}
+
+// Default returns the rules of the Stockholm congestion tax: weekends and public holidays are toll-free.
+func Default() []Rule {
+	return []Rule{Weekend(), PublicHoliday()}
+}
+
+type weekendRule struct{}
+
+// Weekend returns a rule exempting Saturdays and Sundays.
+func Weekend() Rule {
+	return weekendRule{}
+}
+
+func (weekendRule) Exempt(_ context.Context, t time.Time, _ Holidays) (bool, error) {
+	return t.Weekday() == time.Saturday || t.Weekday() == time.Sunday, nil
+}
+
+func (weekendRule) Reason() string {
+	return ReasonWeekend
+}
+
+type publicHolidayRule struct{}
+
+// PublicHoliday returns a rule exempting public holidays.
+func PublicHoliday() Rule {
+	return publicHolidayRule{}
+}
+
+func (publicHolidayRule) Exempt(ctx context.Context, t time.Time, holidays Holidays) (bool, error) {
+	return holidays.IsHoliday(ctx, t)
+}
+
+func (publicHolidayRule) Reason() string {
+	return ReasonPublicHoliday
+}
+
+type dayBeforePublicHolidayRule struct{}
+
+// DayBeforePublicHoliday returns a rule exempting the day before a public holiday, as in Gothenburg.
+func DayBeforePublicHoliday() Rule {
+	return dayBeforePublicHolidayRule{}
+}
+
+func (dayBeforePublicHolidayRule) Exempt(ctx context.Context, t time.Time, holidays Holidays) (bool, error) {
+	// AddDate keeps the wall clock time, so the result is the next calendar day even across a DST change.
+	return holidays.IsHoliday(ctx, t.AddDate(0, 0, 1))
+}
+
+func (dayBeforePublicHolidayRule) Reason() string {
+	return ReasonDayBeforePublicHoliday
+}
+
+type monthRule struct {
+	month time.Month
+}
+
+// Month returns a rule exempting every day of a month, such as July in Gothenburg.
+func Month(month time.Month) Rule {
+	return monthRule{month}
+}
+
+func (r monthRule) Exempt(_ context.Context, t time.Time, _ Holidays) (bool, error) {
+	return t.Month() == r.month, nil
+}
+
+func (monthRule) Reason() string {
+	return ReasonExemptMonth
+}
+
+type datesRule struct {
+	dates map[string]struct{}
+}
+
+// Dates returns a rule exempting explicitly listed dates in YYYY-MM-DD format.
+func Dates(dates ...string) Rule {
+	r := datesRule{dates: make(map[string]struct{}, len(dates))}
+	for _, date := range dates {
+		r.dates[date] = struct{}{}
+	}
+
+	return r
+}
+
+func (r datesRule) Exempt(_ context.Context, t time.Time, _ Holidays) (bool, error) {
+	_, ok := r.dates[t.Format(models.PUBLIC_HOLIDAY_DATE_FORMAT)]
+
+	return ok, nil
+}
+
+func (datesRule) Reason() string {
+	return ReasonExemptDate
+}
+
+// Exempt evaluates rules in order and returns the reason of the first rule that exempts the calendar date
+// of t, or an empty reason if the day is billable.
+func Exempt(ctx context.Context, rules []Rule, t time.Time, holidays Holidays) (string, error) {
+	for _, rule := range rules {
+		exempt, err := rule.Exempt(ctx, t, holidays)
+		if err != nil {
+			return "", err
+		}
+		if exempt {
+			return rule.Reason(), nil
+		}
+	}
+
+	return "", nil
+}
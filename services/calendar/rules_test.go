@@ -0,0 +1,156 @@
+package calendar_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	mock_calendar "afry-toll-calculator/mocks/afry-toll-calculator/services/calendar"
+	"afry-toll-calculator/services/calendar"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestExempt(t *testing.T) {
+	gothenburg := []calendar.Rule{
+		calendar.Weekend(),
+		calendar.PublicHoliday(),
+		calendar.DayBeforePublicHoliday(),
+		calendar.Month(time.July),
+		calendar.Dates("2025-06-05"),
+	}
+
+	tests := []struct {
+		name        string
+		rules       []calendar.Rule
+		mocks       func(holidays *mock_calendar.MockHolidays)
+		day         time.Time
+		want        string
+		wantErrText string
+	}{
+		{
+			name:  "weekend is exempt before any holiday lookup",
+			rules: gothenburg,
+			mocks: func(holidays *mock_calendar.MockHolidays) {},
+			day:   time.Date(2025, 3, 8, 10, 0, 0, 0, time.UTC),
+			want:  calendar.ReasonWeekend,
+		},
+		{
+			name:  "public holiday",
+			rules: gothenburg,
+			mocks: func(holidays *mock_calendar.MockHolidays) {
+				holidays.EXPECT().IsHoliday(mock.Anything, time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)).Return(true, nil).Once()
+			},
+			day:  time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC),
+			want: calendar.ReasonPublicHoliday,
+		},
+		{
+			name:  "day before a public holiday",
+			rules: gothenburg,
+			mocks: func(holidays *mock_calendar.MockHolidays) {
+				holidays.EXPECT().IsHoliday(mock.Anything, time.Date(2025, 4, 30, 10, 0, 0, 0, time.UTC)).Return(false, nil).Once()
+				holidays.EXPECT().IsHoliday(mock.Anything, time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)).Return(true, nil).Once()
+			},
+			day:  time.Date(2025, 4, 30, 10, 0, 0, 0, time.UTC),
+			want: calendar.ReasonDayBeforePublicHoliday,
+		},
+		{
+			name:  "exempt month",
+			rules: gothenburg,
+			mocks: func(holidays *mock_calendar.MockHolidays) {
+				holidays.EXPECT().IsHoliday(mock.Anything, mock.Anything).Return(false, nil).Times(2)
+			},
+			day:  time.Date(2025, 7, 15, 10, 0, 0, 0, time.UTC),
+			want: calendar.ReasonExemptMonth,
+		},
+		{
+			name:  "exempt date",
+			rules: gothenburg,
+			mocks: func(holidays *mock_calendar.MockHolidays) {
+				holidays.EXPECT().IsHoliday(mock.Anything, mock.Anything).Return(false, nil).Times(2)
+			},
+			day:  time.Date(2025, 6, 5, 10, 0, 0, 0, time.UTC),
+			want: calendar.ReasonExemptDate,
+		},
+		{
+			name:  "billable day",
+			rules: gothenburg,
+			mocks: func(holidays *mock_calendar.MockHolidays) {
+				holidays.EXPECT().IsHoliday(mock.Anything, mock.Anything).Return(false, nil).Times(2)
+			},
+			day:  time.Date(2025, 6, 4, 10, 0, 0, 0, time.UTC),
+			want: "",
+		},
+		{
+			name:  "july is billable under the default rules",
+			rules: calendar.Default(),
+			mocks: func(holidays *mock_calendar.MockHolidays) {
+				holidays.EXPECT().IsHoliday(mock.Anything, mock.Anything).Return(false, nil).Once()
+			},
+			day:  time.Date(2025, 7, 15, 10, 0, 0, 0, time.UTC),
+			want: "",
+		},
+		{
+			name:  "holiday lookup error",
+			rules: gothenburg,
+			mocks: func(holidays *mock_calendar.MockHolidays) {
+				holidays.EXPECT().IsHoliday(mock.Anything, mock.Anything).Return(false, errors.New("some error")).Once()
+			},
+			day:         time.Date(2025, 6, 4, 10, 0, 0, 0, time.UTC),
+			wantErrText: "some error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			holidays := mock_calendar.NewMockHolidays(t)
+			tt.mocks(holidays)
+
+			got, err := calendar.Exempt(context.Background(), tt.rules, tt.day, holidays)
+			if (err != nil) != (tt.wantErrText != "") {
+				t.Fatalf("Exempt() error = %v, wantErrText %v", err, tt.wantErrText)
+			}
+			if err != nil && err.Error() != tt.wantErrText {
+				t.Errorf("Exempt() error = %v, wantErrText %v", err, tt.wantErrText)
+			}
+			if got != tt.want {
+				t.Errorf("Exempt() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDayBeforePublicHoliday_acrossYearAndDST(t *testing.T) {
+	stockholm, err := time.LoadLocation("Europe/Stockholm")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		day  time.Time
+		next time.Time
+	}{
+		{
+			name: "new year's eve looks up the next year",
+			day:  time.Date(2025, 12, 31, 23, 30, 0, 0, stockholm),
+			next: time.Date(2026, 1, 1, 23, 30, 0, 0, stockholm),
+		},
+		{
+			name: "saturday before the end of daylight saving time",
+			day:  time.Date(2025, 10, 25, 23, 30, 0, 0, stockholm),
+			next: time.Date(2025, 10, 26, 23, 30, 0, 0, stockholm),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			holidays := mock_calendar.NewMockHolidays(t)
+			holidays.EXPECT().IsHoliday(mock.Anything, tt.next).Return(true, nil).Once()
+
+			got, err := calendar.DayBeforePublicHoliday().Exempt(context.Background(), tt.day, holidays)
+			if err != nil || !got {
+				t.Errorf("Exempt() got = %v, %v", got, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package dispute
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// States of a dispute. A dispute is opened, taken under review, and then accepted or rejected, which is final.
+const (
+	StateOpen        = "open"
+	StateUnderReview = "under_review"
+	StateAccepted    = "accepted"
+	StateRejected    = "rejected"
+)
+
+// transitions lists the states a dispute can move to from each state.
+var transitions = map[string][]string{
+	StateOpen:        {StateUnderReview},
+	StateUnderReview: {StateAccepted, StateRejected},
+	StateAccepted:    {},
+	StateRejected:    {},
+}
+
+// Dispute is a claim that a stored passage was not made by the vehicle it was attributed to, such as after a
+// misread plate. The passage is identified by its sequence number in the passage log; its registration, gantry
+// and timestamp are copied when the dispute is opened.
+type Dispute struct {
+	ID           string     `json:"id"`
+	Passage      uint64     `json:"passage"`
+	Registration string     `json:"registration"`
+	Gantry       string     `json:"gantry"`
+	Timestamp    time.Time  `json:"timestamp"`
+	Claim        string     `json:"claim"`
+	State        string     `json:"state"`
+	Evidence     []Evidence `json:"evidence"`
+	// Resolution explains why the dispute was accepted or rejected.
+	Resolution string    `json:"resolution,omitempty"`
+	OpenedBy   string    `json:"openedBy"`
+	OpenedAt   time.Time `json:"openedAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+	// Notes are the numbers of the credit and debit notes issued when the accepted dispute was recalculated.
+	Notes []int `json:"notes,omitempty"`
+}
+
+// Evidence supports a dispute. Ref refers to the document in external storage; it is never interpreted by the
+// calculator.
+type Evidence struct {
+	Ref         string    `json:"ref"`
+	Description string    `json:"description,omitempty"`
+	AddedBy     string    `json:"addedBy"`
+	AddedAt     time.Time `json:"addedAt"`
+}
+
+// IsResolved reports whether the dispute has been accepted or rejected.
+func (d Dispute) IsResolved() bool {
+	return d.State == StateAccepted || d.State == StateRejected
+}
+
+// transition checks that the dispute can move to state.
+func (d Dispute) transition(state string) error {
+	if _, ok := transitions[state]; !ok {
+		return fmt.Errorf("%w: unknown state %q", ErrInvalid, state)
+	}
+	if !slices.Contains(transitions[d.State], state) {
+		return fmt.Errorf("%w: dispute %s cannot move from %s to %s", ErrInvalidTransition, d.ID, d.State, state)
+	}
+
+	return nil
+}
+
+// Validate reports the first field of a new dispute that is missing.
+func (d Dispute) Validate() error {
+	switch {
+	case d.Passage == 0:
+		return errors.New("missing passage")
+	case d.Claim == "":
+		return errors.New("missing claim")
+	}
+
+	return nil
+}
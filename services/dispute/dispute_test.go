@@ -0,0 +1,77 @@
+package dispute
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	mock_passagelog "afry-toll-calculator/mocks/afry-toll-calculator/services/passagelog"
+	"afry-toll-calculator/services/passagelog"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDispute_transition(t *testing.T) {
+	tests := []struct {
+		from    string
+		to      string
+		wantErr error
+	}{
+		{from: StateOpen, to: StateUnderReview},
+		{from: StateOpen, to: StateAccepted, wantErr: ErrInvalidTransition},
+		{from: StateOpen, to: StateRejected, wantErr: ErrInvalidTransition},
+		{from: StateUnderReview, to: StateAccepted},
+		{from: StateUnderReview, to: StateRejected},
+		{from: StateUnderReview, to: StateOpen, wantErr: ErrInvalidTransition},
+		{from: StateAccepted, to: StateRejected, wantErr: ErrInvalidTransition},
+		{from: StateRejected, to: StateUnderReview, wantErr: ErrInvalidTransition},
+		{from: StateOpen, to: "closed", wantErr: ErrInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.from+" to "+tt.to, func(t *testing.T) {
+			err := Dispute{ID: "1", State: tt.from}.transition(tt.to)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("transition() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExcludeAccepted(t *testing.T) {
+	log := mock_passagelog.NewMockLog(t)
+	log.EXPECT().Scan(mock.Anything).RunAndReturn(func(fn func(passagelog.Event) error) error {
+		for seq := uint64(1); seq <= 3; seq++ {
+			if err := fn(passagelog.Event{Seq: seq}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	store := newTestStore(t, filepath.Join(t.TempDir(), "disputes.jsonl"))
+	for _, passage := range []uint64{2, 3} {
+		d, err := store.Open(testDispute(passage), "alice")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := store.Transition(d.ID, StateUnderReview, "", "bob"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := store.Transition("1", StateAccepted, "", "bob"); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []uint64
+	err := ExcludeAccepted(log, store).Scan(func(e passagelog.Event) error {
+		got = append(got, e.Seq)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	// Passage 3 is only under review, so it is still billed.
+	if want := []uint64{1, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Scan() got passages %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,28 @@
+package dispute
+
+import (
+	"afry-toll-calculator/services/passagelog"
+)
+
+// Ensure conformance to the interface
+var _ passagelog.Log = excludingLog{}
+
+type excludingLog struct {
+	passagelog.Log
+	store Store
+}
+
+// ExcludeAccepted returns the passage log without the passages of accepted disputes, for everything that
+// calculates fees from stored passages. The passages stay in the log itself, which is append-only.
+func ExcludeAccepted(log passagelog.Log, store Store) passagelog.Log {
+	return excludingLog{log, store}
+}
+
+func (l excludingLog) Scan(fn func(passagelog.Event) error) error {
+	return l.Log.Scan(func(e passagelog.Event) error {
+		if l.store.IsExcluded(e.Seq) {
+			return nil
+		}
+		return fn(e)
+	})
+}
@@ -0,0 +1,108 @@
+package dispute
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"afry-toll-calculator/models"
+	"afry-toll-calculator/services/invoice"
+	"afry-toll-calculator/services/passagelog"
+)
+
+// ErrRecalculation is returned if a dispute was accepted but the fees of its day could not be recalculated. The
+// dispute stays accepted; the day can be recalculated again with the reason dispute_accepted.
+var ErrRecalculation = errors.New("dispute accepted, but recalculation failed")
+
+type Service interface {
+	// Open opens a dispute on the passage with the given sequence number in the passage log.
+	Open(passage uint64, claim, actor string) (Dispute, error)
+	AddEvidence(id string, e Evidence, actor string) (Dispute, error)
+	// Transition moves a dispute to state. Accepting a dispute excludes its passage from fee calculation and
+	// recalculates the fees of the vehicle on the day of the passage, which issues notes for invoiced days.
+	Transition(id, state, resolution, actor string) (Dispute, error)
+	Get(id string) (Dispute, error)
+	List(registration string) []Dispute
+	Audit(id string) []AuditEntry
+}
+
+// New initializes and returns a new Service implementation for the passages in passages. If invoices is not nil,
+// accepted disputes are recalculated with it; its passages must exclude the passages of accepted disputes, see
+// ExcludeAccepted. Days are billing days in location.
+func New(passages passagelog.Log, store Store, invoices invoice.Service, location *time.Location) Service {
+	return &disputeService{
+		passages: passages,
+		store:    store,
+		invoices: invoices,
+		location: location,
+	}
+}
+
+type disputeService struct {
+	passages passagelog.Log
+	store    Store
+	invoices invoice.Service
+	location *time.Location
+}
+
+func (s *disputeService) Open(passage uint64, claim, actor string) (Dispute, error) {
+	if passage == 0 {
+		return Dispute{}, fmt.Errorf("%w: missing passage", ErrInvalid)
+	}
+	event, err := s.passages.Get(passage)
+	if err != nil {
+		return Dispute{}, err
+	}
+
+	return s.store.Open(Dispute{
+		Passage:      event.Seq,
+		Registration: event.Registration,
+		Gantry:       event.Gantry,
+		Timestamp:    event.Timestamp,
+		Claim:        claim,
+	}, actor)
+}
+
+func (s *disputeService) AddEvidence(id string, e Evidence, actor string) (Dispute, error) {
+	return s.store.AddEvidence(id, e, actor)
+}
+
+func (s *disputeService) Transition(id, state, resolution, actor string) (Dispute, error) {
+	d, err := s.store.Transition(id, state, resolution, actor)
+	if err != nil || d.State != StateAccepted || s.invoices == nil {
+		return d, err
+	}
+
+	date := d.Timestamp.In(s.location).Format(models.PUBLIC_HOLIDAY_DATE_FORMAT)
+	result, err := s.invoices.Recalculate(invoice.RecalculationRequest{
+		Registration: d.Registration,
+		From:         date,
+		To:           date,
+		Reason:       invoice.ReasonDisputeAccepted,
+	})
+	if err != nil {
+		return d, fmt.Errorf("%w: %w", ErrRecalculation, err)
+	}
+	if len(result.Notes) == 0 {
+		return d, nil
+	}
+
+	notes := make([]int, 0, len(result.Notes))
+	for _, note := range result.Notes {
+		notes = append(notes, note.Number)
+	}
+
+	return s.store.AttachNotes(id, notes, actor)
+}
+
+func (s *disputeService) Get(id string) (Dispute, error) {
+	return s.store.Get(id)
+}
+
+func (s *disputeService) List(registration string) []Dispute {
+	return s.store.List(registration)
+}
+
+func (s *disputeService) Audit(id string) []AuditEntry {
+	return s.store.Audit(id)
+}
@@ -0,0 +1,92 @@
+package dispute
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	mock_invoice "afry-toll-calculator/mocks/afry-toll-calculator/services/invoice"
+	mock_passagelog "afry-toll-calculator/mocks/afry-toll-calculator/services/passagelog"
+	"afry-toll-calculator/services/invoice"
+	"afry-toll-calculator/services/passagelog"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_disputeService(t *testing.T) {
+	stockholm, err := time.LoadLocation("Europe/Stockholm")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	passages := mock_passagelog.NewMockLog(t)
+	// 23:30 UTC is the next day in the billing time zone.
+	event := passagelog.Event{Seq: 7, Registration: "ABC123", Gantry: "STO-01", Timestamp: time.Date(2025, 3, 4, 23, 30, 0, 0, time.UTC)}
+	passages.EXPECT().Get(uint64(7)).Return(event, nil)
+	passages.EXPECT().Get(uint64(9)).Return(passagelog.Event{}, passagelog.ErrNotFound)
+
+	invoices := mock_invoice.NewMockService(t)
+	invoices.EXPECT().Recalculate(invoice.RecalculationRequest{
+		Registration: "ABC123",
+		From:         "2025-03-05",
+		To:           "2025-03-05",
+		Reason:       invoice.ReasonDisputeAccepted,
+	}).Return(invoice.Recalculation{Notes: []invoice.Invoice{{Number: 12, Kind: invoice.KindCreditNote}}}, nil).Once()
+
+	store := newTestStore(t, filepath.Join(t.TempDir(), "disputes.jsonl"))
+	s := New(passages, store, invoices, stockholm)
+
+	if _, err := s.Open(9, "Misread plate", "alice"); !errors.Is(err, passagelog.ErrNotFound) {
+		t.Errorf("Open() of an unknown passage error = %v, want %v", err, passagelog.ErrNotFound)
+	}
+	opened, err := s.Open(7, "Misread plate", "alice")
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if opened.Registration != "ABC123" || opened.Gantry != "STO-01" || !opened.Timestamp.Equal(event.Timestamp) {
+		t.Errorf("Open() did not copy the passage: %+v", opened)
+	}
+
+	// Taking a dispute under review does not recalculate anything.
+	if _, err := s.Transition(opened.ID, StateUnderReview, "", "bob"); err != nil {
+		t.Fatalf("Transition() error = %v", err)
+	}
+	accepted, err := s.Transition(opened.ID, StateAccepted, "Misread plate confirmed", "bob")
+	if err != nil {
+		t.Fatalf("Transition() error = %v", err)
+	}
+	if accepted.State != StateAccepted || !reflect.DeepEqual(accepted.Notes, []int{12}) {
+		t.Errorf("Transition() got = %+v, want an accepted dispute with note 12", accepted)
+	}
+	if !store.IsExcluded(7) {
+		t.Error("IsExcluded() of the accepted dispute = false")
+	}
+}
+
+func Test_disputeService_recalculationFails(t *testing.T) {
+	passages := mock_passagelog.NewMockLog(t)
+	passages.EXPECT().Get(uint64(7)).Return(passagelog.Event{Seq: 7, Registration: "ABC123", Timestamp: time.Now()}, nil)
+
+	invoices := mock_invoice.NewMockService(t)
+	invoices.EXPECT().Recalculate(mock.Anything).Return(invoice.Recalculation{}, invoice.ErrTariffChanged)
+
+	store := newTestStore(t, filepath.Join(t.TempDir(), "disputes.jsonl"))
+	s := New(passages, store, invoices, time.UTC)
+	opened, err := s.Open(7, "Misread plate", "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Transition(opened.ID, StateUnderReview, "", "bob"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The dispute stays accepted, so its passage is no longer billed.
+	accepted, err := s.Transition(opened.ID, StateAccepted, "", "bob")
+	if !errors.Is(err, ErrRecalculation) || !errors.Is(err, invoice.ErrTariffChanged) {
+		t.Errorf("Transition() error = %v, want %v", err, ErrRecalculation)
+	}
+	if accepted.State != StateAccepted || !store.IsExcluded(7) {
+		t.Errorf("Transition() got = %+v, want an accepted dispute", accepted)
+	}
+}
@@ -0,0 +1,261 @@
+package dispute
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"afry-toll-calculator/internal/filestore"
+	"afry-toll-calculator/services/vehicleregistry"
+)
+
+var (
+	ErrInvalid           = errors.New("invalid dispute")
+	ErrNotFound          = errors.New("dispute not found")
+	ErrAlreadyDisputed   = errors.New("passage is already disputed")
+	ErrInvalidTransition = errors.New("invalid dispute transition")
+)
+
+// Actions recorded in the audit trail.
+const (
+	ActionOpen       = "open"
+	ActionEvidence   = "evidence"
+	ActionTransition = "transition"
+	ActionNotes      = "notes"
+)
+
+// AuditEntry records a change to a dispute: who made it, when, and the dispute as it was afterwards.
+type AuditEntry struct {
+	Time    time.Time `json:"time"`
+	Actor   string    `json:"actor"`
+	Action  string    `json:"action"`
+	Dispute Dispute   `json:"dispute"`
+}
+
+// Store keeps disputes and the audit trail of all changes to them.
+type Store interface {
+	// Open validates and stores a new dispute opened by actor, and returns it with its ID assigned. A passage can
+	// only have one dispute that has not been rejected.
+	Open(d Dispute, actor string) (Dispute, error)
+	// AddEvidence attaches evidence to a dispute that has not been resolved.
+	AddEvidence(id string, e Evidence, actor string) (Dispute, error)
+	// Transition moves a dispute to state, if the state machine allows it, with the resolution of the decision.
+	Transition(id, state, resolution, actor string) (Dispute, error)
+	// AttachNotes records the credit and debit notes issued for an accepted dispute.
+	AttachNotes(id string, notes []int, actor string) (Dispute, error)
+	Get(id string) (Dispute, error)
+	// List returns the disputes of a registration number, or all disputes if the number is empty.
+	List(registration string) []Dispute
+	// Audit returns the audit trail of a dispute, oldest first.
+	Audit(id string) []AuditEntry
+	// IsExcluded reports whether the passage has an accepted dispute, which excludes it from fee calculation.
+	IsExcluded(passage uint64) bool
+}
+
+// Ensure conformance to the interface
+var _ Store = (*FileStore)(nil)
+
+// FileStore is a Store backed by an append-only file with one JSON audit entry per line. The disputes are the
+// result of replaying the audit trail, so the file is both the state and its history. Every change is synced to
+// disk before it takes effect. It is safe for concurrent use.
+type FileStore struct {
+	mu       sync.RWMutex
+	log      *filestore.Log[AuditEntry]
+	audit    []AuditEntry
+	disputes map[string]Dispute
+	// byPassage holds the dispute of every passage that has not been rejected.
+	byPassage map[uint64]string
+	now       func() time.Time
+}
+
+// NewFileStore opens the audit trail at path, creating it if it does not exist, and replays it. A last line
+// that was only partially written, as after a crash, is discarded.
+func NewFileStore(path string) (*FileStore, error) {
+	s := &FileStore{disputes: map[string]Dispute{}, byPassage: map[uint64]string{}, now: time.Now}
+	log, err := filestore.OpenLog(path, "dispute audit entry", func(entry AuditEntry) error {
+		s.apply(entry)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.log = log
+
+	return s, nil
+}
+
+// Close closes the audit trail file.
+func (s *FileStore) Close() error {
+	return s.log.Close()
+}
+
+func (s *FileStore) Open(d Dispute, actor string) (Dispute, error) {
+	if err := d.Validate(); err != nil {
+		return Dispute{}, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	if actor == "" {
+		return Dispute{}, fmt.Errorf("%w: missing actor", ErrInvalid)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id, ok := s.byPassage[d.Passage]; ok {
+		return Dispute{}, fmt.Errorf("%w: passage %d by dispute %s", ErrAlreadyDisputed, d.Passage, id)
+	}
+
+	now := s.now()
+	d.ID = strconv.Itoa(len(s.disputes) + 1)
+	d.Registration = vehicleregistry.Normalize(d.Registration)
+	d.State = StateOpen
+	d.Evidence = []Evidence{}
+	d.Resolution = ""
+	d.OpenedBy, d.OpenedAt, d.UpdatedAt = actor, now, now
+	d.Notes = nil
+
+	return d, s.record(AuditEntry{Time: now, Actor: actor, Action: ActionOpen, Dispute: d})
+}
+
+func (s *FileStore) AddEvidence(id string, e Evidence, actor string) (Dispute, error) {
+	if e.Ref == "" {
+		return Dispute{}, fmt.Errorf("%w: missing evidence ref", ErrInvalid)
+	}
+
+	return s.update(id, actor, ActionEvidence, func(d *Dispute, now time.Time) error {
+		if d.IsResolved() {
+			return fmt.Errorf("%w: dispute %s is %s", ErrInvalidTransition, d.ID, d.State)
+		}
+		e.AddedBy, e.AddedAt = actor, now
+		d.Evidence = append(d.Evidence, e)
+		return nil
+	})
+}
+
+func (s *FileStore) Transition(id, state, resolution, actor string) (Dispute, error) {
+	return s.update(id, actor, ActionTransition, func(d *Dispute, now time.Time) error {
+		if err := d.transition(state); err != nil {
+			return err
+		}
+		d.State = state
+		if d.IsResolved() {
+			d.Resolution = resolution
+		}
+		return nil
+	})
+}
+
+func (s *FileStore) AttachNotes(id string, notes []int, actor string) (Dispute, error) {
+	return s.update(id, actor, ActionNotes, func(d *Dispute, now time.Time) error {
+		if d.State != StateAccepted {
+			return fmt.Errorf("%w: dispute %s is %s", ErrInvalidTransition, d.ID, d.State)
+		}
+		d.Notes = append(d.Notes, notes...)
+		return nil
+	})
+}
+
+// update applies change to a copy of the dispute and records the result.
+func (s *FileStore) update(id, actor, action string, change func(d *Dispute, now time.Time) error) (Dispute, error) {
+	if actor == "" {
+		return Dispute{}, fmt.Errorf("%w: missing actor", ErrInvalid)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d, ok := s.disputes[id]
+	if !ok {
+		return Dispute{}, fmt.Errorf("%w: %q", ErrNotFound, id)
+	}
+	// The evidence and notes of the stored dispute must not be shared with the changed copy.
+	d.Evidence = append([]Evidence{}, d.Evidence...)
+	d.Notes = append([]int(nil), d.Notes...)
+
+	now := s.now()
+	if err := change(&d, now); err != nil {
+		return Dispute{}, err
+	}
+	d.UpdatedAt = now
+
+	return d, s.record(AuditEntry{Time: now, Actor: actor, Action: action, Dispute: d})
+}
+
+// record appends the entry to the audit trail and applies it once it is on disk. Must be called with s.mu held.
+func (s *FileStore) record(entry AuditEntry) error {
+	if err := s.log.Append(entry); err != nil {
+		return fmt.Errorf("failed to write dispute audit trail: %w", err)
+	}
+	s.apply(entry)
+
+	return nil
+}
+
+func (s *FileStore) apply(entry AuditEntry) {
+	d := entry.Dispute
+	s.audit = append(s.audit, entry)
+	s.disputes[d.ID] = d
+	if d.State == StateRejected {
+		delete(s.byPassage, d.Passage)
+	} else {
+		s.byPassage[d.Passage] = d.ID
+	}
+}
+
+func (s *FileStore) Get(id string) (Dispute, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	d, ok := s.disputes[id]
+	if !ok {
+		return Dispute{}, fmt.Errorf("%w: %q", ErrNotFound, id)
+	}
+
+	return d, nil
+}
+
+func (s *FileStore) List(registration string) []Dispute {
+	registration = vehicleregistry.Normalize(registration)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	disputes := []Dispute{}
+	for _, d := range s.disputes {
+		if registration == "" || d.Registration == registration {
+			disputes = append(disputes, d)
+		}
+	}
+	sort.Slice(disputes, func(i, j int) bool {
+		a, _ := strconv.Atoi(disputes[i].ID)
+		b, _ := strconv.Atoi(disputes[j].ID)
+		return a < b
+	})
+
+	return disputes
+}
+
+func (s *FileStore) Audit(id string) []AuditEntry {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	audit := []AuditEntry{}
+	for _, entry := range s.audit {
+		if entry.Dispute.ID == id {
+			audit = append(audit, entry)
+		}
+	}
+
+	return audit
+}
+
+func (s *FileStore) IsExcluded(passage uint64) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	id, ok := s.byPassage[passage]
+
+	return ok && s.disputes[id].State == StateAccepted
+}
@@ -0,0 +1,168 @@
+package dispute
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func newTestStore(t *testing.T, path string) *FileStore {
+	t.Helper()
+
+	s, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	s.now = func() time.Time { return time.Date(2025, 4, 10, 9, 0, 0, 0, time.UTC) }
+
+	return s
+}
+
+func testDispute(passage uint64) Dispute {
+	return Dispute{
+		Passage:      passage,
+		Registration: "abc-123",
+		Gantry:       "STO-01",
+		Timestamp:    time.Date(2025, 3, 4, 7, 0, 0, 0, time.UTC),
+		Claim:        "The vehicle was in the garage; the plate was misread.",
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "disputes.jsonl")
+	store := newTestStore(t, path)
+
+	opened, err := store.Open(testDispute(7), "alice")
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if opened.ID != "1" || opened.State != StateOpen || opened.Registration != "ABC123" || opened.OpenedBy != "alice" {
+		t.Errorf("Open() got = %+v", opened)
+	}
+	if _, err := store.Open(testDispute(7), "bob"); !errors.Is(err, ErrAlreadyDisputed) {
+		t.Errorf("Open() of a disputed passage error = %v, want %v", err, ErrAlreadyDisputed)
+	}
+	if _, err := store.Open(Dispute{Passage: 8}, "alice"); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Open() without a claim error = %v, want %v", err, ErrInvalid)
+	}
+	if _, err := store.Open(testDispute(8), ""); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Open() without an actor error = %v, want %v", err, ErrInvalid)
+	}
+
+	withEvidence, err := store.AddEvidence("1", Evidence{Ref: "s3://evidence/1.pdf", Description: "Parking receipt"}, "alice")
+	if err != nil {
+		t.Fatalf("AddEvidence() error = %v", err)
+	}
+	if len(withEvidence.Evidence) != 1 || withEvidence.Evidence[0].AddedBy != "alice" {
+		t.Errorf("AddEvidence() got = %+v", withEvidence)
+	}
+	if _, err := store.AddEvidence("1", Evidence{}, "alice"); !errors.Is(err, ErrInvalid) {
+		t.Errorf("AddEvidence() without a ref error = %v, want %v", err, ErrInvalid)
+	}
+
+	if _, err := store.Transition("1", StateAccepted, "", "bob"); !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("Transition() to accepted before review error = %v, want %v", err, ErrInvalidTransition)
+	}
+	if _, err := store.Transition("1", StateUnderReview, "", "bob"); err != nil {
+		t.Fatalf("Transition() error = %v", err)
+	}
+	if store.IsExcluded(7) {
+		t.Error("IsExcluded() of a dispute under review = true")
+	}
+	accepted, err := store.Transition("1", StateAccepted, "Receipt confirms the vehicle was parked.", "bob")
+	if err != nil {
+		t.Fatalf("Transition() error = %v", err)
+	}
+	if accepted.State != StateAccepted || accepted.Resolution == "" || !store.IsExcluded(7) {
+		t.Errorf("Transition() got = %+v, excluded %v", accepted, store.IsExcluded(7))
+	}
+	if _, err := store.AddEvidence("1", Evidence{Ref: "s3://evidence/2.pdf"}, "alice"); !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("AddEvidence() to an accepted dispute error = %v, want %v", err, ErrInvalidTransition)
+	}
+	if _, err := store.AttachNotes("1", []int{12}, "bob"); err != nil {
+		t.Errorf("AttachNotes() error = %v", err)
+	}
+	if _, err := store.Transition("99", StateUnderReview, "", "bob"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Transition() of an unknown dispute error = %v, want %v", err, ErrNotFound)
+	}
+
+	// A rejected dispute no longer blocks a new dispute of the passage.
+	if _, err := store.Open(testDispute(8), "alice"); err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if _, err := store.Transition("2", StateUnderReview, "", "bob"); err != nil {
+		t.Fatalf("Transition() error = %v", err)
+	}
+	if _, err := store.Transition("2", StateRejected, "The image shows the plate clearly.", "bob"); err != nil {
+		t.Fatalf("Transition() error = %v", err)
+	}
+	if store.IsExcluded(8) {
+		t.Error("IsExcluded() of a rejected dispute = true")
+	}
+	if _, err := store.Open(testDispute(8), "alice"); err != nil {
+		t.Errorf("Open() after a rejected dispute error = %v", err)
+	}
+
+	wantAudit := []string{"open alice", "evidence alice", "transition bob", "transition bob", "notes bob"}
+	var gotAudit []string
+	for _, entry := range store.Audit("1") {
+		gotAudit = append(gotAudit, entry.Action+" "+entry.Actor)
+	}
+	if !reflect.DeepEqual(gotAudit, wantAudit) {
+		t.Errorf("Audit() got = %v, want %v", gotAudit, wantAudit)
+	}
+
+	// A new store over the same file replays the audit trail, as after a restart.
+	want := store.List("")
+	store.Close()
+	store = newTestStore(t, path)
+	if got := store.List(""); !reflect.DeepEqual(got, want) {
+		t.Errorf("List() after restart got = %+v, want %+v", got, want)
+	}
+	if got, err := store.Get("1"); err != nil || !reflect.DeepEqual(got.Notes, []int{12}) {
+		t.Errorf("Get() after restart got = %+v, %v", got, err)
+	}
+	if !store.IsExcluded(7) || store.IsExcluded(8) {
+		t.Error("IsExcluded() after restart does not match the accepted disputes")
+	}
+	if _, err := store.Open(testDispute(8), "alice"); !errors.Is(err, ErrAlreadyDisputed) {
+		t.Errorf("Open() after restart error = %v, want %v", err, ErrAlreadyDisputed)
+	}
+}
+
+func TestNewFileStore_partialLastLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "disputes.jsonl")
+	store := newTestStore(t, path)
+	if _, err := store.Open(testDispute(7), "alice"); err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	store.Close()
+
+	// Simulate a crash in the middle of writing the second entry.
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString(`{"time":"2025-04-10T09:00:00Z","actor":"bo`); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	store = newTestStore(t, path)
+	if got := len(store.List("")); got != 1 {
+		t.Fatalf("List() got %d disputes, want 1", got)
+	}
+	if _, err := store.Transition("1", StateUnderReview, "", "bob"); err != nil {
+		t.Fatalf("Transition() error = %v", err)
+	}
+	store.Close()
+
+	store = newTestStore(t, path)
+	if got, err := store.Get("1"); err != nil || got.State != StateUnderReview {
+		t.Errorf("Get() after restart got = %+v, %v", got, err)
+	}
+}
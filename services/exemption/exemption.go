@@ -0,0 +1,84 @@
+package exemption
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"afry-toll-calculator/models"
+	"afry-toll-calculator/services/vehicleregistry"
+)
+
+// Reason codes of exemptions granted to individual vehicles.
+const (
+	ReasonDisabledDriver = "disabled_driver"
+	ReasonMedical        = "medical"
+	ReasonPublicService  = "public_service"
+	ReasonOther          = "other"
+)
+
+// Exemption makes an individual vehicle toll-free from ValidFrom (inclusive) until ValidTo (exclusive), or until
+// it is revoked.
+type Exemption struct {
+	ID           string    `json:"id"`
+	Registration string    `json:"registration"`
+	Reason       string    `json:"reason"`
+	ValidFrom    time.Time `json:"validFrom"`
+	ValidTo      time.Time `json:"validTo"`
+	GrantedBy    string    `json:"grantedBy"`
+	GrantedAt    time.Time `json:"grantedAt"`
+	RevokedBy    string    `json:"revokedBy,omitempty"`
+	RevokedAt    time.Time `json:"revokedAt,omitzero"`
+}
+
+// IsActive reports whether the exemption covers t. A revoked exemption still covers the times before it was
+// revoked.
+func (e Exemption) IsActive(t time.Time) bool {
+	return !t.Before(e.ValidFrom) && t.Before(e.ValidTo) && (e.RevokedAt.IsZero() || t.Before(e.RevokedAt))
+}
+
+// Validate reports the first field of the exemption that is missing or invalid.
+func (e Exemption) Validate() error {
+	switch {
+	case vehicleregistry.Normalize(e.Registration) == "":
+		return errors.New("missing registration number")
+	case e.ValidFrom.IsZero():
+		return errors.New("missing validFrom")
+	case e.ValidTo.IsZero():
+		return errors.New("missing validTo")
+	case !e.ValidTo.After(e.ValidFrom):
+		return errors.New("validTo must be after validFrom")
+	}
+
+	switch e.Reason {
+	case ReasonDisabledDriver, ReasonMedical, ReasonPublicService, ReasonOther:
+		return nil
+	default:
+		return fmt.Errorf("reason %q must be one of %q, %q, %q or %q",
+			e.Reason, ReasonDisabledDriver, ReasonMedical, ReasonPublicService, ReasonOther)
+	}
+}
+
+// Ensure conformance to the interface
+var _ models.ExemptVehicle = exemptVehicle{}
+
+type exemptVehicle struct {
+	models.Vehicle
+	exemptions []Exemption
+}
+
+// WithExemptions returns the vehicle with the given exemptions of its own, which the fee engine checks against
+// every passage.
+func WithExemptions(vehicle models.Vehicle, exemptions []Exemption) models.ExemptVehicle {
+	return exemptVehicle{vehicle, exemptions}
+}
+
+func (v exemptVehicle) ExemptionAt(t time.Time) (string, bool) {
+	for _, e := range v.exemptions {
+		if e.IsActive(t) {
+			return e.Reason, true
+		}
+	}
+
+	return "", false
+}
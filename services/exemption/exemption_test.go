@@ -0,0 +1,68 @@
+package exemption
+
+import (
+	"testing"
+	"time"
+
+	"afry-toll-calculator/models"
+)
+
+func validExemption() Exemption {
+	return Exemption{
+		Registration: "ABC123",
+		Reason:       ReasonDisabledDriver,
+		ValidFrom:    time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		ValidTo:      time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestExemption_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(e *Exemption)
+		wantErr bool
+	}{
+		{name: "valid", modify: func(*Exemption) {}},
+		{name: "missing registration", modify: func(e *Exemption) { e.Registration = " - " }, wantErr: true},
+		{name: "unknown reason", modify: func(e *Exemption) { e.Reason = "friend_of_the_mayor" }, wantErr: true},
+		{name: "missing validFrom", modify: func(e *Exemption) { e.ValidFrom = time.Time{} }, wantErr: true},
+		{name: "missing validTo", modify: func(e *Exemption) { e.ValidTo = time.Time{} }, wantErr: true},
+		{name: "empty validity period", modify: func(e *Exemption) { e.ValidTo = e.ValidFrom }, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := validExemption()
+			tt.modify(&e)
+			if err := e.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWithExemptions(t *testing.T) {
+	revoked := validExemption()
+	revoked.Reason = ReasonMedical
+	revoked.ValidFrom = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	revoked.RevokedAt = time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	vehicle := WithExemptions(models.NewVehicle("car", false), []Exemption{revoked, validExemption()})
+
+	tests := []struct {
+		at         time.Time
+		wantReason string
+		wantOK     bool
+	}{
+		{at: time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)},
+		{at: time.Date(2025, 5, 31, 0, 0, 0, 0, time.UTC), wantReason: ReasonMedical, wantOK: true},
+		{at: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{at: time.Date(2026, 12, 31, 23, 59, 0, 0, time.UTC), wantReason: ReasonDisabledDriver, wantOK: true},
+		{at: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		reason, ok := vehicle.ExemptionAt(tt.at)
+		if reason != tt.wantReason || ok != tt.wantOK {
+			t.Errorf("ExemptionAt(%v) = %q, %v, want %q, %v", tt.at, reason, ok, tt.wantReason, tt.wantOK)
+		}
+	}
+}
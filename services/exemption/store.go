@@ -0,0 +1,174 @@
+package exemption
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"afry-toll-calculator/internal/filestore"
+	"afry-toll-calculator/services/vehicleregistry"
+)
+
+var (
+	ErrInvalid  = errors.New("invalid exemption")
+	ErrNotFound = errors.New("exemption not found")
+)
+
+// Actions recorded in the audit trail.
+const (
+	ActionGrant  = "grant"
+	ActionRevoke = "revoke"
+)
+
+// AuditEntry records a change to an exemption: who made it, when, and the exemption as it was afterwards.
+type AuditEntry struct {
+	Time      time.Time `json:"time"`
+	Actor     string    `json:"actor"`
+	Action    string    `json:"action"`
+	Exemption Exemption `json:"exemption"`
+}
+
+// Store keeps the exemptions of individual vehicles and the audit trail of all changes to them.
+type Store interface {
+	// Grant validates and stores a new exemption granted by actor, and returns it with its ID assigned.
+	Grant(e Exemption, actor string) (Exemption, error)
+	// Revoke ends the exemption with the given ID at the given time. An exemption that has already been
+	// revoked by then is returned unchanged.
+	Revoke(id string, at time.Time, actor string) (Exemption, error)
+	// List returns the exemptions of a registration number, or all exemptions if the number is empty.
+	List(registration string) []Exemption
+	// Audit returns the audit trail of a registration number, or of all exemptions if the number is empty,
+	// oldest first.
+	Audit(registration string) []AuditEntry
+}
+
+// Ensure conformance to the interface
+var _ Store = (*FileStore)(nil)
+
+// FileStore is a Store backed by an append-only file with one JSON audit entry per line. The exemptions are
+// the result of replaying the audit trail, so the file is both the state and its history. Every change is
+// synced to disk before it takes effect. It is safe for concurrent use.
+type FileStore struct {
+	mu         sync.RWMutex
+	log        *filestore.Log[AuditEntry]
+	audit      []AuditEntry
+	exemptions map[string]Exemption
+	now        func() time.Time
+}
+
+// NewFileStore opens the audit trail at path, creating it if it does not exist, and replays it. A last line
+// that was only partially written, as after a crash, is discarded.
+func NewFileStore(path string) (*FileStore, error) {
+	s := &FileStore{exemptions: map[string]Exemption{}, now: time.Now}
+	log, err := filestore.OpenLog(path, "exemption audit entry", func(entry AuditEntry) error {
+		s.apply(entry)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.log = log
+
+	return s, nil
+}
+
+// Close closes the audit trail file.
+func (s *FileStore) Close() error {
+	return s.log.Close()
+}
+
+func (s *FileStore) Grant(e Exemption, actor string) (Exemption, error) {
+	if err := e.Validate(); err != nil {
+		return Exemption{}, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	if actor == "" {
+		return Exemption{}, fmt.Errorf("%w: missing actor", ErrInvalid)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	e.ID = strconv.Itoa(len(s.exemptions) + 1)
+	e.Registration = vehicleregistry.Normalize(e.Registration)
+	e.GrantedBy, e.GrantedAt = actor, now
+	e.RevokedBy, e.RevokedAt = "", time.Time{}
+
+	return e, s.record(AuditEntry{Time: now, Actor: actor, Action: ActionGrant, Exemption: e})
+}
+
+func (s *FileStore) Revoke(id string, at time.Time, actor string) (Exemption, error) {
+	if actor == "" {
+		return Exemption{}, fmt.Errorf("%w: missing actor", ErrInvalid)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.exemptions[id]
+	if !ok {
+		return Exemption{}, fmt.Errorf("%w: %q", ErrNotFound, id)
+	}
+	if !e.RevokedAt.IsZero() && !e.RevokedAt.After(at) {
+		return e, nil
+	}
+
+	e.RevokedBy, e.RevokedAt = actor, at
+
+	return e, s.record(AuditEntry{Time: s.now(), Actor: actor, Action: ActionRevoke, Exemption: e})
+}
+
+// record appends the entry to the audit trail and applies it once it is on disk. Must be called with s.mu held.
+func (s *FileStore) record(entry AuditEntry) error {
+	if err := s.log.Append(entry); err != nil {
+		return fmt.Errorf("failed to write exemption audit trail: %w", err)
+	}
+	s.apply(entry)
+
+	return nil
+}
+
+func (s *FileStore) apply(entry AuditEntry) {
+	s.audit = append(s.audit, entry)
+	s.exemptions[entry.Exemption.ID] = entry.Exemption
+}
+
+func (s *FileStore) List(registration string) []Exemption {
+	registration = vehicleregistry.Normalize(registration)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	exemptions := []Exemption{}
+	for _, e := range s.exemptions {
+		if registration == "" || e.Registration == registration {
+			exemptions = append(exemptions, e)
+		}
+	}
+	sort.Slice(exemptions, func(i, j int) bool {
+		a, _ := strconv.Atoi(exemptions[i].ID)
+		b, _ := strconv.Atoi(exemptions[j].ID)
+		return a < b
+	})
+
+	return exemptions
+}
+
+func (s *FileStore) Audit(registration string) []AuditEntry {
+	registration = vehicleregistry.Normalize(registration)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	audit := []AuditEntry{}
+	for _, entry := range s.audit {
+		if registration == "" || entry.Exemption.Registration == registration {
+			audit = append(audit, entry)
+		}
+	}
+
+	return audit
+}
@@ -0,0 +1,139 @@
+package exemption
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func newTestStore(t *testing.T, path string) *FileStore {
+	t.Helper()
+
+	s, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	s.now = func() time.Time { return time.Date(2025, 12, 1, 9, 0, 0, 0, time.UTC) }
+
+	return s
+}
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "exemptions.jsonl")
+	store := newTestStore(t, path)
+
+	e := validExemption()
+	e.Registration = "abc-123"
+	granted, err := store.Grant(e, "alice")
+	if err != nil {
+		t.Fatalf("Grant() error = %v", err)
+	}
+	if granted.ID != "1" || granted.Registration != "ABC123" || granted.GrantedBy != "alice" {
+		t.Errorf("Grant() got = %+v", granted)
+	}
+	if _, err := store.Grant(Exemption{Registration: "ABC123"}, "alice"); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Grant() of an invalid exemption error = %v, want %v", err, ErrInvalid)
+	}
+	if _, err := store.Grant(validExemption(), ""); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Grant() without an actor error = %v, want %v", err, ErrInvalid)
+	}
+
+	other := validExemption()
+	other.Registration = "XYZ789"
+	if _, err := store.Grant(other, "alice"); err != nil {
+		t.Fatalf("Grant() error = %v", err)
+	}
+
+	at := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	revoked, err := store.Revoke("1", at, "bob")
+	if err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+	if revoked.RevokedBy != "bob" || !revoked.RevokedAt.Equal(at) {
+		t.Errorf("Revoke() got = %+v", revoked)
+	}
+	// Revoking again later neither moves the end of the exemption nor adds to the audit trail.
+	if again, err := store.Revoke("1", at.AddDate(0, 1, 0), "carol"); err != nil || again.RevokedBy != "bob" {
+		t.Errorf("Revoke() again got = %+v, %v", again, err)
+	}
+	if _, err := store.Revoke("99", at, "bob"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Revoke() of an unknown exemption error = %v, want %v", err, ErrNotFound)
+	}
+
+	wantAudit := []string{"grant alice", "revoke bob"}
+	audit := store.Audit("ABC 123")
+	var gotAudit []string
+	for _, entry := range audit {
+		gotAudit = append(gotAudit, entry.Action+" "+entry.Actor)
+	}
+	if !reflect.DeepEqual(gotAudit, wantAudit) {
+		t.Errorf("Audit() got = %v, want %v", gotAudit, wantAudit)
+	}
+	if got := len(store.Audit("")); got != 3 {
+		t.Errorf("Audit() of all exemptions got %d entries, want 3", got)
+	}
+
+	// A new store over the same file replays the audit trail, as after a restart.
+	want := store.List("")
+	store.Close()
+	store = newTestStore(t, path)
+	if got := store.List(""); !reflect.DeepEqual(got, want) {
+		t.Errorf("List() after restart got = %+v, want %+v", got, want)
+	}
+	if got := store.List("ABC123"); len(got) != 1 || got[0].ID != "1" {
+		t.Errorf("List() of a registration got = %+v", got)
+	}
+
+	// IDs keep counting after a restart.
+	if granted, err := store.Grant(validExemption(), "alice"); err != nil || granted.ID != "3" {
+		t.Errorf("Grant() after restart got = %+v, %v", granted, err)
+	}
+}
+
+func TestNewFileStore_partialLastLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "exemptions.jsonl")
+	store := newTestStore(t, path)
+	if _, err := store.Grant(validExemption(), "alice"); err != nil {
+		t.Fatalf("Grant() error = %v", err)
+	}
+	store.Close()
+
+	// Simulate a crash in the middle of writing the second entry.
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString(`{"time":"2025-12-01T09:00:00Z","actor":"bo`); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	store = newTestStore(t, path)
+	if got := len(store.List("")); got != 1 {
+		t.Fatalf("List() got %d exemptions, want 1", got)
+	}
+	if _, err := store.Grant(validExemption(), "alice"); err != nil {
+		t.Fatalf("Grant() error = %v", err)
+	}
+	store.Close()
+
+	store = newTestStore(t, path)
+	if got := len(store.List("")); got != 2 {
+		t.Errorf("List() after restart got %d exemptions, want 2", got)
+	}
+}
+
+func TestNewFileStore_corruptEntry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "exemptions.jsonl")
+	if err := os.WriteFile(path, []byte("not json\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewFileStore(path); err == nil {
+		t.Error("NewFileStore() expected an error")
+	}
+}
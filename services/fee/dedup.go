@@ -0,0 +1,29 @@
+package fee
+
+import (
+	"time"
+
+	"afry-toll-calculator/models"
+)
+
+// collapse reports which passages of a day, sorted chronologically, are duplicates: passages less than window
+// after the last passage that was kept at the same gantry, as when the cameras on both sides of a gantry read
+// the same vehicle. A window of zero keeps all passages.
+func collapse(passages []models.Passage, window time.Duration) []bool {
+	duplicates := make([]bool, len(passages))
+	if window <= 0 {
+		return duplicates
+	}
+
+	lastKept := map[string]time.Time{}
+	for i, p := range passages {
+		if last, ok := lastKept[p.Gantry]; ok && p.Timestamp.Sub(last) < window {
+			duplicates[i] = true
+			continue
+		}
+
+		lastKept[p.Gantry] = p.Timestamp
+	}
+
+	return duplicates
+}
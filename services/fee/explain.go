@@ -0,0 +1,68 @@
+package fee
+
+import (
+	"time"
+
+	"afry-toll-calculator/services/calendar"
+	"afry-toll-calculator/services/multiplier"
+)
+
+// Classification describes how a single entry time was treated by the fee calculation. Entries on a day
+// exempted by a calendar rule are classified with the reason of that rule.
+type Classification string
+
+const (
+	ClassificationBillable               Classification = "billable"
+	ClassificationWeekend                Classification = calendar.ReasonWeekend
+	ClassificationPublicHoliday          Classification = calendar.ReasonPublicHoliday
+	ClassificationDayBeforePublicHoliday Classification = calendar.ReasonDayBeforePublicHoliday
+	ClassificationExemptMonth            Classification = calendar.ReasonExemptMonth
+	ClassificationExemptDate             Classification = calendar.ReasonExemptDate
+	ClassificationTollFreeVehicle        Classification = "toll_free_vehicle"
+	ClassificationExemptVehicle          Classification = "exempt_vehicle"
+	ClassificationDuplicate              Classification = "duplicate"
+)
+
+// Explanation describes how the fee for a single billing day was calculated, so it can be presented
+// to a driver. Field names are part of the public API and must remain stable.
+type Explanation struct {
+	Date        string `json:"date"`
+	Fee         int    `json:"fee"`
+	UncappedFee int    `json:"uncappedFee"`
+	// DailyCap is 0 if the tariff has no daily cap.
+	DailyCap   int                  `json:"dailyCap"`
+	CapApplied bool                 `json:"capApplied"`
+	Passages   []PassageExplanation `json:"passages"`
+	Blocks     []BlockExplanation   `json:"blocks"`
+	// Collapsed is the number of passages classified as duplicates of an earlier passage at the same gantry.
+	Collapsed int `json:"collapsed"`
+	// Multipliers are the tariff multipliers that apply to the vehicle and Factor is their product, which
+	// scales the passage prices and the daily cap. Both are omitted if no multiplier applies, and BaseDailyCap
+	// is the daily cap of the tariff before it was scaled.
+	Multipliers  []multiplier.Multiplier `json:"multipliers,omitempty"`
+	Factor       float64                 `json:"factor,omitempty"`
+	BaseDailyCap int                     `json:"baseDailyCap,omitempty"`
+	// TariffVersion and Currency are those of the tariff the fee was calculated with, if it defines them.
+	TariffVersion string `json:"tariffVersion,omitempty"`
+	Currency      string `json:"currency,omitempty"`
+}
+
+// PassageExplanation is an input entry time with its classification. Price is only set for billable entries.
+// BasePrice is the price list price before multipliers, and is only set if a multiplier applies. Exemption is
+// the reason code of the exemption of an individual vehicle that made the entry toll-free. Gantry is only set for
+// passages at a known gantry.
+type PassageExplanation struct {
+	Timestamp      time.Time      `json:"timestamp"`
+	Gantry         string         `json:"gantry,omitempty"`
+	Classification Classification `json:"classification"`
+	Price          int            `json:"price"`
+	BasePrice      int            `json:"basePrice,omitempty"`
+	Exemption      string         `json:"exemption,omitempty"`
+}
+
+// BlockExplanation is a charging window formed by billable entries, with the highest price inside it.
+type BlockExplanation struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	Price int       `json:"price"`
+}
@@ -1,103 +1,95 @@
 package fee
 
 import (
+	"context"
 	"errors"
 	"sort"
+	"sync/atomic"
 	"time"
 
-	"afry-toll-calculator/integrations/dagsmart"
 	"afry-toll-calculator/models"
-	"afry-toll-calculator/services/pricelist"
+	"afry-toll-calculator/services/calendar"
+	"afry-toll-calculator/services/holidays"
+	"afry-toll-calculator/services/multiplier"
 	"afry-toll-calculator/services/vehiclelist"
+	"afry-toll-calculator/services/window"
+)
+
+var (
+	// ErrUnknownVehicleType is returned for vehicle types that are not in the vehicle list.
+	ErrUnknownVehicleType = errors.New("unknown vehicle type")
+	// ErrMultipleDays is returned for single-day calculations with entry times on more than one billing day.
+	ErrMultipleDays = errors.New("GetFee call contains more than one day of entry times")
 )
 
 type Service interface {
 	GetFee(vehicleType models.VehicleType, entryDates []time.Time) (int, error)
+	GetFees(vehicleType models.VehicleType, entryDates []time.Time) (Summary, error)
+	GetPassageFees(vehicleType models.VehicleType, passages []models.Passage) (Summary, error)
+	Explain(vehicleType models.VehicleType, entryDates []time.Time) (Explanation, error)
+	ExplainVehicle(vehicle models.Vehicle, entryDates []time.Time) (Explanation, error)
+	ExplainPassages(vehicle models.Vehicle, passages []models.Passage) (Explanation, error)
+	// PrepareReload validates a new configuration and returns a function that swaps it in.
+	PrepareReload(tariff Tariff, vehicles []models.Vehicle, holidayCache *holidays.Cache) (func(), error)
+}
+
+// Summary holds the total fee for a set of entry times spanning any number of days, together with the
+// fee of each billing day that had at least one entry.
+type Summary struct {
+	Total int      `json:"total"`
+	Days  []DayFee `json:"days"`
+	// Collapsed is the number of passages that were collapsed into an earlier passage as duplicates.
+	Collapsed int `json:"collapsed,omitempty"`
+}
+
+// DayFee is the fee of a single billing day, with the daily maximum already applied.
+type DayFee struct {
+	Date      string `json:"date"`
+	Fee       int    `json:"fee"`
+	Collapsed int    `json:"collapsed,omitempty"`
 }
 
+// New initializes and returns a new Service implementation. All entry times are converted to the given
+// location before day boundaries and the calendar rules of the tariff are applied.
 func New(
 	vehiclesGetter vehiclelist.Getter,
-	dagsmartService dagsmart.Service,
-	priceListService pricelist.Service,
+	holidayCache *holidays.Cache,
+	tariff Tariff,
+	location *time.Location,
 ) Service {
-	vl := map[models.VehicleType]bool{}
-	for _, v := range vehiclesGetter.GetVehicleList() {
-		vl[v.GetType()] = v.IsTollFree()
-	}
-
 	svc := feeService{
-		vehiclesGetter:   vehiclesGetter,
-		holidaysGetter:   dagsmartService,
-		priceListService: priceListService,
-		vehicleLookup:    vl,
-		publicHolidays:   map[int]map[string]struct{}{},
+		location: location,
 	}
+	svc.current.Store(newSnapshot(vehiclesGetter.GetVehicleList(), tariff, holidayCache))
 
 	return &svc
 }
 
 type feeService struct {
-	publicHolidays   map[int]map[string]struct{}
-	vehiclesGetter   vehiclelist.Getter
-	holidaysGetter   dagsmart.Service
-	priceListService pricelist.Service
-	vehicleLookup    map[models.VehicleType]bool
-}
-
-type billableBlock struct {
-	start time.Time
-	end   time.Time
-	price int
-}
-
-// getHolidays retrieves and caches the list of public holidays for the specified year. Returns an error if retrieval fails.
-// The purpose of this indirection is to ensure that when the year changes, service will self-manage retrieval and caching of
-// holidays for the new year, otherwise stale data would be cached until service restart.
-func (s *feeService) getHolidays(year int) (map[string]struct{}, error) {
-	if _, ok := s.publicHolidays[year]; !ok {
-		s.publicHolidays[year] = map[string]struct{}{}
-		h, err := s.holidaysGetter.Get(year)
-		if err != nil {
-			return nil, err
-		}
-
-		for _, date := range h {
-			s.publicHolidays[year][date] = struct{}{}
-		}
-	}
-
-	return s.publicHolidays[year], nil
+	current  atomic.Pointer[snapshot]
+	location *time.Location
 }
 
-func (s *feeService) filterBillableDates(dates []time.Time) ([]time.Time, error) {
-	out := dates[:0]
-	for _, v := range dates {
-		switch v.Weekday() {
-		case time.Saturday, time.Sunday:
-			continue
-		default:
-			date := v.Format(models.PUBLIC_HOLIDAY_DATE_FORMAT)
-			h, err := s.getHolidays(v.Year())
-			if err != nil {
-				return nil, err
-			}
-
-			if _, ok := h[date]; ok {
-				continue
-			}
+// classify returns the classification of an entry time for a vehicle that is not toll-free, based on the
+// first calendar rule of the tariff that exempts its day.
+func (s *feeService) classify(snap *snapshot, entry time.Time) (Classification, error) {
+	reason, err := calendar.Exempt(context.Background(), snap.tariff.Calendar, entry, snap.holidays)
+	if err != nil {
+		return "", err
+	}
 
-			out = append(out, v)
-		}
+	if reason == "" {
+		return ClassificationBillable, nil
 	}
 
-	return out, nil
+	return Classification(reason), nil
 }
 
-func (s *feeService) validateSingleDay(entryDates []time.Time) bool {
+func (s *feeService) validateSingleDay(passages []models.Passage) bool {
 	dates := map[string]struct{}{}
-	for _, v := range entryDates {
-		s := v.Format(models.PUBLIC_HOLIDAY_DATE_FORMAT)
-		dates[s] = struct{}{}
+	for _, p := range passages {
+		d := p.Timestamp.In(s.location).Format(models.PUBLIC_HOLIDAY_DATE_FORMAT)
+		dates[d] = struct{}{}
 	}
 
 	return len(dates) == 1
@@ -106,54 +98,245 @@ func (s *feeService) validateSingleDay(entryDates []time.Time) bool {
 // GetFee returns the total sum of fees for a given array of entry times. Function will return an
 // error if entry times for more than one day are included.
 func (s *feeService) GetFee(vehicleType models.VehicleType, entryDates []time.Time) (int, error) {
-	if !s.validateSingleDay(entryDates) {
-		return 0, errors.New("GetFee call contains more than one day of entry times")
+	explanation, err := s.Explain(vehicleType, entryDates)
+	if err != nil {
+		return 0, err
+	}
+
+	return explanation.Fee, nil
+}
+
+// Explain returns the fee for a given array of entry times together with a breakdown of how it was
+// calculated. Like GetFee, it will return an error if entry times for more than one day are included.
+func (s *feeService) Explain(vehicleType models.VehicleType, entryDates []time.Time) (Explanation, error) {
+	return s.explain(vehicleType, nil, passagesOf(entryDates))
+}
+
+// ExplainVehicle works like Explain for an individual vehicle, such as a registered one, which is toll-free if
+// either its type or the vehicle itself is toll-free. The type of the vehicle must be in the vehicle list.
+func (s *feeService) ExplainVehicle(vehicle models.Vehicle, entryDates []time.Time) (Explanation, error) {
+	return s.explain(vehicle.GetType(), vehicle, passagesOf(entryDates))
+}
+
+// ExplainPassages works like ExplainVehicle for passages at known gantries, such as stored passages.
+func (s *feeService) ExplainPassages(vehicle models.Vehicle, passages []models.Passage) (Explanation, error) {
+	return s.explain(vehicle.GetType(), vehicle, passages)
+}
+
+// explain calculates the fee of a single day for a vehicle of the given type. If individual is not nil, it
+// refines the vehicle type as described for individualVehicle.
+func (s *feeService) explain(
+	vehicleType models.VehicleType,
+	individual models.Vehicle,
+	passages []models.Passage,
+) (Explanation, error) {
+	if !s.validateSingleDay(passages) {
+		return Explanation{}, ErrMultipleDays
 	}
 
-	tollFree, vehicleFound := s.vehicleLookup[vehicleType]
+	snap := s.current.Load()
+	vehicle, vehicleFound := snap.vehicleLookup[vehicleType]
 	if !vehicleFound {
-		return 0, errors.New("unknown vehicle type")
+		return Explanation{}, ErrUnknownVehicleType
 	}
+	if individual != nil {
+		vehicle = individualVehicle{individual, vehicle}
+	}
+
+	days := s.groupByDay(passages)
+
+	return s.explainDay(snap, days[0], vehicle)
+}
+
+// GetFees returns the total sum of fees for entry times spanning an arbitrary date range, along with
+// a per-day breakdown. Entry times are grouped into billing days and the hourly window and daily maximum
+// are applied to each day separately.
+func (s *feeService) GetFees(vehicleType models.VehicleType, entryDates []time.Time) (Summary, error) {
+	return s.GetPassageFees(vehicleType, passagesOf(entryDates))
+}
 
-	if tollFree {
-		return 0, nil
+// GetPassageFees works like GetFees for passages at known gantries, so only passages at the same gantry are
+// collapsed as duplicates of each other.
+func (s *feeService) GetPassageFees(vehicleType models.VehicleType, passages []models.Passage) (Summary, error) {
+	snap := s.current.Load()
+	vehicle, vehicleFound := snap.vehicleLookup[vehicleType]
+	if !vehicleFound {
+		return Summary{}, ErrUnknownVehicleType
 	}
 
-	billableDates, err := s.filterBillableDates(entryDates)
-	if err != nil {
-		return 0, err
+	summary := Summary{Days: []DayFee{}}
+	for _, day := range s.groupByDay(passages) {
+		explanation, err := s.explainDay(snap, day, vehicle)
+		if err != nil {
+			return Summary{}, err
+		}
+
+		summary.Total += explanation.Fee
+		summary.Collapsed += explanation.Collapsed
+		summary.Days = append(summary.Days, DayFee{Date: day.date, Fee: explanation.Fee, Collapsed: explanation.Collapsed})
 	}
 
-	if len(billableDates) == 0 {
-		return 0, nil
+	return summary, nil
+}
+
+type billingDay struct {
+	date     string
+	passages []models.Passage
+}
+
+// passagesOf returns entry times as passages at an unknown gantry, which are all treated as the same gantry.
+func passagesOf(entryDates []time.Time) []models.Passage {
+	passages := make([]models.Passage, len(entryDates))
+	for i, t := range entryDates {
+		passages[i] = models.Passage{Timestamp: t}
+	}
+
+	return passages
+}
+
+// groupByDay converts passage times to the billing time zone and splits them into billing days, sorted by
+// date. Passages within a day are sorted chronologically. The input slice is not modified.
+//
+// Days are local calendar days, so a day on which daylight saving time starts or ends is 23 or 25 hours
+// long. The hourly window is based on elapsed time and is not affected by the wall clock shift.
+func (s *feeService) groupByDay(passages []models.Passage) []billingDay {
+	index := map[string]int{}
+	days := []billingDay{}
+	for _, p := range passages {
+		p.Timestamp = p.Timestamp.In(s.location)
+		date := p.Timestamp.Format(models.PUBLIC_HOLIDAY_DATE_FORMAT)
+		i, ok := index[date]
+		if !ok {
+			i = len(days)
+			index[date] = i
+			days = append(days, billingDay{date: date})
+		}
+
+		days[i].passages = append(days[i].passages, p)
 	}
 
-	sort.Slice(billableDates, func(i, j int) bool {
-		return billableDates[i].Before(billableDates[j])
+	sort.Slice(days, func(i, j int) bool {
+		return days[i].date < days[j].date
 	})
+	for _, day := range days {
+		sort.SliceStable(day.passages, func(i, j int) bool {
+			return day.passages[i].Timestamp.Before(day.passages[j].Timestamp)
+		})
+	}
+
+	return days
+}
 
-	var currentBlock *billableBlock
-	billableBlocks := []*billableBlock{}
-	for _, date := range billableDates {
-		if currentBlock == nil || currentBlock.end.Before(date.Add(time.Minute)) {
-			currentBlock = &billableBlock{date, date.Add(time.Hour), 0}
-			billableBlocks = append(billableBlocks, currentBlock)
+// explainDay calculates the fee for a single billing day and records how every entry time contributed to it.
+func (s *feeService) explainDay(snap *snapshot, day billingDay, vehicle models.Vehicle) (Explanation, error) {
+	dailyCap := *snap.tariff.DailyCap
+	explanation := Explanation{
+		Date:          day.date,
+		DailyCap:      dailyCap,
+		Passages:      make([]PassageExplanation, 0, len(day.passages)),
+		Blocks:        []BlockExplanation{},
+		TariffVersion: snap.tariff.Version,
+		Currency:      snap.tariff.Currency,
+	}
+
+	factor, multipliers := multiplier.Factor(snap.tariff.Multipliers, vehicle)
+	scaled := len(multipliers) > 0
+	if scaled {
+		explanation.Multipliers = multipliers
+		explanation.Factor = factor
+		explanation.BaseDailyCap = dailyCap
+		explanation.DailyCap = snap.tariff.Rounding.Apply(dailyCap, factor)
+	}
+
+	duplicates := collapse(day.passages, snap.tariff.DedupWindow)
+	billable := []window.Passage{}
+	for i, p := range day.passages {
+		date := p.Timestamp
+		passage := PassageExplanation{Timestamp: date, Gantry: p.Gantry}
+		reason, exempted := exemptionAt(vehicle, date)
+		switch {
+		case duplicates[i]:
+			passage.Classification = ClassificationDuplicate
+			explanation.Collapsed++
+		case vehicle.IsTollFreeAt(date):
+			passage.Classification = ClassificationTollFreeVehicle
+		case exempted:
+			passage.Classification, passage.Exemption = ClassificationExemptVehicle, reason
+		default:
+			classification, err := s.classify(snap, date)
+			if err != nil {
+				return Explanation{}, err
+			}
+			passage.Classification = classification
 		}
 
-		price := s.priceListService.GetPrice(date)
-		if currentBlock.price < price {
-			currentBlock.price = price
+		if passage.Classification == ClassificationBillable {
+			passage.Price = snap.tariff.PriceList.GetPrice(date)
+			if scaled {
+				passage.BasePrice = passage.Price
+				passage.Price = snap.tariff.Rounding.Apply(passage.BasePrice, factor)
+			}
+			billable = append(billable, window.Passage{Time: date, Price: passage.Price})
 		}
+
+		explanation.Passages = append(explanation.Passages, passage)
+	}
+
+	for _, charge := range snap.tariff.WindowStrategy.Charges(billable, snap.tariff.Window) {
+		explanation.UncappedFee += charge.Price
+		explanation.Blocks = append(explanation.Blocks, BlockExplanation{charge.Start, charge.End, charge.Price})
+	}
+
+	explanation.Fee = explanation.UncappedFee
+	if dailyCap > 0 && explanation.Fee > explanation.DailyCap {
+		explanation.Fee = explanation.DailyCap
+		explanation.CapApplied = true
+	}
+
+	return explanation, nil
+}
+
+// individualVehicle is an individual vehicle of a type in the vehicle list. It is toll-free whenever either the
+// individual vehicle or its type is, and it is classified like its type unless it has a class of its own.
+type individualVehicle struct {
+	models.Vehicle
+	vehicleType models.Vehicle
+}
+
+func (v individualVehicle) IsTollFree() bool {
+	return v.Vehicle.IsTollFree() || v.vehicleType.IsTollFree()
+}
+
+func (v individualVehicle) IsTollFreeAt(t time.Time) bool {
+	return v.Vehicle.IsTollFreeAt(t) || v.vehicleType.IsTollFreeAt(t)
+}
+
+func (v individualVehicle) ExemptionAt(t time.Time) (string, bool) {
+	return exemptionAt(v.Vehicle, t)
+}
+
+func (v individualVehicle) GetWeightClass() models.WeightClass {
+	if c := v.Vehicle.GetWeightClass(); c != "" {
+		return c
 	}
 
-	sum := 0
-	for _, block := range billableBlocks {
-		sum += block.price
+	return v.vehicleType.GetWeightClass()
+}
+
+func (v individualVehicle) GetEmissionClass() models.EmissionClass {
+	if c := v.Vehicle.GetEmissionClass(); c != "" {
+		return c
 	}
 
-	if sum > 60 {
-		return 60, nil
-	} else {
-		return sum, nil
+	return v.vehicleType.GetEmissionClass()
+}
+
+// exemptionAt returns the reason code of an exemption of the vehicle itself that is valid at t, if the vehicle
+// has exemptions of its own.
+func exemptionAt(vehicle models.Vehicle, t time.Time) (string, bool) {
+	if exempt, ok := vehicle.(models.ExemptVehicle); ok {
+		return exempt.ExemptionAt(t)
 	}
+
+	return "", false
 }
@@ -5,11 +5,16 @@ import (
 	"reflect"
 	"testing"
 	"time"
+	_ "time/tzdata"
 
 	mock_dagsmart "afry-toll-calculator/mocks/afry-toll-calculator/integrations/dagsmart"
 	mock_pricelist "afry-toll-calculator/mocks/afry-toll-calculator/services/pricelist"
 	mock_vehiclelist "afry-toll-calculator/mocks/afry-toll-calculator/services/vehiclelist"
 	"afry-toll-calculator/models"
+	"afry-toll-calculator/services/calendar"
+	"afry-toll-calculator/services/holidays"
+	"afry-toll-calculator/services/multiplier"
+	"afry-toll-calculator/services/window"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -37,7 +42,7 @@ func Test_feeService_getHolidays(t *testing.T) {
 		{
 			name: "normal vehicle pays two entry fees, for two separate blocks",
 			mocks: func(getter *mock_vehiclelist.MockGetter, dagsmart *mock_dagsmart.MockService, pricelist *mock_pricelist.MockService) {
-				dagsmart.EXPECT().Get(mock.Anything).Return([]string{}, nil)
+				dagsmart.EXPECT().Get(mock.Anything, mock.Anything).Return([]string{}, nil)
 
 				pricelist.EXPECT().GetPrice(time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC)).Return(5)
 				pricelist.EXPECT().GetPrice(time.Date(2020, 1, 1, 20, 0, 0, 0, time.UTC)).Return(6)
@@ -52,7 +57,7 @@ func Test_feeService_getHolidays(t *testing.T) {
 		{
 			name: "normal vehicle pays two entry fees, for two separate blocks, despite entering each block 3 times",
 			mocks: func(getter *mock_vehiclelist.MockGetter, dagsmart *mock_dagsmart.MockService, pricelist *mock_pricelist.MockService) {
-				dagsmart.EXPECT().Get(mock.Anything).Return([]string{}, nil)
+				dagsmart.EXPECT().Get(mock.Anything, mock.Anything).Return([]string{}, nil)
 
 				pricelist.EXPECT().GetPrice(time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC)).Return(5)
 				pricelist.EXPECT().GetPrice(time.Date(2020, 1, 1, 10, 15, 0, 0, time.UTC)).Return(5)
@@ -75,7 +80,7 @@ func Test_feeService_getHolidays(t *testing.T) {
 		{
 			name: "normal vehicle pays two entry fees, because third entry misses last block by a minute",
 			mocks: func(getter *mock_vehiclelist.MockGetter, dagsmart *mock_dagsmart.MockService, pricelist *mock_pricelist.MockService) {
-				dagsmart.EXPECT().Get(mock.Anything).Return([]string{}, nil)
+				dagsmart.EXPECT().Get(mock.Anything, mock.Anything).Return([]string{}, nil)
 
 				pricelist.EXPECT().GetPrice(time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC)).Return(5)
 				pricelist.EXPECT().GetPrice(time.Date(2020, 1, 1, 10, 15, 0, 0, time.UTC)).Return(5)
@@ -92,7 +97,7 @@ func Test_feeService_getHolidays(t *testing.T) {
 		{
 			name: "normal vehicle pays the highest fee in each block",
 			mocks: func(getter *mock_vehiclelist.MockGetter, dagsmart *mock_dagsmart.MockService, pricelist *mock_pricelist.MockService) {
-				dagsmart.EXPECT().Get(mock.Anything).Return([]string{}, nil)
+				dagsmart.EXPECT().Get(mock.Anything, mock.Anything).Return([]string{}, nil)
 
 				pricelist.EXPECT().GetPrice(time.Date(2020, 1, 1, 10, 00, 0, 0, time.UTC)).Return(3)
 				pricelist.EXPECT().GetPrice(time.Date(2020, 1, 1, 10, 15, 0, 0, time.UTC)).Return(8)
@@ -111,7 +116,7 @@ func Test_feeService_getHolidays(t *testing.T) {
 		{
 			name: "normal vehicle exceeds maximum daily toll fee and pays the maximum daily rate",
 			mocks: func(getter *mock_vehiclelist.MockGetter, dagsmart *mock_dagsmart.MockService, pricelist *mock_pricelist.MockService) {
-				dagsmart.EXPECT().Get(mock.Anything).Return([]string{"2020-03-05"}, nil)
+				dagsmart.EXPECT().Get(mock.Anything, mock.Anything).Return([]string{"2020-03-05"}, nil)
 
 				pricelist.EXPECT().GetPrice(time.Date(2020, 1, 1, 01, 00, 0, 0, time.UTC)).Return(18)
 				pricelist.EXPECT().GetPrice(time.Date(2020, 1, 1, 06, 00, 0, 0, time.UTC)).Return(25)
@@ -148,7 +153,7 @@ func Test_feeService_getHolidays(t *testing.T) {
 		{
 			name: "normal vehicle has free pass on holidays",
 			mocks: func(getter *mock_vehiclelist.MockGetter, dagsmart *mock_dagsmart.MockService, pricelist *mock_pricelist.MockService) {
-				dagsmart.EXPECT().Get(mock.Anything).Return([]string{"2020-01-01"}, nil)
+				dagsmart.EXPECT().Get(mock.Anything, mock.Anything).Return([]string{"2020-01-01"}, nil)
 			},
 			entryDates: []time.Time{
 				time.Date(2020, 1, 1, 01, 00, 0, 0, time.UTC),
@@ -173,7 +178,7 @@ func Test_feeService_getHolidays(t *testing.T) {
 		{
 			name: "holiday API returns an error",
 			mocks: func(getter *mock_vehiclelist.MockGetter, dagsmart *mock_dagsmart.MockService, pricelist *mock_pricelist.MockService) {
-				dagsmart.EXPECT().Get(mock.Anything).Return(nil, errors.New("some error"))
+				dagsmart.EXPECT().Get(mock.Anything, mock.Anything).Return(nil, errors.New("some error"))
 			},
 			entryDates: []time.Time{
 				time.Date(2020, 1, 1, 01, 00, 0, 0, time.UTC),
@@ -208,8 +213,9 @@ func Test_feeService_getHolidays(t *testing.T) {
 
 			s := New(
 				mockVehicleListGetter,
-				mockDagsmartService,
-				mockpriceListService,
+				holidays.NewCache(mockDagsmartService, 0),
+				Tariff{PriceList: mockpriceListService},
+				time.UTC,
 			)
 
 			got, err := s.GetFee(tt.vehicleType, tt.entryDates)
@@ -227,3 +233,1069 @@ func Test_feeService_getHolidays(t *testing.T) {
 		})
 	}
 }
+
+func Test_feeService_GetFees(t *testing.T) {
+	tests := []struct {
+		name        string
+		mocks       func(dagsmart *mock_dagsmart.MockService, pricelist *mock_pricelist.MockService)
+		vehicleType models.VehicleType
+		entryDates  []time.Time
+		want        Summary
+		wantErr     bool
+		wantErrText string
+	}{
+		{
+			name: "a toll free vehicle pays nothing on any day",
+			mocks: func(dagsmart *mock_dagsmart.MockService, pricelist *mock_pricelist.MockService) {
+			},
+			entryDates: []time.Time{
+				time.Date(2020, 1, 2, 10, 0, 0, 0, time.UTC),
+				time.Date(2020, 1, 3, 10, 0, 0, 0, time.UTC),
+			},
+			vehicleType: models.VehicleType("motorbike"),
+			want: Summary{
+				Total: 0,
+				Days: []DayFee{
+					{Date: "2020-01-02", Fee: 0},
+					{Date: "2020-01-03", Fee: 0},
+				},
+			},
+		},
+		{
+			name: "entry times are grouped into sorted billing days",
+			mocks: func(dagsmart *mock_dagsmart.MockService, pricelist *mock_pricelist.MockService) {
+				dagsmart.EXPECT().Get(mock.Anything, 2020).Return([]string{}, nil).Once()
+
+				pricelist.EXPECT().GetPrice(time.Date(2020, 1, 2, 10, 0, 0, 0, time.UTC)).Return(5)
+				pricelist.EXPECT().GetPrice(time.Date(2020, 1, 2, 10, 30, 0, 0, time.UTC)).Return(8)
+				pricelist.EXPECT().GetPrice(time.Date(2020, 1, 3, 10, 0, 0, 0, time.UTC)).Return(13)
+			},
+			entryDates: []time.Time{
+				time.Date(2020, 1, 3, 10, 0, 0, 0, time.UTC),
+				time.Date(2020, 1, 2, 10, 30, 0, 0, time.UTC),
+				time.Date(2020, 1, 2, 10, 0, 0, 0, time.UTC),
+			},
+			vehicleType: models.VehicleType("car"),
+			want: Summary{
+				Total: 21,
+				Days: []DayFee{
+					{Date: "2020-01-02", Fee: 8},
+					{Date: "2020-01-03", Fee: 13},
+				},
+			},
+		},
+		{
+			name: "daily maximum is applied to each day separately",
+			mocks: func(dagsmart *mock_dagsmart.MockService, pricelist *mock_pricelist.MockService) {
+				dagsmart.EXPECT().Get(mock.Anything, 2020).Return([]string{}, nil).Once()
+
+				pricelist.EXPECT().GetPrice(mock.Anything).Return(25)
+			},
+			entryDates: []time.Time{
+				time.Date(2020, 1, 2, 7, 0, 0, 0, time.UTC),
+				time.Date(2020, 1, 2, 9, 0, 0, 0, time.UTC),
+				time.Date(2020, 1, 2, 16, 0, 0, 0, time.UTC),
+				time.Date(2020, 1, 3, 7, 0, 0, 0, time.UTC),
+				time.Date(2020, 1, 3, 9, 0, 0, 0, time.UTC),
+				time.Date(2020, 1, 3, 16, 0, 0, 0, time.UTC),
+			},
+			vehicleType: models.VehicleType("car"),
+			want: Summary{
+				Total: 120,
+				Days: []DayFee{
+					{Date: "2020-01-02", Fee: 60},
+					{Date: "2020-01-03", Fee: 60},
+				},
+			},
+		},
+		{
+			name: "weekends and holidays are listed with no fee",
+			mocks: func(dagsmart *mock_dagsmart.MockService, pricelist *mock_pricelist.MockService) {
+				dagsmart.EXPECT().Get(mock.Anything, 2025).Return([]string{"2025-12-25"}, nil).Once()
+
+				pricelist.EXPECT().GetPrice(time.Date(2025, 12, 24, 7, 0, 0, 0, time.UTC)).Return(18)
+			},
+			entryDates: []time.Time{
+				time.Date(2025, 12, 24, 7, 0, 0, 0, time.UTC),
+				time.Date(2025, 12, 25, 7, 0, 0, 0, time.UTC),
+				time.Date(2025, 12, 27, 7, 0, 0, 0, time.UTC),
+			},
+			vehicleType: models.VehicleType("car"),
+			want: Summary{
+				Total: 18,
+				Days: []DayFee{
+					{Date: "2025-12-24", Fee: 18},
+					{Date: "2025-12-25", Fee: 0},
+					{Date: "2025-12-27", Fee: 0},
+				},
+			},
+		},
+		{
+			name: "holiday API returns an error",
+			mocks: func(dagsmart *mock_dagsmart.MockService, pricelist *mock_pricelist.MockService) {
+				dagsmart.EXPECT().Get(mock.Anything, mock.Anything).Return(nil, errors.New("some error"))
+			},
+			entryDates: []time.Time{
+				time.Date(2020, 1, 2, 1, 0, 0, 0, time.UTC),
+				time.Date(2020, 1, 3, 1, 0, 0, 0, time.UTC),
+			},
+			vehicleType: models.VehicleType("car"),
+			wantErr:     true,
+			wantErrText: "some error",
+		},
+		{
+			name: "invalid vehicle type",
+			mocks: func(dagsmart *mock_dagsmart.MockService, pricelist *mock_pricelist.MockService) {
+			},
+			entryDates: []time.Time{
+				time.Date(2020, 1, 2, 1, 0, 0, 0, time.UTC),
+			},
+			vehicleType: models.VehicleType("foo"),
+			wantErr:     true,
+			wantErrText: "unknown vehicle type",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockVehicleListGetter := mock_vehiclelist.NewMockGetter(t)
+			mockDagsmartService := mock_dagsmart.NewMockService(t)
+			mockpriceListService := mock_pricelist.NewMockService(t)
+
+			tt.mocks(mockDagsmartService, mockpriceListService)
+			mockVehicleListGetter.EXPECT().GetVehicleList().Return([]models.Vehicle{
+				models.NewVehicle("car", false),
+				models.NewVehicle("motorbike", true),
+			})
+
+			s := New(
+				mockVehicleListGetter,
+				holidays.NewCache(mockDagsmartService, 0),
+				Tariff{PriceList: mockpriceListService},
+				time.UTC,
+			)
+
+			got, err := s.GetFees(tt.vehicleType, tt.entryDates)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetFees() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && err.Error() != tt.wantErrText {
+				t.Errorf("GetFees() error = %v, wantErrText %v", err, tt.wantErrText)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetFees() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_feeService_Explain(t *testing.T) {
+	tests := []struct {
+		name        string
+		mocks       func(dagsmart *mock_dagsmart.MockService, pricelist *mock_pricelist.MockService)
+		vehicleType models.VehicleType
+		entryDates  []time.Time
+		want        Explanation
+		wantErr     bool
+		wantErrText string
+	}{
+		{
+			name: "toll free vehicle passages are classified without holiday lookup",
+			mocks: func(dagsmart *mock_dagsmart.MockService, pricelist *mock_pricelist.MockService) {
+			},
+			entryDates: []time.Time{
+				time.Date(2020, 1, 2, 10, 0, 0, 0, time.UTC),
+			},
+			vehicleType: models.VehicleType("motorbike"),
+			want: Explanation{
+				Date:     "2020-01-02",
+				DailyCap: 60,
+				Passages: []PassageExplanation{
+					{Timestamp: time.Date(2020, 1, 2, 10, 0, 0, 0, time.UTC), Classification: ClassificationTollFreeVehicle},
+				},
+				Blocks: []BlockExplanation{},
+			},
+		},
+		{
+			name: "weekend passages are classified as weekend",
+			mocks: func(dagsmart *mock_dagsmart.MockService, pricelist *mock_pricelist.MockService) {
+			},
+			entryDates: []time.Time{
+				time.Date(2025, 12, 6, 10, 0, 0, 0, time.UTC),
+			},
+			vehicleType: models.VehicleType("car"),
+			want: Explanation{
+				Date:     "2025-12-06",
+				DailyCap: 60,
+				Passages: []PassageExplanation{
+					{Timestamp: time.Date(2025, 12, 6, 10, 0, 0, 0, time.UTC), Classification: ClassificationWeekend},
+				},
+				Blocks: []BlockExplanation{},
+			},
+		},
+		{
+			name: "holiday passages are classified as public holiday",
+			mocks: func(dagsmart *mock_dagsmart.MockService, pricelist *mock_pricelist.MockService) {
+				dagsmart.EXPECT().Get(mock.Anything, 2020).Return([]string{"2020-01-01"}, nil)
+			},
+			entryDates: []time.Time{
+				time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC),
+			},
+			vehicleType: models.VehicleType("car"),
+			want: Explanation{
+				Date:     "2020-01-01",
+				DailyCap: 60,
+				Passages: []PassageExplanation{
+					{Timestamp: time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC), Classification: ClassificationPublicHoliday},
+				},
+				Blocks: []BlockExplanation{},
+			},
+		},
+		{
+			name: "billable passages form blocks with the winning price and the cap is reported",
+			mocks: func(dagsmart *mock_dagsmart.MockService, pricelist *mock_pricelist.MockService) {
+				dagsmart.EXPECT().Get(mock.Anything, 2020).Return([]string{}, nil)
+
+				pricelist.EXPECT().GetPrice(time.Date(2020, 1, 2, 7, 0, 0, 0, time.UTC)).Return(18)
+				pricelist.EXPECT().GetPrice(time.Date(2020, 1, 2, 7, 30, 0, 0, time.UTC)).Return(25)
+				pricelist.EXPECT().GetPrice(time.Date(2020, 1, 2, 9, 0, 0, 0, time.UTC)).Return(20)
+				pricelist.EXPECT().GetPrice(time.Date(2020, 1, 2, 16, 0, 0, 0, time.UTC)).Return(18)
+			},
+			entryDates: []time.Time{
+				time.Date(2020, 1, 2, 16, 0, 0, 0, time.UTC),
+				time.Date(2020, 1, 2, 7, 0, 0, 0, time.UTC),
+				time.Date(2020, 1, 2, 7, 30, 0, 0, time.UTC),
+				time.Date(2020, 1, 2, 9, 0, 0, 0, time.UTC),
+			},
+			vehicleType: models.VehicleType("car"),
+			want: Explanation{
+				Date:        "2020-01-02",
+				Fee:         60,
+				UncappedFee: 63,
+				DailyCap:    60,
+				CapApplied:  true,
+				Passages: []PassageExplanation{
+					{Timestamp: time.Date(2020, 1, 2, 7, 0, 0, 0, time.UTC), Classification: ClassificationBillable, Price: 18},
+					{Timestamp: time.Date(2020, 1, 2, 7, 30, 0, 0, time.UTC), Classification: ClassificationBillable, Price: 25},
+					{Timestamp: time.Date(2020, 1, 2, 9, 0, 0, 0, time.UTC), Classification: ClassificationBillable, Price: 20},
+					{Timestamp: time.Date(2020, 1, 2, 16, 0, 0, 0, time.UTC), Classification: ClassificationBillable, Price: 18},
+				},
+				Blocks: []BlockExplanation{
+					{Start: time.Date(2020, 1, 2, 7, 0, 0, 0, time.UTC), End: time.Date(2020, 1, 2, 8, 0, 0, 0, time.UTC), Price: 25},
+					{Start: time.Date(2020, 1, 2, 9, 0, 0, 0, time.UTC), End: time.Date(2020, 1, 2, 10, 0, 0, 0, time.UTC), Price: 20},
+					{Start: time.Date(2020, 1, 2, 16, 0, 0, 0, time.UTC), End: time.Date(2020, 1, 2, 17, 0, 0, 0, time.UTC), Price: 18},
+				},
+			},
+		},
+		{
+			name: "Explain expects all entry times on the same day",
+			mocks: func(dagsmart *mock_dagsmart.MockService, pricelist *mock_pricelist.MockService) {
+			},
+			entryDates: []time.Time{
+				time.Date(2020, 1, 1, 1, 0, 0, 0, time.UTC),
+				time.Date(2020, 1, 2, 6, 0, 0, 0, time.UTC),
+			},
+			vehicleType: models.VehicleType("car"),
+			wantErr:     true,
+			wantErrText: "GetFee call contains more than one day of entry times",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockVehicleListGetter := mock_vehiclelist.NewMockGetter(t)
+			mockDagsmartService := mock_dagsmart.NewMockService(t)
+			mockpriceListService := mock_pricelist.NewMockService(t)
+
+			tt.mocks(mockDagsmartService, mockpriceListService)
+			mockVehicleListGetter.EXPECT().GetVehicleList().Return([]models.Vehicle{
+				models.NewVehicle("car", false),
+				models.NewVehicle("motorbike", true),
+			})
+
+			s := New(
+				mockVehicleListGetter,
+				holidays.NewCache(mockDagsmartService, 0),
+				Tariff{PriceList: mockpriceListService},
+				time.UTC,
+			)
+
+			got, err := s.Explain(tt.vehicleType, tt.entryDates)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Explain() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && err.Error() != tt.wantErrText {
+				t.Errorf("Explain() error = %v, wantErrText %v", err, tt.wantErrText)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Explain() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_feeService_GetFees_billingTimezone(t *testing.T) {
+	stockholm, err := time.LoadLocation("Europe/Stockholm")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		mocks      func(dagsmart *mock_dagsmart.MockService, pricelist *mock_pricelist.MockService)
+		entryDates []time.Time
+		want       Summary
+	}{
+		{
+			name: "UTC entries are grouped by Stockholm calendar day",
+			mocks: func(dagsmart *mock_dagsmart.MockService, pricelist *mock_pricelist.MockService) {
+				dagsmart.EXPECT().Get(mock.Anything, 2025).Return([]string{}, nil)
+
+				pricelist.EXPECT().GetPrice(time.Date(2025, 12, 5, 0, 30, 0, 0, stockholm)).Return(1)
+				pricelist.EXPECT().GetPrice(time.Date(2025, 12, 5, 23, 30, 0, 0, stockholm)).Return(2)
+			},
+			entryDates: []time.Time{
+				time.Date(2025, 12, 4, 23, 30, 0, 0, time.UTC),
+				time.Date(2025, 12, 5, 22, 30, 0, 0, time.UTC),
+			},
+			want: Summary{
+				Total: 3,
+				Days:  []DayFee{{Date: "2025-12-05", Fee: 3}},
+			},
+		},
+		{
+			name: "a Saturday in Stockholm is toll free even if it is Friday in UTC",
+			mocks: func(dagsmart *mock_dagsmart.MockService, pricelist *mock_pricelist.MockService) {
+			},
+			entryDates: []time.Time{
+				time.Date(2025, 12, 5, 23, 30, 0, 0, time.UTC),
+			},
+			want: Summary{
+				Total: 0,
+				Days:  []DayFee{{Date: "2025-12-06", Fee: 0}},
+			},
+		},
+		{
+			name: "the 25 hour day when daylight saving time ends is a single billing day",
+			mocks: func(dagsmart *mock_dagsmart.MockService, pricelist *mock_pricelist.MockService) {
+			},
+			entryDates: []time.Time{
+				time.Date(2025, 10, 25, 22, 30, 0, 0, time.UTC), // 00:30 CEST
+				time.Date(2025, 10, 26, 22, 30, 0, 0, time.UTC), // 23:30 CET
+			},
+			want: Summary{
+				Total: 0,
+				Days:  []DayFee{{Date: "2025-10-26", Fee: 0}},
+			},
+		},
+		{
+			name: "the 23 hour day when daylight saving time starts is a single billing day",
+			mocks: func(dagsmart *mock_dagsmart.MockService, pricelist *mock_pricelist.MockService) {
+			},
+			entryDates: []time.Time{
+				time.Date(2025, 3, 29, 23, 30, 0, 0, time.UTC), // 00:30 CET
+				time.Date(2025, 3, 30, 21, 30, 0, 0, time.UTC), // 23:30 CEST
+			},
+			want: Summary{
+				Total: 0,
+				Days:  []DayFee{{Date: "2025-03-30", Fee: 0}},
+			},
+		},
+		{
+			name: "UTC entries are grouped by Stockholm calendar day in summer time",
+			mocks: func(dagsmart *mock_dagsmart.MockService, pricelist *mock_pricelist.MockService) {
+				dagsmart.EXPECT().Get(mock.Anything, 2025).Return([]string{}, nil)
+
+				pricelist.EXPECT().GetPrice(time.Date(2025, 6, 5, 0, 30, 0, 0, stockholm)).Return(1)
+				pricelist.EXPECT().GetPrice(time.Date(2025, 6, 5, 23, 30, 0, 0, stockholm)).Return(2)
+			},
+			entryDates: []time.Time{
+				time.Date(2025, 6, 4, 22, 30, 0, 0, time.UTC),
+				time.Date(2025, 6, 5, 21, 30, 0, 0, time.UTC),
+			},
+			want: Summary{
+				Total: 3,
+				Days:  []DayFee{{Date: "2025-06-05", Fee: 3}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockVehicleListGetter := mock_vehiclelist.NewMockGetter(t)
+			mockDagsmartService := mock_dagsmart.NewMockService(t)
+			mockpriceListService := mock_pricelist.NewMockService(t)
+
+			tt.mocks(mockDagsmartService, mockpriceListService)
+			mockVehicleListGetter.EXPECT().GetVehicleList().Return([]models.Vehicle{
+				models.NewVehicle("car", false),
+			})
+
+			s := New(
+				mockVehicleListGetter,
+				holidays.NewCache(mockDagsmartService, 0),
+				Tariff{PriceList: mockpriceListService},
+				stockholm,
+			)
+
+			got, err := s.GetFees("car", tt.entryDates)
+			if err != nil {
+				t.Errorf("GetFees() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetFees() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// uncomparableVehicle is a vehicle that cannot be compared with ==, as a vehicle holding a slice.
+type uncomparableVehicle struct {
+	models.Vehicle
+	permits []string
+}
+
+func Test_feeService_PrepareReload(t *testing.T) {
+	entryDates := []time.Time{time.Date(2020, 1, 2, 10, 0, 0, 0, time.UTC)}
+
+	tests := []struct {
+		name        string
+		vehicles    []models.Vehicle
+		mocks       func(reloaded *mock_pricelist.MockService)
+		wantFees    map[models.VehicleType]int
+		wantErrText string
+	}{
+		{
+			name: "reload swaps in the new vehicle list and price list",
+			vehicles: []models.Vehicle{
+				models.NewVehicle("car", false),
+				models.NewVehicle("bus", false),
+			},
+			mocks: func(reloaded *mock_pricelist.MockService) {
+				reloaded.EXPECT().GetPrice(mock.Anything).Return(20)
+			},
+			wantFees: map[models.VehicleType]int{"car": 20, "bus": 20},
+		},
+		{
+			name: "invalid vehicle list keeps the previous configuration",
+			vehicles: []models.Vehicle{
+				models.NewVehicle("bus", false),
+				models.NewVehicle("bus", true),
+			},
+			wantFees:    map[models.VehicleType]int{"car": 10},
+			wantErrText: `vehicle type "bus" is listed as both toll-free and billable`,
+		},
+		{
+			name: "vehicle type listed twice with the same details",
+			vehicles: []models.Vehicle{
+				models.NewVehicle("car", false),
+				uncomparableVehicle{Vehicle: models.NewVehicle("bus", false), permits: []string{"a"}},
+				uncomparableVehicle{Vehicle: models.NewVehicle("bus", false), permits: []string{"b"}},
+			},
+			mocks: func(reloaded *mock_pricelist.MockService) {
+				reloaded.EXPECT().GetPrice(mock.Anything).Return(20)
+			},
+			wantFees: map[models.VehicleType]int{"car": 20, "bus": 20},
+		},
+		{
+			name: "vehicle type listed twice with different classes",
+			vehicles: []models.Vehicle{
+				models.NewClassifiedVehicle("bus", false, models.WeightClassHeavy, "", models.Exemption{}),
+				models.NewClassifiedVehicle("bus", false, models.WeightClassLight, "", models.Exemption{}),
+			},
+			wantFees:    map[models.VehicleType]int{"car": 10},
+			wantErrText: `vehicle type "bus" is listed twice with different details`,
+		},
+		{
+			name:        "empty vehicle list keeps the previous configuration",
+			vehicles:    []models.Vehicle{},
+			wantFees:    map[models.VehicleType]int{"car": 10},
+			wantErrText: "vehicle list is empty",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockVehicleListGetter := mock_vehiclelist.NewMockGetter(t)
+			mockDagsmartService := mock_dagsmart.NewMockService(t)
+			initialPriceList := mock_pricelist.NewMockService(t)
+			reloadedPriceList := mock_pricelist.NewMockService(t)
+
+			if tt.mocks != nil {
+				tt.mocks(reloadedPriceList)
+			}
+			mockVehicleListGetter.EXPECT().GetVehicleList().Return([]models.Vehicle{
+				models.NewVehicle("car", false),
+			}).Once()
+			mockDagsmartService.EXPECT().Get(mock.Anything, 2020).Return([]string{}, nil).Once()
+			initialPriceList.EXPECT().GetPrice(mock.Anything).Return(10).Maybe()
+
+			holidayCache := holidays.NewCache(mockDagsmartService, 0)
+			s := New(mockVehicleListGetter, holidayCache, Tariff{PriceList: initialPriceList}, time.UTC)
+
+			commit, err := s.PrepareReload(Tariff{PriceList: reloadedPriceList}, tt.vehicles, holidayCache)
+			if (err != nil) != (tt.wantErrText != "") {
+				t.Fatalf("PrepareReload() error = %v, wantErrText %v", err, tt.wantErrText)
+			}
+			if err != nil {
+				if err.Error() != tt.wantErrText {
+					t.Errorf("PrepareReload() error = %v, wantErrText %v", err, tt.wantErrText)
+				}
+			} else {
+				// Nothing is swapped in before the commit.
+				if _, err := s.GetFee("bus", entryDates); !errors.Is(err, ErrUnknownVehicleType) {
+					t.Errorf("GetFee(bus) before commit error = %v, want %v", err, ErrUnknownVehicleType)
+				}
+				commit()
+			}
+
+			for vehicleType, want := range tt.wantFees {
+				got, err := s.GetFee(vehicleType, entryDates)
+				if err != nil {
+					t.Fatalf("GetFee(%v) error = %v", vehicleType, err)
+				}
+				if got != want {
+					t.Errorf("GetFee(%v) got = %v, want %v", vehicleType, got, want)
+				}
+			}
+		})
+	}
+}
+
+func Test_feeService_GetFee_holidayErrorIsNotCached(t *testing.T) {
+	mockVehicleListGetter := mock_vehiclelist.NewMockGetter(t)
+	mockDagsmartService := mock_dagsmart.NewMockService(t)
+	mockpriceListService := mock_pricelist.NewMockService(t)
+
+	mockVehicleListGetter.EXPECT().GetVehicleList().Return([]models.Vehicle{
+		models.NewVehicle("car", false),
+	})
+	mockDagsmartService.EXPECT().Get(mock.Anything, 2020).Return(nil, errors.New("some error")).Once()
+	mockDagsmartService.EXPECT().Get(mock.Anything, 2020).Return([]string{"2020-01-01"}, nil).Once()
+
+	s := New(
+		mockVehicleListGetter,
+		holidays.NewCache(mockDagsmartService, 0),
+		Tariff{PriceList: mockpriceListService},
+		time.UTC,
+	)
+
+	entryDates := []time.Time{time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC)}
+	if _, err := s.GetFee("car", entryDates); err == nil {
+		t.Fatal("GetFee() expected error from holiday source")
+	}
+
+	got, err := s.GetFee("car", entryDates)
+	if err != nil {
+		t.Fatalf("GetFee() error = %v", err)
+	}
+	if got != 0 {
+		t.Errorf("GetFee() got = %v, want 0 on a public holiday", got)
+	}
+}
+
+func Test_feeService_Explain_calendarRules(t *testing.T) {
+	mockVehicleListGetter := mock_vehiclelist.NewMockGetter(t)
+	mockDagsmartService := mock_dagsmart.NewMockService(t)
+	mockpriceListService := mock_pricelist.NewMockService(t)
+
+	mockVehicleListGetter.EXPECT().GetVehicleList().Return([]models.Vehicle{
+		models.NewVehicle("car", false),
+	})
+	mockDagsmartService.EXPECT().Get(mock.Anything, 2025).Return([]string{"2025-05-01"}, nil).Once()
+	mockpriceListService.EXPECT().GetPrice(mock.Anything).Return(9)
+
+	s := New(
+		mockVehicleListGetter,
+		holidays.NewCache(mockDagsmartService, 0),
+		Tariff{
+			PriceList: mockpriceListService,
+			Calendar: []calendar.Rule{
+				calendar.Weekend(),
+				calendar.PublicHoliday(),
+				calendar.DayBeforePublicHoliday(),
+				calendar.Month(time.July),
+				calendar.Dates("2025-06-05"),
+			},
+		},
+		time.UTC,
+	)
+
+	tests := []struct {
+		entryDate time.Time
+		want      Classification
+		wantFee   int
+	}{
+		{time.Date(2025, 4, 29, 10, 0, 0, 0, time.UTC), ClassificationBillable, 9},
+		{time.Date(2025, 4, 30, 10, 0, 0, 0, time.UTC), ClassificationDayBeforePublicHoliday, 0},
+		{time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC), ClassificationPublicHoliday, 0},
+		{time.Date(2025, 5, 3, 10, 0, 0, 0, time.UTC), ClassificationWeekend, 0},
+		{time.Date(2025, 6, 5, 10, 0, 0, 0, time.UTC), ClassificationExemptDate, 0},
+		{time.Date(2025, 7, 15, 10, 0, 0, 0, time.UTC), ClassificationExemptMonth, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.entryDate.Format(models.PUBLIC_HOLIDAY_DATE_FORMAT), func(t *testing.T) {
+			got, err := s.Explain("car", []time.Time{tt.entryDate})
+			if err != nil {
+				t.Fatalf("Explain() error = %v", err)
+			}
+			if got.Passages[0].Classification != tt.want {
+				t.Errorf("Explain() classification = %v, want %v", got.Passages[0].Classification, tt.want)
+			}
+			if got.Fee != tt.wantFee {
+				t.Errorf("Explain() fee = %v, want %v", got.Fee, tt.wantFee)
+			}
+		})
+	}
+}
+
+func dailyCap(n int) *int {
+	return &n
+}
+
+func Test_feeService_Explain_windowSettings(t *testing.T) {
+	at := func(hour, min int) time.Time {
+		return time.Date(2025, 3, 4, hour, min, 0, 0, time.UTC)
+	}
+	entryDates := []time.Time{at(6, 50), at(7, 10), at(7, 40), at(8, 15), at(16, 0)}
+
+	tests := []struct {
+		name           string
+		tariff         Tariff
+		wantBlocks     []BlockExplanation
+		wantFee        int
+		wantCapApplied bool
+	}{
+		{
+			name:   "defaults open a one hour window at the first passage and cap at 60",
+			tariff: Tariff{},
+			wantBlocks: []BlockExplanation{
+				{Start: at(6, 50), End: at(7, 50), Price: 18},
+				{Start: at(8, 15), End: at(9, 15), Price: 13},
+				{Start: at(16, 0), End: at(17, 0), Price: 18},
+			},
+			wantFee: 49,
+		},
+		{
+			name:   "clock hours with a lower cap",
+			tariff: Tariff{DailyCap: dailyCap(45), WindowStrategy: window.ClockHour},
+			wantBlocks: []BlockExplanation{
+				{Start: at(6, 0), End: at(7, 0), Price: 13},
+				{Start: at(7, 0), End: at(8, 0), Price: 18},
+				{Start: at(8, 0), End: at(9, 0), Price: 13},
+				{Start: at(16, 0), End: at(17, 0), Price: 18},
+			},
+			wantFee:        45,
+			wantCapApplied: true,
+		},
+		{
+			name:   "clock hours without a daily cap",
+			tariff: Tariff{DailyCap: dailyCap(0), WindowStrategy: window.ClockHour},
+			wantBlocks: []BlockExplanation{
+				{Start: at(6, 0), End: at(7, 0), Price: 13},
+				{Start: at(7, 0), End: at(8, 0), Price: 18},
+				{Start: at(8, 0), End: at(9, 0), Price: 13},
+				{Start: at(16, 0), End: at(17, 0), Price: 18},
+			},
+			wantFee: 62,
+		},
+		{
+			name:   "sliding window of 90 minutes",
+			tariff: Tariff{Window: 90 * time.Minute, WindowStrategy: window.SlidingHighest},
+			wantBlocks: []BlockExplanation{
+				{Start: at(6, 50), End: at(8, 15), Price: 18},
+				{Start: at(16, 0), End: at(16, 0), Price: 18},
+			},
+			wantFee: 36,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockVehicleListGetter := mock_vehiclelist.NewMockGetter(t)
+			mockDagsmartService := mock_dagsmart.NewMockService(t)
+			mockpriceListService := mock_pricelist.NewMockService(t)
+
+			mockVehicleListGetter.EXPECT().GetVehicleList().Return([]models.Vehicle{
+				models.NewVehicle("car", false),
+			})
+			mockDagsmartService.EXPECT().Get(mock.Anything, 2025).Return([]string{}, nil).Once()
+			mockpriceListService.EXPECT().GetPrice(at(6, 50)).Return(13)
+			mockpriceListService.EXPECT().GetPrice(at(7, 10)).Return(18)
+			mockpriceListService.EXPECT().GetPrice(at(7, 40)).Return(18)
+			mockpriceListService.EXPECT().GetPrice(at(8, 15)).Return(13)
+			mockpriceListService.EXPECT().GetPrice(at(16, 0)).Return(18)
+
+			tt.tariff.PriceList = mockpriceListService
+			s := New(mockVehicleListGetter, holidays.NewCache(mockDagsmartService, 0), tt.tariff, time.UTC)
+
+			got, err := s.Explain("car", entryDates)
+			if err != nil {
+				t.Fatalf("Explain() error = %v", err)
+			}
+			if !reflect.DeepEqual(got.Blocks, tt.wantBlocks) {
+				t.Errorf("Explain() blocks = %v, want %v", got.Blocks, tt.wantBlocks)
+			}
+			if got.Fee != tt.wantFee || got.CapApplied != tt.wantCapApplied {
+				t.Errorf("Explain() fee = %v capApplied = %v, want %v %v", got.Fee, got.CapApplied, tt.wantFee, tt.wantCapApplied)
+			}
+		})
+	}
+}
+
+func Test_feeService_PrepareReload_invalidTariff(t *testing.T) {
+	mockVehicleListGetter := mock_vehiclelist.NewMockGetter(t)
+	mockVehicleListGetter.EXPECT().GetVehicleList().Return([]models.Vehicle{
+		models.NewVehicle("car", false),
+	}).Once()
+
+	holidayCache := holidays.NewCache(mock_dagsmart.NewMockService(t), 0)
+	s := New(mockVehicleListGetter, holidayCache, Tariff{}, time.UTC)
+
+	_, err := s.PrepareReload(Tariff{Window: 7 * time.Hour, WindowStrategy: window.ClockHour},
+		[]models.Vehicle{models.NewVehicle("car", false)}, holidayCache)
+	if err == nil || err.Error() != "window 7h0m0s must divide the day evenly for the clockHour strategy" {
+		t.Errorf("PrepareReload() error = %v", err)
+	}
+}
+
+func Test_feeService_ExplainVehicle(t *testing.T) {
+	mockVehicleListGetter := mock_vehiclelist.NewMockGetter(t)
+	mockDagsmartService := mock_dagsmart.NewMockService(t)
+	mockpriceListService := mock_pricelist.NewMockService(t)
+
+	mockVehicleListGetter.EXPECT().GetVehicleList().Return([]models.Vehicle{
+		models.NewVehicle("car", false),
+		models.NewVehicle("motorbike", true),
+	})
+	mockDagsmartService.EXPECT().Get(mock.Anything, 2025).Return([]string{}, nil).Maybe()
+	mockpriceListService.EXPECT().GetPrice(mock.Anything).Return(9).Maybe()
+
+	s := New(
+		mockVehicleListGetter,
+		holidays.NewCache(mockDagsmartService, 0),
+		Tariff{PriceList: mockpriceListService},
+		time.UTC,
+	)
+
+	entryDates := []time.Time{time.Date(2025, 4, 29, 10, 0, 0, 0, time.UTC)}
+	tests := []struct {
+		name    string
+		vehicle models.Vehicle
+		wantFee int
+		wantErr error
+	}{
+		{name: "billable vehicle", vehicle: models.NewVehicle("car", false), wantFee: 9},
+		{name: "toll free vehicle of a billable type", vehicle: models.NewVehicle("car", true), wantFee: 0},
+		{name: "vehicle of a toll free type", vehicle: models.NewVehicle("motorbike", false), wantFee: 0},
+		{name: "unknown vehicle type", vehicle: models.NewVehicle("truck", false), wantErr: ErrUnknownVehicleType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.ExplainVehicle(tt.vehicle, entryDates)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ExplainVehicle() error = %v, want %v", err, tt.wantErr)
+			}
+			if got.Fee != tt.wantFee {
+				t.Errorf("ExplainVehicle() fee = %v, want %v", got.Fee, tt.wantFee)
+			}
+		})
+	}
+}
+
+func Test_feeService_Explain_exemptionPeriod(t *testing.T) {
+	mockVehicleListGetter := mock_vehiclelist.NewMockGetter(t)
+	mockDagsmartService := mock_dagsmart.NewMockService(t)
+	mockpriceListService := mock_pricelist.NewMockService(t)
+
+	mockVehicleListGetter.EXPECT().GetVehicleList().Return([]models.Vehicle{
+		models.NewClassifiedVehicle("electric-heavy", true, models.WeightClassHeavy, models.EmissionClassElectric,
+			models.Exemption{From: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}),
+	})
+	mockDagsmartService.EXPECT().Get(mock.Anything, 2024).Return([]string{}, nil).Once()
+	mockpriceListService.EXPECT().GetPrice(mock.Anything).Return(9).Once()
+
+	s := New(
+		mockVehicleListGetter,
+		holidays.NewCache(mockDagsmartService, 0),
+		Tariff{PriceList: mockpriceListService},
+		time.UTC,
+	)
+
+	tests := []struct {
+		entryDate time.Time
+		want      Classification
+		wantFee   int
+	}{
+		{time.Date(2024, 12, 31, 10, 0, 0, 0, time.UTC), ClassificationBillable, 9},
+		{time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC), ClassificationTollFreeVehicle, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.entryDate.Format(models.PUBLIC_HOLIDAY_DATE_FORMAT), func(t *testing.T) {
+			got, err := s.Explain("electric-heavy", []time.Time{tt.entryDate})
+			if err != nil {
+				t.Fatalf("Explain() error = %v", err)
+			}
+			if got.Passages[0].Classification != tt.want {
+				t.Errorf("Explain() classification = %v, want %v", got.Passages[0].Classification, tt.want)
+			}
+			if got.Fee != tt.wantFee {
+				t.Errorf("Explain() fee = %v, want %v", got.Fee, tt.wantFee)
+			}
+		})
+	}
+}
+
+func Test_feeService_Explain_multipliers(t *testing.T) {
+	mockVehicleListGetter := mock_vehiclelist.NewMockGetter(t)
+	mockDagsmartService := mock_dagsmart.NewMockService(t)
+	mockpriceListService := mock_pricelist.NewMockService(t)
+
+	mockVehicleListGetter.EXPECT().GetVehicleList().Return([]models.Vehicle{
+		models.NewVehicle("car", false),
+		models.NewClassifiedVehicle("truck", false, models.WeightClassHeavy, models.EmissionClassDiesel, models.Exemption{}),
+		models.NewClassifiedVehicle("van", false, models.WeightClassLight, models.EmissionClassElectric, models.Exemption{}),
+	})
+	mockDagsmartService.EXPECT().Get(mock.Anything, 2025).Return([]string{}, nil).Once()
+	mockpriceListService.EXPECT().GetPrice(mock.Anything).Return(13)
+
+	multipliers := []multiplier.Multiplier{
+		{WeightClass: models.WeightClassHeavy, Factor: 2},
+		{EmissionClass: models.EmissionClassElectric, Factor: 0.5},
+	}
+	s := New(
+		mockVehicleListGetter,
+		holidays.NewCache(mockDagsmartService, 0),
+		Tariff{PriceList: mockpriceListService, Multipliers: multipliers, Rounding: multiplier.Down},
+		time.UTC,
+	)
+
+	entryDates := []time.Time{
+		time.Date(2025, 4, 29, 7, 0, 0, 0, time.UTC),
+		time.Date(2025, 4, 29, 9, 0, 0, 0, time.UTC),
+		time.Date(2025, 4, 29, 11, 0, 0, 0, time.UTC),
+	}
+	tests := []struct {
+		vehicleType      models.VehicleType
+		wantPrice        int
+		wantBasePrice    int
+		wantFee          int
+		wantDailyCap     int
+		wantMultipliers  []multiplier.Multiplier
+		wantFactor       float64
+		wantBaseDailyCap int
+	}{
+		{vehicleType: "car", wantPrice: 13, wantFee: 39, wantDailyCap: 60},
+		{
+			vehicleType:      "truck",
+			wantPrice:        26,
+			wantBasePrice:    13,
+			wantFee:          78,
+			wantDailyCap:     120,
+			wantMultipliers:  multipliers[:1],
+			wantFactor:       2,
+			wantBaseDailyCap: 60,
+		},
+		{
+			vehicleType:      "van",
+			wantPrice:        6,
+			wantBasePrice:    13,
+			wantFee:          18,
+			wantDailyCap:     30,
+			wantMultipliers:  multipliers[1:],
+			wantFactor:       0.5,
+			wantBaseDailyCap: 60,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.vehicleType), func(t *testing.T) {
+			got, err := s.Explain(tt.vehicleType, entryDates)
+			if err != nil {
+				t.Fatalf("Explain() error = %v", err)
+			}
+			if got.Passages[0].Price != tt.wantPrice || got.Passages[0].BasePrice != tt.wantBasePrice {
+				t.Errorf("Explain() passage price = %v (base %v), want %v (base %v)",
+					got.Passages[0].Price, got.Passages[0].BasePrice, tt.wantPrice, tt.wantBasePrice)
+			}
+			if got.Fee != tt.wantFee || got.DailyCap != tt.wantDailyCap || got.BaseDailyCap != tt.wantBaseDailyCap {
+				t.Errorf("Explain() fee = %v, cap = %v (base %v), want %v, %v (base %v)",
+					got.Fee, got.DailyCap, got.BaseDailyCap, tt.wantFee, tt.wantDailyCap, tt.wantBaseDailyCap)
+			}
+			if !reflect.DeepEqual(got.Multipliers, tt.wantMultipliers) || got.Factor != tt.wantFactor {
+				t.Errorf("Explain() multipliers = %v x%v, want %v x%v",
+					got.Multipliers, got.Factor, tt.wantMultipliers, tt.wantFactor)
+			}
+		})
+	}
+}
+
+func Test_feeService_GetPassageFees_dedup(t *testing.T) {
+	mockVehicleListGetter := mock_vehiclelist.NewMockGetter(t)
+	mockDagsmartService := mock_dagsmart.NewMockService(t)
+	mockpriceListService := mock_pricelist.NewMockService(t)
+
+	mockVehicleListGetter.EXPECT().GetVehicleList().Return([]models.Vehicle{models.NewVehicle("car", false)})
+	mockDagsmartService.EXPECT().Get(mock.Anything, 2025).Return([]string{}, nil).Once()
+	mockpriceListService.EXPECT().GetPrice(mock.Anything).Return(8)
+
+	s := New(
+		mockVehicleListGetter,
+		holidays.NewCache(mockDagsmartService, 0),
+		Tariff{PriceList: mockpriceListService, DedupWindow: 10 * time.Second},
+		time.UTC,
+	)
+
+	at := func(hour, minute, second int) time.Time {
+		return time.Date(2025, 4, 29, hour, minute, second, 0, time.UTC)
+	}
+	tests := []struct {
+		name          string
+		passages      []models.Passage
+		wantFee       int
+		wantCollapsed int
+	}{
+		{
+			name: "repeated reads at the same gantry are collapsed",
+			passages: []models.Passage{
+				{Gantry: "STO-01", Timestamp: at(7, 0, 0)},
+				{Gantry: "STO-01", Timestamp: at(7, 0, 4)},
+				{Gantry: "STO-01", Timestamp: at(7, 0, 9)},
+				{Gantry: "STO-01", Timestamp: at(9, 0, 0)},
+			},
+			wantFee:       16,
+			wantCollapsed: 2,
+		},
+		{
+			name: "window is measured from the last kept passage",
+			passages: []models.Passage{
+				{Gantry: "STO-01", Timestamp: at(7, 0, 0)},
+				{Gantry: "STO-01", Timestamp: at(7, 0, 6)},
+				{Gantry: "STO-01", Timestamp: at(7, 0, 12)},
+			},
+			wantFee:       8,
+			wantCollapsed: 1,
+		},
+		{
+			name: "passage at the end of the window is kept",
+			passages: []models.Passage{
+				{Gantry: "STO-01", Timestamp: at(7, 0, 10)},
+				{Gantry: "STO-01", Timestamp: at(7, 0, 0)},
+			},
+			wantFee:       8,
+			wantCollapsed: 0,
+		},
+		{
+			name: "passages at different gantries are kept",
+			passages: []models.Passage{
+				{Gantry: "STO-01", Timestamp: at(7, 0, 0)},
+				{Gantry: "STO-02", Timestamp: at(7, 0, 2)},
+			},
+			wantFee:       8,
+			wantCollapsed: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.GetPassageFees("car", tt.passages)
+			if err != nil {
+				t.Fatalf("GetPassageFees() error = %v", err)
+			}
+			want := Summary{
+				Total:     tt.wantFee,
+				Days:      []DayFee{{Date: "2025-04-29", Fee: tt.wantFee, Collapsed: tt.wantCollapsed}},
+				Collapsed: tt.wantCollapsed,
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("GetPassageFees() got = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func Test_feeService_Explain_dedup(t *testing.T) {
+	mockVehicleListGetter := mock_vehiclelist.NewMockGetter(t)
+	mockDagsmartService := mock_dagsmart.NewMockService(t)
+	mockpriceListService := mock_pricelist.NewMockService(t)
+
+	mockVehicleListGetter.EXPECT().GetVehicleList().Return([]models.Vehicle{models.NewVehicle("car", false)})
+	mockDagsmartService.EXPECT().Get(mock.Anything, 2025).Return([]string{}, nil).Once()
+	mockpriceListService.EXPECT().GetPrice(mock.Anything).Return(8)
+
+	s := New(
+		mockVehicleListGetter,
+		holidays.NewCache(mockDagsmartService, 0),
+		Tariff{PriceList: mockpriceListService, DedupWindow: time.Minute},
+		time.UTC,
+	)
+
+	got, err := s.Explain("car", []time.Time{
+		time.Date(2025, 4, 29, 7, 0, 0, 0, time.UTC),
+		time.Date(2025, 4, 29, 7, 0, 30, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Explain() error = %v", err)
+	}
+	if got.Collapsed != 1 {
+		t.Errorf("Explain() collapsed = %v, want 1", got.Collapsed)
+	}
+	if got.Passages[1].Classification != ClassificationDuplicate || got.Passages[1].Price != 0 {
+		t.Errorf("Explain() second passage = %+v, want an unpriced duplicate", got.Passages[1])
+	}
+}
+
+// exemptVehicle is a car with an exemption of its own.
+type exemptVehicle struct {
+	models.Vehicle
+	from, to time.Time
+}
+
+func (v exemptVehicle) ExemptionAt(t time.Time) (string, bool) {
+	return "disabled_driver", !t.Before(v.from) && t.Before(v.to)
+}
+
+func Test_feeService_ExplainVehicle_individualExemption(t *testing.T) {
+	mockVehicleListGetter := mock_vehiclelist.NewMockGetter(t)
+	mockDagsmartService := mock_dagsmart.NewMockService(t)
+	mockpriceListService := mock_pricelist.NewMockService(t)
+
+	mockVehicleListGetter.EXPECT().GetVehicleList().Return([]models.Vehicle{
+		models.NewVehicle("car", false),
+	})
+	mockDagsmartService.EXPECT().Get(mock.Anything, 2025).Return([]string{}, nil).Once()
+	mockpriceListService.EXPECT().GetPrice(mock.Anything).Return(9).Once()
+
+	s := New(
+		mockVehicleListGetter,
+		holidays.NewCache(mockDagsmartService, 0),
+		Tariff{PriceList: mockpriceListService},
+		time.UTC,
+	)
+
+	vehicle := exemptVehicle{
+		Vehicle: models.NewVehicle("car", false),
+		from:    time.Date(2025, 4, 29, 8, 0, 0, 0, time.UTC),
+		to:      time.Date(2025, 4, 30, 0, 0, 0, 0, time.UTC),
+	}
+	got, err := s.ExplainVehicle(vehicle, []time.Time{
+		time.Date(2025, 4, 29, 7, 0, 0, 0, time.UTC),
+		time.Date(2025, 4, 29, 9, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("ExplainVehicle() error = %v", err)
+	}
+
+	want := []PassageExplanation{
+		{Timestamp: time.Date(2025, 4, 29, 7, 0, 0, 0, time.UTC), Classification: ClassificationBillable, Price: 9},
+		{
+			Timestamp:      time.Date(2025, 4, 29, 9, 0, 0, 0, time.UTC),
+			Classification: ClassificationExemptVehicle,
+			Exemption:      "disabled_driver",
+		},
+	}
+	if !reflect.DeepEqual(got.Passages, want) {
+		t.Errorf("ExplainVehicle() passages = %+v, want %+v", got.Passages, want)
+	}
+	if got.Fee != 9 {
+		t.Errorf("ExplainVehicle() fee = %v, want 9", got.Fee)
+	}
+}
@@ -0,0 +1,74 @@
+package fee
+
+import (
+	"errors"
+	"fmt"
+
+	"afry-toll-calculator/models"
+	"afry-toll-calculator/services/holidays"
+)
+
+// snapshot holds all lookups a fee calculation depends on. A snapshot is never modified after it has been
+// published, apart from the holidays it caches, so a calculation that loads it once sees a consistent
+// configuration even if a reload happens in the meantime.
+type snapshot struct {
+	vehicleLookup map[models.VehicleType]models.Vehicle
+	tariff        Tariff
+	holidays      *holidays.Cache
+}
+
+func newSnapshot(vehicles []models.Vehicle, tariff Tariff, holidayCache *holidays.Cache) *snapshot {
+	vl := map[models.VehicleType]models.Vehicle{}
+	for _, v := range vehicles {
+		vl[v.GetType()] = v
+	}
+
+	return &snapshot{
+		vehicleLookup: vl,
+		tariff:        tariff.withDefaults(),
+		holidays:      holidayCache,
+	}
+}
+
+// PrepareReload validates a new tariff and vehicle list and builds a configuration from them and the holiday cache,
+// without swapping it in. It returns a function that swaps the configuration in; calculations that are in progress
+// then finish with the previous configuration. Preparing does not change the service, so the previous configuration
+// is kept if it fails.
+func (s *feeService) PrepareReload(tariff Tariff, vehicles []models.Vehicle, holidayCache *holidays.Cache) (func(), error) {
+	if err := tariff.withDefaults().validate(); err != nil {
+		return nil, err
+	}
+	if err := validateVehicleList(vehicles); err != nil {
+		return nil, err
+	}
+
+	next := newSnapshot(vehicles, tariff, holidayCache)
+
+	return func() { s.current.Store(next) }, nil
+}
+
+func validateVehicleList(vehicles []models.Vehicle) error {
+	if len(vehicles) == 0 {
+		return errors.New("vehicle list is empty")
+	}
+
+	seen := map[models.VehicleType]models.Vehicle{}
+	for _, v := range vehicles {
+		if v.GetType() == "" {
+			return errors.New("vehicle list contains a vehicle without a type")
+		}
+		if previous, ok := seen[v.GetType()]; ok {
+			if previous.IsTollFree() != v.IsTollFree() {
+				return fmt.Errorf("vehicle type %q is listed as both toll-free and billable", v.GetType())
+			}
+			// Vehicles are compared by their details, since comparing the interface values panics for vehicle
+			// implementations that are not comparable.
+			if previous.GetWeightClass() != v.GetWeightClass() || previous.GetEmissionClass() != v.GetEmissionClass() {
+				return fmt.Errorf("vehicle type %q is listed twice with different details", v.GetType())
+			}
+		}
+		seen[v.GetType()] = v
+	}
+
+	return nil
+}
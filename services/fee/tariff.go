@@ -0,0 +1,88 @@
+package fee
+
+import (
+	"fmt"
+	"time"
+
+	"afry-toll-calculator/services/calendar"
+	"afry-toll-calculator/services/multiplier"
+	"afry-toll-calculator/services/pricelist"
+	"afry-toll-calculator/services/window"
+)
+
+// Defaults applied to the zero values of a Tariff, which match the Stockholm congestion tax.
+const (
+	defaultDailyCap       = 60
+	defaultWindow         = time.Hour
+	defaultWindowStrategy = window.FirstPassage
+	defaultRounding       = multiplier.Nearest
+)
+
+// Tariff is the deployment specific part of the fee calculation, which can be replaced by a reload.
+type Tariff struct {
+	// Version identifies the tariff document the tariff was loaded from, and Currency is the currency its prices
+	// are expressed in. Both are only recorded in explanations.
+	Version   string
+	Currency  string
+	PriceList pricelist.Service
+	// Calendar lists the rules that make a day toll-free, evaluated in order. A nil Calendar applies
+	// calendar.Default().
+	Calendar []calendar.Rule
+	// DailyCap is the maximum fee of a single billing day. Nil applies a cap of 60, and zero charges every day in
+	// full.
+	DailyCap *int
+	// Window is the length of the window within which passages are charged only once, and WindowStrategy
+	// decides how those windows are formed. Zero values apply one hour and window.FirstPassage.
+	Window         time.Duration
+	WindowStrategy window.Strategy
+	// Multipliers scale the passage prices and the daily cap of vehicles in matching weight and emission classes,
+	// rounded with Rounding. A zero Rounding applies multiplier.Nearest.
+	Multipliers []multiplier.Multiplier
+	Rounding    multiplier.Rounding
+	// DedupWindow collapses passages at the same gantry less than the window apart into the first of them.
+	// Zero keeps all passages.
+	DedupWindow time.Duration
+}
+
+// withDefaults returns the tariff with defaults applied to its zero values.
+func (t Tariff) withDefaults() Tariff {
+	if t.Calendar == nil {
+		t.Calendar = calendar.Default()
+	}
+	// The cap is copied, so a tariff that has been applied is not changed through the caller's pointer.
+	dailyCap := defaultDailyCap
+	if t.DailyCap != nil {
+		dailyCap = *t.DailyCap
+	}
+	t.DailyCap = &dailyCap
+	if t.Window == 0 {
+		t.Window = defaultWindow
+	}
+	if t.WindowStrategy == "" {
+		t.WindowStrategy = defaultWindowStrategy
+	}
+	if t.Rounding == "" {
+		t.Rounding = defaultRounding
+	}
+
+	return t
+}
+
+func (t Tariff) validate() error {
+	if t.DailyCap != nil && *t.DailyCap < 0 {
+		return fmt.Errorf("daily cap %d must not be negative", *t.DailyCap)
+	}
+	if t.DedupWindow < 0 {
+		return fmt.Errorf("dedup window %v must not be negative", t.DedupWindow)
+	}
+	if _, err := multiplier.ParseRounding(string(t.Rounding)); err != nil {
+		return err
+	}
+	for _, m := range t.Multipliers {
+		if err := m.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return t.WindowStrategy.Validate(t.Window)
+}
@@ -0,0 +1,68 @@
+package gantry
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Direction is the direction of traffic a gantry registers.
+type Direction string
+
+const (
+	DirectionInbound  Direction = "inbound"
+	DirectionOutbound Direction = "outbound"
+	DirectionBoth     Direction = "both"
+)
+
+// Coordinates is a WGS 84 position.
+type Coordinates struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// Gantry is a toll station registering passages. It is active from ActiveFrom (inclusive) until ActiveTo
+// (exclusive); a zero ActiveTo means the gantry is active until further notice, and an ActiveTo equal to
+// ActiveFrom means it is never active.
+type Gantry struct {
+	ID          string      `json:"id"`
+	Name        string      `json:"name"`
+	Coordinates Coordinates `json:"coordinates"`
+	Direction   Direction   `json:"direction"`
+	Zone        string      `json:"zone"`
+	ActiveFrom  time.Time   `json:"activeFrom"`
+	ActiveTo    time.Time   `json:"activeTo,omitzero"`
+}
+
+// IsActive reports whether the gantry registers passages at t.
+func (g Gantry) IsActive(t time.Time) bool {
+	return !t.Before(g.ActiveFrom) && (g.ActiveTo.IsZero() || t.Before(g.ActiveTo))
+}
+
+// Validate reports the first field of the gantry that is missing or invalid.
+func (g Gantry) Validate() error {
+	switch {
+	case g.ID == "":
+		return errors.New("missing gantry id")
+	case g.Name == "":
+		return errors.New("missing gantry name")
+	case g.Zone == "":
+		return errors.New("missing gantry zone")
+	case g.Coordinates.Latitude < -90 || g.Coordinates.Latitude > 90:
+		return fmt.Errorf("latitude %v must be between -90 and 90", g.Coordinates.Latitude)
+	case g.Coordinates.Longitude < -180 || g.Coordinates.Longitude > 180:
+		return fmt.Errorf("longitude %v must be between -180 and 180", g.Coordinates.Longitude)
+	case g.ActiveFrom.IsZero():
+		return errors.New("missing gantry activeFrom")
+	case !g.ActiveTo.IsZero() && g.ActiveTo.Before(g.ActiveFrom):
+		return errors.New("activeTo must not be before activeFrom")
+	}
+
+	switch g.Direction {
+	case DirectionInbound, DirectionOutbound, DirectionBoth:
+		return nil
+	default:
+		return fmt.Errorf("direction %q must be one of %q, %q or %q",
+			g.Direction, DirectionInbound, DirectionOutbound, DirectionBoth)
+	}
+}
@@ -0,0 +1,72 @@
+package gantry
+
+import (
+	"testing"
+	"time"
+)
+
+func validGantry() Gantry {
+	return Gantry{
+		ID:          "STO-01",
+		Name:        "Essingeleden",
+		Coordinates: Coordinates{Latitude: 59.32, Longitude: 18.01},
+		Direction:   DirectionBoth,
+		Zone:        "stockholm",
+		ActiveFrom:  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestGantry_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(g *Gantry)
+		wantErr bool
+	}{
+		{name: "valid", modify: func(*Gantry) {}},
+		{name: "valid with end of active period", modify: func(g *Gantry) { g.ActiveTo = g.ActiveFrom.AddDate(1, 0, 0) }},
+		{name: "empty active period", modify: func(g *Gantry) { g.ActiveTo = g.ActiveFrom }},
+		{name: "missing id", modify: func(g *Gantry) { g.ID = "" }, wantErr: true},
+		{name: "missing name", modify: func(g *Gantry) { g.Name = "" }, wantErr: true},
+		{name: "missing zone", modify: func(g *Gantry) { g.Zone = "" }, wantErr: true},
+		{name: "latitude out of range", modify: func(g *Gantry) { g.Coordinates.Latitude = 91 }, wantErr: true},
+		{name: "longitude out of range", modify: func(g *Gantry) { g.Coordinates.Longitude = -181 }, wantErr: true},
+		{name: "missing activeFrom", modify: func(g *Gantry) { g.ActiveFrom = time.Time{} }, wantErr: true},
+		{name: "activeTo before activeFrom", modify: func(g *Gantry) { g.ActiveTo = g.ActiveFrom.Add(-time.Hour) }, wantErr: true},
+		{name: "unknown direction", modify: func(g *Gantry) { g.Direction = "sideways" }, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := validGantry()
+			tt.modify(&g)
+			if err := g.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGantry_IsActive(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		activeTo time.Time
+		at       time.Time
+		want     bool
+	}{
+		{name: "before activeFrom", at: from.Add(-time.Second), want: false},
+		{name: "at activeFrom", at: from, want: true},
+		{name: "open ended", at: from.AddDate(10, 0, 0), want: true},
+		{name: "before activeTo", activeTo: to, at: to.Add(-time.Second), want: true},
+		{name: "at activeTo", activeTo: to, at: to, want: false},
+		{name: "empty active period", activeTo: from, at: from, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := Gantry{ActiveFrom: from, ActiveTo: tt.activeTo}
+			if got := g.IsActive(tt.at); got != tt.want {
+				t.Errorf("IsActive() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package gantry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"afry-toll-calculator/internal/filestore"
+)
+
+var (
+	ErrInvalid       = errors.New("invalid gantry")
+	ErrNotFound      = errors.New("gantry not found")
+	ErrAlreadyExists = errors.New("gantry already exists")
+	ErrInactive      = errors.New("gantry is not active")
+)
+
+// Store keeps the registry of gantries.
+type Store interface {
+	List() ([]Gantry, error)
+	// Lookup returns the gantry with the given ID if it is active at t. Returns ErrNotFound for unknown
+	// gantries and ErrInactive for gantries that are not active at t.
+	Lookup(id string, at time.Time) (Gantry, error)
+	Create(g Gantry) error
+	Update(g Gantry) error
+	// Deactivate ends the active period of a gantry at the given time and returns the updated gantry. A
+	// gantry that is already inactive by then is returned unchanged.
+	Deactivate(id string, at time.Time) (Gantry, error)
+	// Zone returns the zone of a gantry that is active at the given time.
+	Zone(id string, at time.Time) (string, bool)
+}
+
+// Ensure conformance to the interface
+var _ Store = (*FileStore)(nil)
+
+// FileStore is a Store that keeps all gantries in memory and persists them to a JSON file on every change.
+// It is safe for concurrent use.
+type FileStore struct {
+	path string
+
+	mu       sync.RWMutex
+	gantries map[string]Gantry
+}
+
+// NewFileStore returns a FileStore persisted at path, loading the gantries stored there. The file is created
+// on the first change if it does not exist.
+func NewFileStore(path string) (*FileStore, error) {
+	s := &FileStore{path: path, gantries: map[string]Gantry{}}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var gantries []Gantry
+	if err := json.Unmarshal(data, &gantries); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+	for _, g := range gantries {
+		if err := g.Validate(); err != nil {
+			return nil, fmt.Errorf("%s: gantry %q: %w", path, g.ID, err)
+		}
+		if _, ok := s.gantries[g.ID]; ok {
+			return nil, fmt.Errorf("%s: gantry %q is defined twice", path, g.ID)
+		}
+		s.gantries[g.ID] = g
+	}
+
+	return s, nil
+}
+
+// List returns all gantries, including inactive ones, sorted by ID.
+func (s *FileStore) List() ([]Gantry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.sorted(), nil
+}
+
+func (s *FileStore) Lookup(id string, at time.Time) (Gantry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	g, ok := s.gantries[id]
+	if !ok {
+		return Gantry{}, fmt.Errorf("%w: %q", ErrNotFound, id)
+	}
+	if !g.IsActive(at) {
+		return Gantry{}, fmt.Errorf("%w: %q at %s", ErrInactive, id, at.Format(time.RFC3339))
+	}
+
+	return g, nil
+}
+
+func (s *FileStore) Zone(id string, at time.Time) (string, bool) {
+	g, err := s.Lookup(id, at)
+	if err != nil {
+		return "", false
+	}
+
+	return g.Zone, true
+}
+
+func (s *FileStore) Create(g Gantry) error {
+	if err := g.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.gantries[g.ID]; ok {
+		return fmt.Errorf("%w: %q", ErrAlreadyExists, g.ID)
+	}
+
+	return s.commit(g)
+}
+
+func (s *FileStore) Update(g Gantry) error {
+	if err := g.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.gantries[g.ID]; !ok {
+		return fmt.Errorf("%w: %q", ErrNotFound, g.ID)
+	}
+
+	return s.commit(g)
+}
+
+func (s *FileStore) Deactivate(id string, at time.Time) (Gantry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	g, ok := s.gantries[id]
+	if !ok {
+		return Gantry{}, fmt.Errorf("%w: %q", ErrNotFound, id)
+	}
+	if !g.ActiveTo.IsZero() && !g.ActiveTo.After(at) {
+		return g, nil
+	}
+
+	g.ActiveTo = at
+	if g.ActiveTo.Before(g.ActiveFrom) {
+		// The gantry never became active; an empty active period keeps it inactive at all times.
+		g.ActiveTo = g.ActiveFrom
+	}
+
+	return g, s.commit(g)
+}
+
+// commit persists the gantries with g added or replaced, and only updates the in-memory state once the
+// file has been written. Must be called with s.mu held.
+func (s *FileStore) commit(g Gantry) error {
+	previous, existed := s.gantries[g.ID]
+	s.gantries[g.ID] = g
+
+	if err := s.save(); err != nil {
+		if existed {
+			s.gantries[g.ID] = previous
+		} else {
+			delete(s.gantries, g.ID)
+		}
+		return fmt.Errorf("failed to save gantries: %w", err)
+	}
+
+	return nil
+}
+
+// save writes all gantries to a temporary file and renames it into place, so a crash never leaves a
+// partially written file behind.
+func (s *FileStore) save() error {
+	data, err := json.MarshalIndent(s.sorted(), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return filestore.WriteFile(s.path, data)
+}
+
+func (s *FileStore) sorted() []Gantry {
+	gantries := make([]Gantry, 0, len(s.gantries))
+	for _, g := range s.gantries {
+		gantries = append(gantries, g)
+	}
+	sort.Slice(gantries, func(i, j int) bool {
+		return gantries[i].ID < gantries[j].ID
+	})
+
+	return gantries
+}
@@ -0,0 +1,150 @@
+package gantry
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gantries.json")
+	store, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+
+	g := validGantry()
+	if err := store.Create(g); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := store.Create(g); !errors.Is(err, ErrAlreadyExists) {
+		t.Errorf("Create() of an existing gantry error = %v, want %v", err, ErrAlreadyExists)
+	}
+
+	invalid := validGantry()
+	invalid.ID = "STO-02"
+	invalid.Direction = ""
+	if err := store.Create(invalid); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Create() of an invalid gantry error = %v, want %v", err, ErrInvalid)
+	}
+
+	g.Name = "Essingeleden north"
+	if err := store.Update(g); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	missing := validGantry()
+	missing.ID = "STO-99"
+	if err := store.Update(missing); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Update() of an unknown gantry error = %v, want %v", err, ErrNotFound)
+	}
+
+	at := g.ActiveFrom.AddDate(0, 6, 0)
+	deactivated, err := store.Deactivate(g.ID, at)
+	if err != nil {
+		t.Fatalf("Deactivate() error = %v", err)
+	}
+	if !deactivated.ActiveTo.Equal(at) {
+		t.Errorf("Deactivate() ActiveTo = %v, want %v", deactivated.ActiveTo, at)
+	}
+	// Deactivating again later leaves the earlier end of the active period in place.
+	again, err := store.Deactivate(g.ID, at.AddDate(0, 1, 0))
+	if err != nil || !again.ActiveTo.Equal(at) {
+		t.Errorf("Deactivate() again got = %v, %v, want ActiveTo %v", again.ActiveTo, err, at)
+	}
+	if _, err := store.Deactivate("STO-99", at); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Deactivate() of an unknown gantry error = %v, want %v", err, ErrNotFound)
+	}
+
+	// A new store over the same file sees the data, as after a restart.
+	store, err = NewFileStore(path)
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+	got, err := store.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if want := []Gantry{deactivated}; !reflect.DeepEqual(got, want) {
+		t.Errorf("List() got = %+v, want %+v", got, want)
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("save() left %d files behind, want 1", len(entries))
+	}
+}
+
+func TestFileStore_Lookup(t *testing.T) {
+	store, err := NewFileStore(filepath.Join(t.TempDir(), "gantries.json"))
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+	g := validGantry()
+	g.ActiveTo = g.ActiveFrom.AddDate(1, 0, 0)
+	if err := store.Create(g); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		id       string
+		at       time.Time
+		wantZone string
+		wantErr  error
+	}{
+		{name: "active", id: g.ID, at: g.ActiveFrom, wantZone: "stockholm"},
+		{name: "before active period", id: g.ID, at: g.ActiveFrom.Add(-time.Hour), wantErr: ErrInactive},
+		{name: "after active period", id: g.ID, at: g.ActiveTo, wantErr: ErrInactive},
+		{name: "unknown gantry", id: "STO-99", at: g.ActiveFrom, wantErr: ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := store.Lookup(tt.id, tt.at)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Lookup() error = %v, want %v", err, tt.wantErr)
+			}
+			if got.Zone != tt.wantZone {
+				t.Errorf("Lookup() zone = %q, want %q", got.Zone, tt.wantZone)
+			}
+
+			zone, ok := store.Zone(tt.id, tt.at)
+			if zone != tt.wantZone || ok != (tt.wantErr == nil) {
+				t.Errorf("Zone() got = %q, %v", zone, ok)
+			}
+		})
+	}
+}
+
+func TestNewFileStore_invalidFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "corrupt", content: `[{"id":`},
+		{name: "invalid gantry", content: `[{"id":"STO-01"}]`},
+		{
+			name: "duplicate gantry",
+			content: `[
+				{"id":"STO-01","name":"a","direction":"both","zone":"stockholm","activeFrom":"2025-01-01T00:00:00Z"},
+				{"id":"STO-01","name":"b","direction":"both","zone":"stockholm","activeFrom":"2025-01-01T00:00:00Z"}
+			]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "gantries.json")
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := NewFileStore(path); err == nil {
+				t.Error("NewFileStore() expected an error")
+			}
+		})
+	}
+}
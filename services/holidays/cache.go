@@ -0,0 +1,263 @@
+package holidays
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"maps"
+	"sync"
+	"time"
+
+	"afry-toll-calculator/metrics"
+	"afry-toll-calculator/models"
+)
+
+// refreshRetryInterval is how long to wait before refreshing a stale year again after a refresh failed.
+const refreshRetryInterval = time.Minute
+
+// Cache keeps the public holidays of each year in memory. It is safe for concurrent use.
+//
+// Concurrent lookups of a year that is not cached share a single fetch from the Getter. Failed fetches are
+// not cached, so the next lookup tries again. Cached years become stale after the TTL; stale years keep
+// being served while they are refreshed in the background, and for as long as refreshing fails.
+//
+// A Cache with a Store loads years from the store before fetching them and persists every successful fetch,
+// so a restart does not have to fetch all years again.
+type Cache struct {
+	getter Getter
+	ttl    time.Duration
+	store  Store
+	source string
+	now    func() time.Time
+
+	mu       sync.Mutex
+	entries  map[int]entry
+	inFlight map[int]*fetchCall
+}
+
+type entry struct {
+	dates     map[string]struct{}
+	source    string
+	fetchedAt time.Time
+	failedAt  time.Time
+}
+
+type fetchCall struct {
+	done  chan struct{}
+	entry entry
+	err   error
+}
+
+// NewCache returns a Cache that fetches holidays from getter. A ttl of zero keeps cached years forever.
+func NewCache(getter Getter, ttl time.Duration) *Cache {
+	return &Cache{
+		getter:   getter,
+		ttl:      ttl,
+		now:      time.Now,
+		entries:  map[int]entry{},
+		inFlight: map[int]*fetchCall{},
+	}
+}
+
+// NewPersistentCache returns a Cache that fetches holidays from getter and persists them in store. Source
+// names the getter; stored years fetched from a different source are served, but refreshed right away.
+func NewPersistentCache(getter Getter, ttl time.Duration, store Store, source string) *Cache {
+	c := NewCache(getter, ttl)
+	c.store = store
+	c.source = source
+
+	return c
+}
+
+// IsHoliday reports whether the calendar date of t is a public holiday.
+func (c *Cache) IsHoliday(ctx context.Context, t time.Time) (bool, error) {
+	e, err := c.get(ctx, t.Year())
+	if err != nil {
+		return false, err
+	}
+
+	_, ok := e.dates[t.Format(models.PUBLIC_HOLIDAY_DATE_FORMAT)]
+
+	return ok, nil
+}
+
+// Prewarm loads the given years into the cache. All years are attempted; the returned error joins the
+// errors of the years that failed.
+func (c *Cache) Prewarm(ctx context.Context, years ...int) error {
+	var errs []error
+	for _, year := range years {
+		if _, err := c.get(ctx, year); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Rebuild returns a new Cache with the same configuration, which starts out with every year the receiver holds and
+// refreshes the given years in the background. Given years the receiver does not hold are loaded from the store or
+// fetched before Rebuild returns, and only those can fail it, so a rebuild keeps serving stale data while the getter
+// is unavailable. The receiver is not modified, so the new cache can be swapped in once it has been validated.
+func (c *Cache) Rebuild(ctx context.Context, years ...int) (*Cache, error) {
+	next := NewPersistentCache(c.getter, c.ttl, c.store, c.source)
+	next.now = c.now
+	started := c.now()
+
+	c.mu.Lock()
+	maps.Copy(next.entries, c.entries)
+	c.mu.Unlock()
+
+	var errs []error
+	for _, year := range years {
+		next.mu.Lock()
+		e, ok := next.entries[year]
+		var call *fetchCall
+		if !ok {
+			call = next.startLocked(year, true)
+		}
+		next.mu.Unlock()
+
+		if call != nil {
+			var err error
+			if e, err = wait(ctx, call); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+		}
+
+		// Years that were not fetched by this rebuild are refreshed, and served as they are until that succeeds.
+		if e.fetchedAt.Before(started) {
+			next.mu.Lock()
+			next.startLocked(year, false)
+			next.mu.Unlock()
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	return next, nil
+}
+
+func (c *Cache) get(ctx context.Context, year int) (entry, error) {
+	c.mu.Lock()
+	if cached, ok := c.entries[year]; ok {
+		c.refreshIfStaleLocked(year, cached)
+		c.mu.Unlock()
+		return cached, nil
+	}
+
+	call := c.startLocked(year, true)
+	c.mu.Unlock()
+
+	return wait(ctx, call)
+}
+
+func wait(ctx context.Context, call *fetchCall) (entry, error) {
+	select {
+	case <-ctx.Done():
+		return entry{}, ctx.Err()
+	case <-call.done:
+		return call.entry, call.err
+	}
+}
+
+// startLocked starts fetching a year unless a fetch is already in flight, and returns the call to wait on.
+// The store is only consulted when fromStore is set. Must be called with c.mu held.
+func (c *Cache) startLocked(year int, fromStore bool) *fetchCall {
+	if call, ok := c.inFlight[year]; ok {
+		return call
+	}
+
+	call := &fetchCall{done: make(chan struct{})}
+	c.inFlight[year] = call
+	go c.fetch(year, call, fromStore)
+
+	return call
+}
+
+// refreshIfStaleLocked starts a background refresh of a stale year, unless the last refresh failed less
+// than refreshRetryInterval ago. Must be called with c.mu held.
+func (c *Cache) refreshIfStaleLocked(year int, e entry) {
+	if !c.stale(e) || c.now().Sub(e.failedAt) < refreshRetryInterval {
+		return
+	}
+
+	c.startLocked(year, false)
+}
+
+// fetch retrieves a year and stores it on success. It is detached from the context of the lookup that
+// triggered it, since other lookups may be waiting for the same result.
+func (c *Cache) fetch(year int, call *fetchCall, fromStore bool) {
+	e, err := c.load(year, fromStore)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.inFlight, year)
+	if err != nil {
+		call.err = err
+		if stale, ok := c.entries[year]; ok {
+			slog.Warn("failed to refresh holidays, serving stale data",
+				"year", year,
+				"fetchedAt", stale.fetchedAt,
+				"error", err,
+			)
+			stale.failedAt = c.now()
+			c.entries[year] = stale
+		}
+	} else {
+		call.entry = e
+		c.entries[year] = e
+		metrics.RecordHolidayData(year, e.fetchedAt)
+		c.refreshIfStaleLocked(year, e)
+	}
+
+	close(call.done)
+}
+
+// load returns a year from the store if fromStore is set and the store has it, and fetches it from the
+// getter otherwise. Fetched years are persisted; store failures are logged, since the data is still usable.
+func (c *Cache) load(year int, fromStore bool) (entry, error) {
+	if fromStore && c.store != nil {
+		record, found, err := c.store.Load(year)
+		switch {
+		case err != nil:
+			slog.Warn("failed to load stored holidays", "year", year, "error", err)
+		case found:
+			return newEntry(record.Dates, record.Source, record.FetchedAt), nil
+		}
+	}
+
+	dates, err := c.getter.Get(context.Background(), year)
+	if err != nil {
+		return entry{}, err
+	}
+
+	e := newEntry(dates, c.source, c.now())
+	if c.store != nil {
+		record := models.HolidayRecord{Year: year, Source: c.source, FetchedAt: e.fetchedAt, Dates: dates}
+		if err := c.store.Save(record); err != nil {
+			slog.Warn("failed to store holidays", "year", year, "error", err)
+		}
+	}
+
+	return e, nil
+}
+
+func newEntry(dates []string, source string, fetchedAt time.Time) entry {
+	e := entry{dates: make(map[string]struct{}, len(dates)), source: source, fetchedAt: fetchedAt}
+	for _, date := range dates {
+		e.dates[date] = struct{}{}
+	}
+
+	return e
+}
+
+func (c *Cache) stale(e entry) bool {
+	if e.source != c.source {
+		return true
+	}
+
+	return c.ttl > 0 && c.now().Sub(e.fetchedAt) >= c.ttl
+}
@@ -0,0 +1,430 @@
+package holidays
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	mock_holidays "afry-toll-calculator/mocks/afry-toll-calculator/services/holidays"
+	"afry-toll-calculator/models"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCache_IsHoliday(t *testing.T) {
+	newYear := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name             string
+		mocks            func(getter *mock_holidays.MockGetter)
+		ttl              time.Duration
+		elapsed          time.Duration
+		want             bool
+		wantAfterRefresh bool
+		wantErr          bool
+		wantErrText      string
+	}{
+		{
+			name: "cached year is not fetched again",
+			mocks: func(getter *mock_holidays.MockGetter) {
+				getter.EXPECT().Get(mock.Anything, 2025).Return([]string{"2025-01-01"}, nil).Once()
+			},
+			want:             true,
+			wantAfterRefresh: true,
+		},
+		{
+			name: "failed fetch is not cached",
+			mocks: func(getter *mock_holidays.MockGetter) {
+				getter.EXPECT().Get(mock.Anything, 2025).Return(nil, errors.New("some error")).Once()
+				getter.EXPECT().Get(mock.Anything, 2025).Return([]string{"2025-01-01"}, nil).Once()
+			},
+			want:             true,
+			wantAfterRefresh: true,
+			wantErr:          true,
+			wantErrText:      "some error",
+		},
+		{
+			name: "stale year is served while it is refreshed",
+			mocks: func(getter *mock_holidays.MockGetter) {
+				getter.EXPECT().Get(mock.Anything, 2025).Return([]string{"2025-01-01"}, nil).Once()
+				getter.EXPECT().Get(mock.Anything, 2025).Return([]string{}, nil).Once()
+			},
+			ttl:              time.Hour,
+			elapsed:          time.Hour,
+			want:             true,
+			wantAfterRefresh: false,
+		},
+		{
+			name: "stale year is served when refreshing fails",
+			mocks: func(getter *mock_holidays.MockGetter) {
+				getter.EXPECT().Get(mock.Anything, 2025).Return([]string{"2025-01-01"}, nil).Once()
+				getter.EXPECT().Get(mock.Anything, 2025).Return(nil, errors.New("some error")).Once()
+			},
+			ttl:              time.Hour,
+			elapsed:          time.Hour,
+			want:             true,
+			wantAfterRefresh: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getter := mock_holidays.NewMockGetter(t)
+			tt.mocks(getter)
+
+			now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+			c := NewCache(getter, tt.ttl)
+			c.now = func() time.Time { return now }
+
+			// The first lookup populates the cache, the second one is the lookup under test.
+			_, err := c.IsHoliday(context.Background(), newYear)
+			if err != nil && !tt.wantErr {
+				t.Fatalf("IsHoliday() error = %v", err)
+			}
+			if tt.wantErr && (err == nil || err.Error() != tt.wantErrText) {
+				t.Fatalf("IsHoliday() error = %v, wantErrText %v", err, tt.wantErrText)
+			}
+
+			now = now.Add(tt.elapsed)
+			got, err := c.IsHoliday(context.Background(), newYear)
+			if err != nil {
+				t.Fatalf("IsHoliday() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("IsHoliday() got = %v, want %v", got, tt.want)
+			}
+
+			waitForRefresh(c, 2025)
+			got, err = c.IsHoliday(context.Background(), newYear)
+			if err != nil {
+				t.Fatalf("IsHoliday() error = %v", err)
+			}
+			if got != tt.wantAfterRefresh {
+				t.Errorf("IsHoliday() after refresh got = %v, want %v", got, tt.wantAfterRefresh)
+			}
+		})
+	}
+}
+
+func TestCache_IsHoliday_persistent(t *testing.T) {
+	newYear := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	stored := func(source string, age time.Duration) models.HolidayRecord {
+		return models.HolidayRecord{Year: 2025, Source: source, FetchedAt: now.Add(-age), Dates: []string{"2025-01-01"}}
+	}
+
+	tests := []struct {
+		name             string
+		mocks            func(getter *mock_holidays.MockGetter, store *mock_holidays.MockStore)
+		want             bool
+		wantAfterRefresh bool
+		wantErr          bool
+	}{
+		{
+			name: "stored year is served without fetching",
+			mocks: func(getter *mock_holidays.MockGetter, store *mock_holidays.MockStore) {
+				store.EXPECT().Load(2025).Return(stored("dagsmart", time.Minute), true, nil).Once()
+			},
+			want:             true,
+			wantAfterRefresh: true,
+		},
+		{
+			name: "missing year is fetched and stored",
+			mocks: func(getter *mock_holidays.MockGetter, store *mock_holidays.MockStore) {
+				store.EXPECT().Load(2025).Return(models.HolidayRecord{}, false, nil).Once()
+				getter.EXPECT().Get(mock.Anything, 2025).Return([]string{"2025-01-01"}, nil).Once()
+				store.EXPECT().Save(stored("dagsmart", 0)).Return(nil).Once()
+			},
+			want:             true,
+			wantAfterRefresh: true,
+		},
+		{
+			name: "failing store falls back to fetching",
+			mocks: func(getter *mock_holidays.MockGetter, store *mock_holidays.MockStore) {
+				store.EXPECT().Load(2025).Return(models.HolidayRecord{}, false, errors.New("some error")).Once()
+				getter.EXPECT().Get(mock.Anything, 2025).Return([]string{"2025-01-01"}, nil).Once()
+				store.EXPECT().Save(mock.Anything).Return(errors.New("some error")).Once()
+			},
+			want:             true,
+			wantAfterRefresh: true,
+		},
+		{
+			name: "stale stored year is served and refreshed",
+			mocks: func(getter *mock_holidays.MockGetter, store *mock_holidays.MockStore) {
+				store.EXPECT().Load(2025).Return(stored("dagsmart", 48*time.Hour), true, nil).Once()
+				getter.EXPECT().Get(mock.Anything, 2025).Return([]string{}, nil).Once()
+				store.EXPECT().Save(models.HolidayRecord{
+					Year: 2025, Source: "dagsmart", FetchedAt: now, Dates: []string{},
+				}).Return(nil).Once()
+			},
+			want:             true,
+			wantAfterRefresh: false,
+		},
+		{
+			name: "stale stored year is served when the source is unreachable",
+			mocks: func(getter *mock_holidays.MockGetter, store *mock_holidays.MockStore) {
+				store.EXPECT().Load(2025).Return(stored("dagsmart", 48*time.Hour), true, nil).Once()
+				getter.EXPECT().Get(mock.Anything, 2025).Return(nil, errors.New("some error")).Once()
+			},
+			want:             true,
+			wantAfterRefresh: true,
+		},
+		{
+			name: "stored year from another source is refreshed",
+			mocks: func(getter *mock_holidays.MockGetter, store *mock_holidays.MockStore) {
+				store.EXPECT().Load(2025).Return(stored("offline", 0), true, nil).Once()
+				getter.EXPECT().Get(mock.Anything, 2025).Return([]string{}, nil).Once()
+				store.EXPECT().Save(mock.Anything).Return(nil).Once()
+			},
+			want:             true,
+			wantAfterRefresh: false,
+		},
+		{
+			name: "missing year is not served when the source is unreachable",
+			mocks: func(getter *mock_holidays.MockGetter, store *mock_holidays.MockStore) {
+				store.EXPECT().Load(2025).Return(models.HolidayRecord{}, false, nil).Once()
+				getter.EXPECT().Get(mock.Anything, 2025).Return(nil, errors.New("some error")).Once()
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getter := mock_holidays.NewMockGetter(t)
+			store := mock_holidays.NewMockStore(t)
+			tt.mocks(getter, store)
+
+			c := NewPersistentCache(getter, 24*time.Hour, store, "dagsmart")
+			c.now = func() time.Time { return now }
+
+			got, err := c.IsHoliday(context.Background(), newYear)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("IsHoliday() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got != tt.want {
+				t.Errorf("IsHoliday() got = %v, want %v", got, tt.want)
+			}
+
+			waitForRefresh(c, 2025)
+			got, err = c.IsHoliday(context.Background(), newYear)
+			if err != nil {
+				t.Fatalf("IsHoliday() error = %v", err)
+			}
+			if got != tt.wantAfterRefresh {
+				t.Errorf("IsHoliday() after refresh got = %v, want %v", got, tt.wantAfterRefresh)
+			}
+		})
+	}
+}
+
+// waitForRefresh waits for a background refresh of the year to finish, if one is in flight.
+func waitForRefresh(c *Cache, year int) {
+	c.mu.Lock()
+	call := c.inFlight[year]
+	c.mu.Unlock()
+
+	if call != nil {
+		<-call.done
+	}
+}
+
+func TestCache_IsHoliday_concurrentLookupsShareOneFetch(t *testing.T) {
+	release := make(chan struct{})
+	getter := mock_holidays.NewMockGetter(t)
+	getter.EXPECT().
+		Get(mock.Anything, 2025).
+		RunAndReturn(func(context.Context, int) ([]string, error) {
+			<-release
+			return []string{"2025-01-01"}, nil
+		}).
+		Once()
+
+	c := NewCache(getter, 0)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 50)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			holiday, err := c.IsHoliday(context.Background(), time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+			if err == nil && !holiday {
+				err = errors.New("expected a holiday")
+			}
+			errs <- err
+		}()
+	}
+
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestCache_IsHoliday_contextCanceled(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	getter := mock_holidays.NewMockGetter(t)
+	getter.EXPECT().
+		Get(mock.Anything, 2025).
+		RunAndReturn(func(context.Context, int) ([]string, error) {
+			<-release
+			return []string{}, nil
+		}).
+		Maybe()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := NewCache(getter, 0).IsHoliday(ctx, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("IsHoliday() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestCache_Prewarm(t *testing.T) {
+	getter := mock_holidays.NewMockGetter(t)
+	getter.EXPECT().Get(mock.Anything, 2025).Return([]string{"2025-01-01"}, nil).Once()
+	getter.EXPECT().Get(mock.Anything, 2026).Return(nil, errors.New("some error")).Once()
+
+	c := NewCache(getter, 0)
+	err := c.Prewarm(context.Background(), 2025, 2026)
+	if err == nil || err.Error() != "some error" {
+		t.Fatalf("Prewarm() error = %v", err)
+	}
+
+	// 2025 was cached despite 2026 failing.
+	holiday, err := c.IsHoliday(context.Background(), time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil || !holiday {
+		t.Errorf("IsHoliday() got = %v, %v", holiday, err)
+	}
+	holiday, err = c.IsHoliday(context.Background(), time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC))
+	if err != nil || holiday {
+		t.Errorf("IsHoliday() on a workday got = %v, %v", holiday, err)
+	}
+}
+
+func TestCache_Rebuild(t *testing.T) {
+	newYear := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	started := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name             string
+		mocks            func(getter *mock_holidays.MockGetter, store *mock_holidays.MockStore)
+		prewarm          bool
+		wantAfterRefresh bool
+		wantErr          bool
+	}{
+		{
+			name: "cached year is served and refreshed in the background",
+			mocks: func(getter *mock_holidays.MockGetter, store *mock_holidays.MockStore) {
+				getter.EXPECT().Get(mock.Anything, 2025).Return([]string{}, nil).Once()
+				store.EXPECT().Save(mock.Anything).Return(nil).Once()
+			},
+			prewarm:          true,
+			wantAfterRefresh: false,
+		},
+		{
+			name: "cached year is served when the source is unreachable",
+			mocks: func(getter *mock_holidays.MockGetter, store *mock_holidays.MockStore) {
+				getter.EXPECT().Get(mock.Anything, 2025).Return(nil, errors.New("some error")).Once()
+			},
+			prewarm:          true,
+			wantAfterRefresh: true,
+		},
+		{
+			name: "stored year is served and refreshed in the background",
+			mocks: func(getter *mock_holidays.MockGetter, store *mock_holidays.MockStore) {
+				store.EXPECT().Load(2025).Return(models.HolidayRecord{
+					Year: 2025, Source: "dagsmart", FetchedAt: started.Add(-time.Hour), Dates: []string{"2025-01-01"},
+				}, true, nil).Once()
+				getter.EXPECT().Get(mock.Anything, 2025).Return(nil, errors.New("some error")).Once()
+			},
+			wantAfterRefresh: true,
+		},
+		{
+			name: "missing year is fetched",
+			mocks: func(getter *mock_holidays.MockGetter, store *mock_holidays.MockStore) {
+				store.EXPECT().Load(2025).Return(models.HolidayRecord{}, false, nil).Once()
+				getter.EXPECT().Get(mock.Anything, 2025).Return([]string{"2025-01-01"}, nil).Once()
+				store.EXPECT().Save(mock.Anything).Return(nil).Once()
+			},
+			wantAfterRefresh: true,
+		},
+		{
+			name: "missing year fails the rebuild when the source is unreachable",
+			mocks: func(getter *mock_holidays.MockGetter, store *mock_holidays.MockStore) {
+				store.EXPECT().Load(2025).Return(models.HolidayRecord{}, false, nil).Once()
+				getter.EXPECT().Get(mock.Anything, 2025).Return(nil, errors.New("some error")).Once()
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getter := mock_holidays.NewMockGetter(t)
+			store := mock_holidays.NewMockStore(t)
+
+			now := started.Add(-time.Hour)
+			c := NewPersistentCache(getter, 0, store, "dagsmart")
+			c.now = func() time.Time { return now }
+			if tt.prewarm {
+				store.EXPECT().Load(2025).Return(models.HolidayRecord{}, false, nil).Once()
+				getter.EXPECT().Get(mock.Anything, 2025).Return([]string{"2025-01-01"}, nil).Once()
+				store.EXPECT().Save(mock.Anything).Return(nil).Once()
+				if err := c.Prewarm(context.Background(), 2025); err != nil {
+					t.Fatal(err)
+				}
+			}
+			tt.mocks(getter, store)
+
+			now = started
+			next, err := c.Rebuild(context.Background(), 2025)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Rebuild() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			waitForRefresh(next, 2025)
+			if got, err := next.IsHoliday(context.Background(), newYear); err != nil || got != tt.wantAfterRefresh {
+				t.Errorf("IsHoliday() after refresh got = %v, %v, want %v", got, err, tt.wantAfterRefresh)
+			}
+			if tt.prewarm {
+				if got, _ := c.IsHoliday(context.Background(), newYear); !got {
+					t.Error("Rebuild() must not modify the original cache")
+				}
+			}
+		})
+	}
+}
+
+func TestCache_Rebuild_keepsOtherYears(t *testing.T) {
+	getter := mock_holidays.NewMockGetter(t)
+	getter.EXPECT().Get(mock.Anything, 2024).Return([]string{"2024-01-01"}, nil).Once()
+	getter.EXPECT().Get(mock.Anything, 2025).Return([]string{"2025-01-01"}, nil).Once()
+
+	c := NewCache(getter, 0)
+	if err := c.Prewarm(context.Background(), 2024); err != nil {
+		t.Fatal(err)
+	}
+
+	next, err := c.Rebuild(context.Background(), 2025)
+	if err != nil {
+		t.Fatalf("Rebuild() error = %v", err)
+	}
+
+	// 2024 is served from the data of the original cache, without fetching it again.
+	if got, err := next.IsHoliday(context.Background(), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)); err != nil || !got {
+		t.Errorf("IsHoliday() got = %v, %v", got, err)
+	}
+}
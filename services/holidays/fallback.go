@@ -0,0 +1,69 @@
+package holidays
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+)
+
+// Ensure conformance to the interface
+var _ Getter = (*fallbackGetter)(nil)
+
+type fallbackGetter struct {
+	primary  Getter
+	fallback Getter
+}
+
+// NewFallback returns a Getter that fetches holidays from primary and falls back to fallback when primary
+// fails. When both succeed, their results are cross-checked and any difference is logged, so a primary
+// source returning incomplete data is noticed.
+func NewFallback(primary, fallback Getter) Getter {
+	return &fallbackGetter{
+		primary:  primary,
+		fallback: fallback,
+	}
+}
+
+// Get returns the public holiday dates of the year from the primary source, or from the fallback source if
+// the primary source fails.
+func (g *fallbackGetter) Get(ctx context.Context, year int) ([]string, error) {
+	dates, err := g.primary.Get(ctx, year)
+	if err != nil {
+		slog.Warn("primary holiday source failed, using fallback", "year", year, "error", err)
+		return g.fallback.Get(ctx, year)
+	}
+
+	g.crossCheck(ctx, year, dates)
+
+	return dates, nil
+}
+
+func (g *fallbackGetter) crossCheck(ctx context.Context, year int, dates []string) {
+	reference, err := g.fallback.Get(ctx, year)
+	if err != nil {
+		slog.Warn("failed to cross-check holidays", "year", year, "error", err)
+		return
+	}
+
+	missing := difference(reference, dates)
+	unexpected := difference(dates, reference)
+	if len(missing) > 0 || len(unexpected) > 0 {
+		slog.Warn("holiday sources disagree",
+			"year", year,
+			"missing", missing,
+			"unexpected", unexpected,
+		)
+	}
+}
+
+// difference returns the dates in a that are not in b.
+func difference(a, b []string) []string {
+	out := []string{}
+	for _, v := range a {
+		if !slices.Contains(b, v) {
+			out = append(out, v)
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,82 @@
+package holidays
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	mock_holidays "afry-toll-calculator/mocks/afry-toll-calculator/services/holidays"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_fallbackGetter_Get(t *testing.T) {
+	tests := []struct {
+		name        string
+		mocks       func(primary, fallback *mock_holidays.MockGetter)
+		want        []string
+		wantErr     bool
+		wantErrText string
+	}{
+		{
+			name: "primary result is returned and cross-checked",
+			mocks: func(primary, fallback *mock_holidays.MockGetter) {
+				primary.EXPECT().Get(mock.Anything, 2025).Return([]string{"2025-01-01"}, nil)
+				fallback.EXPECT().Get(mock.Anything, 2025).Return([]string{"2025-01-01", "2025-01-06"}, nil)
+			},
+			want: []string{"2025-01-01"},
+		},
+		{
+			name: "primary result is returned when cross-check fails",
+			mocks: func(primary, fallback *mock_holidays.MockGetter) {
+				primary.EXPECT().Get(mock.Anything, 2025).Return([]string{"2025-01-01"}, nil)
+				fallback.EXPECT().Get(mock.Anything, 2025).Return(nil, errors.New("fallback error"))
+			},
+			want: []string{"2025-01-01"},
+		},
+		{
+			name: "fallback result is returned when primary fails",
+			mocks: func(primary, fallback *mock_holidays.MockGetter) {
+				primary.EXPECT().Get(mock.Anything, 2025).Return(nil, errors.New("primary error"))
+				fallback.EXPECT().Get(mock.Anything, 2025).Return([]string{"2025-01-06"}, nil)
+			},
+			want: []string{"2025-01-06"},
+		},
+		{
+			name: "fallback error is returned when both fail",
+			mocks: func(primary, fallback *mock_holidays.MockGetter) {
+				primary.EXPECT().Get(mock.Anything, 2025).Return(nil, errors.New("primary error"))
+				fallback.EXPECT().Get(mock.Anything, 2025).Return(nil, errors.New("fallback error"))
+			},
+			wantErr:     true,
+			wantErrText: "fallback error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			primary := mock_holidays.NewMockGetter(t)
+			fallback := mock_holidays.NewMockGetter(t)
+			tt.mocks(primary, fallback)
+
+			got, err := NewFallback(primary, fallback).Get(context.Background(), 2025)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Get() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && err.Error() != tt.wantErrText {
+				t.Errorf("Get() error = %v, wantErrText %v", err, tt.wantErrText)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Get() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_difference(t *testing.T) {
+	got := difference([]string{"a", "b", "c"}, []string{"b"})
+	if !reflect.DeepEqual(got, []string{"a", "c"}) {
+		t.Errorf("difference() = %v", got)
+	}
+}
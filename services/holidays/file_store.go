@@ -0,0 +1,72 @@
+package holidays
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"afry-toll-calculator/internal/filestore"
+	"afry-toll-calculator/models"
+)
+
+// Store persists holiday data so it survives restarts. Load reports false if no data is stored for the year.
+type Store interface {
+	Load(year int) (models.HolidayRecord, bool, error)
+	Save(record models.HolidayRecord) error
+}
+
+// Ensure conformance to the interface
+var _ Store = (*FileStore)(nil)
+
+// FileStore is a Store that keeps one JSON file per year in a directory.
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore returns a FileStore that keeps its files in dir, creating the directory if needed.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create holiday store directory: %w", err)
+	}
+
+	return &FileStore{dir: dir}, nil
+}
+
+// Load reads the stored holiday data of a year.
+func (s *FileStore) Load(year int) (models.HolidayRecord, bool, error) {
+	data, err := os.ReadFile(s.path(year))
+	if errors.Is(err, os.ErrNotExist) {
+		return models.HolidayRecord{}, false, nil
+	}
+	if err != nil {
+		return models.HolidayRecord{}, false, err
+	}
+
+	var record models.HolidayRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return models.HolidayRecord{}, false, fmt.Errorf("failed to decode %s: %w", s.path(year), err)
+	}
+	if record.Year != year {
+		return models.HolidayRecord{}, false, fmt.Errorf("%s holds holidays of %d", s.path(year), record.Year)
+	}
+
+	return record, true, nil
+}
+
+// Save writes the holiday data of a year, replacing any data stored before. The file is written to a temporary
+// file first and renamed into place, so a crash never leaves a partially written file behind.
+func (s *FileStore) Save(record models.HolidayRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	return filestore.WriteFile(s.path(record.Year), data)
+}
+
+func (s *FileStore) path(year int) string {
+	return filepath.Join(s.dir, strconv.Itoa(year)+".json")
+}
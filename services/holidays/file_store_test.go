@@ -0,0 +1,91 @@
+package holidays
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"afry-toll-calculator/models"
+)
+
+func TestFileStore(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "holidays")
+	store, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+
+	_, found, err := store.Load(2025)
+	if err != nil || found {
+		t.Fatalf("Load() of a missing year got = %v, %v", found, err)
+	}
+
+	want := models.HolidayRecord{
+		Year:      2025,
+		Source:    "dagsmart",
+		FetchedAt: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+		Dates:     []string{"2025-01-01", "2025-01-06"},
+	}
+	if err := store.Save(want); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	want.Dates = []string{"2025-01-01"}
+	if err := store.Save(want); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	// A new store over the same directory sees the data, as after a restart.
+	store, err = NewFileStore(dir)
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+	got, found, err := store.Load(2025)
+	if err != nil || !found {
+		t.Fatalf("Load() got = %v, %v", found, err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Load() got = %+v, want %+v", got, want)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("Save() left %d files behind, want 1", len(entries))
+	}
+}
+
+func TestFileStore_Load_invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{
+			name:    "corrupt file",
+			content: `{"year": 2025, "dates": [`,
+		},
+		{
+			name:    "file of another year",
+			content: `{"year": 2024, "source": "dagsmart", "dates": []}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "2025.json"), []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			store, err := NewFileStore(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, _, err := store.Load(2025); err == nil {
+				t.Error("Load() expected error")
+			}
+		})
+	}
+}
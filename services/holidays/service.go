@@ -0,0 +1,11 @@
+// Package holidays provides sources of public holidays for the fee calculation, as alternatives to and
+// safeguards for the dagsmart integration.
+package holidays
+
+import "context"
+
+// Getter returns the public holiday dates of a year in models.PUBLIC_HOLIDAY_DATE_FORMAT. It is satisfied by
+// dagsmart.Service, so holiday sources can be used interchangeably.
+type Getter interface {
+	Get(ctx context.Context, year int) ([]string, error)
+}
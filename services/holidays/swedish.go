@@ -0,0 +1,127 @@
+package holidays
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"afry-toll-calculator/models"
+)
+
+// Ensure conformance to the interface
+var _ Getter = (*swedishCalendar)(nil)
+
+// firstSupportedYear is the first year Midsummer has been celebrated on a Friday and Saturday, which is the
+// oldest rule the calendar implements.
+const firstSupportedYear = 1953
+
+// Holiday is a named public holiday.
+type Holiday struct {
+	Date time.Time
+	Code string
+	Name string
+}
+
+type swedishCalendar struct{}
+
+// NewSwedishCalendar returns a Getter that computes Swedish public holidays without any external dependency.
+// Besides the official public holidays it includes the eves of Easter, Pentecost, Midsummer, Christmas and
+// New Year, which are treated as holidays in practice.
+func NewSwedishCalendar() Getter {
+	return &swedishCalendar{}
+}
+
+// Get returns the sorted public holiday dates of the year. A date on which two holidays fall is listed once.
+func (c *swedishCalendar) Get(_ context.Context, year int) ([]string, error) {
+	holidays, err := SwedishHolidays(year)
+	if err != nil {
+		return nil, err
+	}
+
+	dates := make([]string, 0, len(holidays))
+	for _, h := range holidays {
+		date := h.Date.Format(models.PUBLIC_HOLIDAY_DATE_FORMAT)
+		if len(dates) > 0 && dates[len(dates)-1] == date {
+			continue
+		}
+		dates = append(dates, date)
+	}
+
+	return dates, nil
+}
+
+// SwedishHolidays returns the Swedish public holidays of the year, sorted by date. Movable holidays can fall on
+// the same date as another holiday, such as Ascension Day on May Day or Pentecost on National Day; both are
+// returned, in the order they are listed below.
+func SwedishHolidays(year int) ([]Holiday, error) {
+	if year < firstSupportedYear {
+		return nil, fmt.Errorf("holidays before %d are not supported", firstSupportedYear)
+	}
+
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	easter := easterSunday(year)
+
+	holidays := []Holiday{
+		{date(time.January, 1), "newYearsDay", "Nyårsdagen"},
+		{date(time.January, 6), "epiphany", "Trettondedag jul"},
+		{easter.AddDate(0, 0, -2), "goodFriday", "Långfredagen"},
+		{easter.AddDate(0, 0, -1), "easterEve", "Påskafton"},
+		{easter, "easterSunday", "Påskdagen"},
+		{easter.AddDate(0, 0, 1), "easterMonday", "Annandag påsk"},
+		{date(time.May, 1), "mayDay", "Första maj"},
+		{easter.AddDate(0, 0, 39), "ascensionDay", "Kristi himmelsfärdsdag"},
+		{easter.AddDate(0, 0, 48), "pentecostEve", "Pingstafton"},
+		{easter.AddDate(0, 0, 49), "pentecost", "Pingstdagen"},
+		{weekdayOnOrAfter(date(time.June, 19), time.Friday), "midsummerEve", "Midsommarafton"},
+		{weekdayOnOrAfter(date(time.June, 20), time.Saturday), "midsummerDay", "Midsommardagen"},
+		{weekdayOnOrAfter(date(time.October, 31), time.Saturday), "allSaintsDay", "Alla helgons dag"},
+		{date(time.December, 24), "christmasEve", "Julafton"},
+		{date(time.December, 25), "christmasDay", "Juldagen"},
+		{date(time.December, 26), "boxingDay", "Annandag jul"},
+		{date(time.December, 31), "newYearsEve", "Nyårsafton"},
+	}
+
+	// National Day replaced Whit Monday as a public holiday in 2005.
+	if year >= 2005 {
+		holidays = append(holidays, Holiday{date(time.June, 6), "nationalDay", "Sveriges nationaldag"})
+	} else {
+		holidays = append(holidays, Holiday{easter.AddDate(0, 0, 50), "whitMonday", "Annandag pingst"})
+	}
+
+	sort.SliceStable(holidays, func(i, j int) bool {
+		return holidays[i].Date.Before(holidays[j].Date)
+	})
+
+	return holidays, nil
+}
+
+// easterSunday returns the date of Easter Sunday in the Gregorian calendar, using the anonymous Gregorian
+// algorithm (Meeus/Jones/Butcher).
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+// weekdayOnOrAfter returns the first date on or after from that falls on the weekday.
+func weekdayOnOrAfter(from time.Time, weekday time.Weekday) time.Time {
+	offset := (int(weekday) - int(from.Weekday()) + 7) % 7
+
+	return from.AddDate(0, 0, offset)
+}
@@ -0,0 +1,120 @@
+package holidays
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_swedishCalendar_Get(t *testing.T) {
+	tests := []struct {
+		name        string
+		year        int
+		want        []string
+		wantErr     bool
+		wantErrText string
+	}{
+		{
+			name: "2025",
+			year: 2025,
+			want: []string{
+				"2025-01-01", "2025-01-06", "2025-04-18", "2025-04-19", "2025-04-20", "2025-04-21",
+				"2025-05-01", "2025-05-29", "2025-06-06", "2025-06-07", "2025-06-08", "2025-06-20",
+				"2025-06-21", "2025-11-01", "2025-12-24", "2025-12-25", "2025-12-26", "2025-12-31",
+			},
+		},
+		{
+			name: "2026, with National Day before Pentecost",
+			year: 2026,
+			want: []string{
+				"2026-01-01", "2026-01-06", "2026-04-03", "2026-04-04", "2026-04-05", "2026-04-06",
+				"2026-05-01", "2026-05-14", "2026-05-23", "2026-05-24", "2026-06-06", "2026-06-19",
+				"2026-06-20", "2026-10-31", "2026-12-24", "2026-12-25", "2026-12-26", "2026-12-31",
+			},
+		},
+		{
+			name: "2004, with Whit Monday instead of National Day",
+			year: 2004,
+			want: []string{
+				"2004-01-01", "2004-01-06", "2004-04-09", "2004-04-10", "2004-04-11", "2004-04-12",
+				"2004-05-01", "2004-05-20", "2004-05-29", "2004-05-30", "2004-05-31", "2004-06-25",
+				"2004-06-26", "2004-11-06", "2004-12-24", "2004-12-25", "2004-12-26", "2004-12-31",
+			},
+		},
+		{
+			name: "2008, with Ascension Day on May Day",
+			year: 2008,
+			want: []string{
+				"2008-01-01", "2008-01-06", "2008-03-21", "2008-03-22", "2008-03-23", "2008-03-24",
+				"2008-05-01", "2008-05-10", "2008-05-11", "2008-06-06", "2008-06-20", "2008-06-21",
+				"2008-11-01", "2008-12-24", "2008-12-25", "2008-12-26", "2008-12-31",
+			},
+		},
+		{
+			name: "2049, with Pentecost on National Day",
+			year: 2049,
+			want: []string{
+				"2049-01-01", "2049-01-06", "2049-04-16", "2049-04-17", "2049-04-18", "2049-04-19",
+				"2049-05-01", "2049-05-27", "2049-06-05", "2049-06-06", "2049-06-25", "2049-06-26",
+				"2049-11-06", "2049-12-24", "2049-12-25", "2049-12-26", "2049-12-31",
+			},
+		},
+		{
+			name:        "years before the modern rules are not supported",
+			year:        1952,
+			wantErr:     true,
+			wantErrText: "holidays before 1953 are not supported",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewSwedishCalendar().Get(context.Background(), tt.year)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Get() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && err.Error() != tt.wantErrText {
+				t.Errorf("Get() error = %v, wantErrText %v", err, tt.wantErrText)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Get() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSwedishHolidays_sameDate(t *testing.T) {
+	holidays, err := SwedishHolidays(2049)
+	if err != nil {
+		t.Fatalf("SwedishHolidays() error = %v", err)
+	}
+
+	var got []string
+	for _, h := range holidays {
+		if h.Date.Equal(time.Date(2049, time.June, 6, 0, 0, 0, 0, time.UTC)) {
+			got = append(got, h.Code)
+		}
+	}
+	if want := []string{"pentecost", "nationalDay"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SwedishHolidays() on 2049-06-06 = %v, want %v", got, want)
+	}
+}
+
+func Test_easterSunday(t *testing.T) {
+	tests := map[int]string{
+		1961: "1961-04-02",
+		2000: "2000-04-23",
+		2008: "2008-03-23",
+		2011: "2011-04-24",
+		2024: "2024-03-31",
+		2038: "2038-04-25",
+		2285: "2285-03-22",
+	}
+	for year, want := range tests {
+		if got := easterSunday(year).Format(time.DateOnly); got != want {
+			t.Errorf("easterSunday(%d) = %v, want %v", year, got, want)
+		}
+	}
+}
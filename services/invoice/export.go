@@ -0,0 +1,107 @@
+package invoice
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// WriteCSV writes the lines of the invoice as CSV with a header row, one row per vehicle and day, or per vehicle,
+// day and zone if zones are configured.
+func WriteCSV(w io.Writer, inv Invoice) error {
+	cw := csv.NewWriter(w)
+	records := [][]string{{
+		"invoice", "owner", "period", "date", "registration", "vehicle_type", "zone", "passages", "collapsed", "fee", "currency",
+	}}
+	for _, line := range inv.Lines {
+		records = append(records, []string{
+			strconv.Itoa(inv.Number),
+			inv.Owner,
+			inv.Period,
+			line.Date,
+			line.Registration,
+			string(line.VehicleType),
+			line.Zone,
+			strconv.Itoa(len(line.Passages)),
+			strconv.Itoa(line.Collapsed),
+			strconv.Itoa(line.Fee),
+			inv.Currency,
+		})
+	}
+
+	return cw.WriteAll(records)
+}
+
+// SIEAccounts are the accounts of the chart of accounts an invoice is booked on in SIE exports.
+type SIEAccounts struct {
+	Receivable string
+	Revenue    string
+}
+
+// WriteSIE writes the invoice as an SIE 4 import file with a single voucher, which debits the total to the
+// receivable account and credits the fee of every vehicle and day to the revenue account. The negative amounts of
+// a credit note reverse the booking. The voucher series and number are left empty, for the accounting system to
+// assign.
+func WriteSIE(w io.Writer, inv Invoice, accounts SIEAccounts) error {
+	var buf bytes.Buffer
+	line := func(format string, args ...any) {
+		fmt.Fprintf(&buf, format+"\r\n", args...)
+	}
+
+	issued := inv.IssuedAt.Format("20060102")
+	line("#FLAGGA 0")
+	line("#FORMAT PC8")
+	line("#SIETYP 4")
+	line("#PROGRAM %s 1", sieString("toll-calculator"))
+	line("#GEN %s", issued)
+	if inv.Currency != "" {
+		line("#VALUTA %s", inv.Currency)
+	}
+	text := fmt.Sprintf("%s %s %s", inv.Title(), inv.Owner, inv.Period)
+	if inv.isCorrection() {
+		text += fmt.Sprintf(" corrects invoice %d", inv.Corrects)
+	}
+	line("#VER \"\" \"\" %s %s", issued, sieString(text))
+	line("{")
+	line("#TRANS %s {} %s", accounts.Receivable, formatAmount(inv.Total))
+	for _, l := range inv.Lines {
+		if l.Fee == 0 {
+			continue
+		}
+		line("#TRANS %s {} %s %s %s", accounts.Revenue, formatAmount(-l.Fee),
+			strings.ReplaceAll(l.Date, "-", ""), sieString(l.Registration))
+	}
+	line("}")
+
+	_, err := w.Write(buf.Bytes())
+
+	return err
+}
+
+// cp437 maps the non-ASCII characters of Swedish text to code page 437, the character set of SIE files.
+var cp437 = map[rune]byte{
+	'å': 0x86, 'ä': 0x84, 'ö': 0x94, 'Å': 0x8f, 'Ä': 0x8e, 'Ö': 0x99, 'é': 0x82, 'É': 0x90, 'ü': 0x81, 'Ü': 0x9a,
+}
+
+// sieString quotes text for an SIE file, encoded in code page 437. Characters it cannot represent are replaced
+// with a question mark.
+func sieString(text string) string {
+	out := []byte{'"'}
+	for _, r := range text {
+		switch b, ok := cp437[r]; {
+		case r == '"' || r == '\\':
+			out = append(out, '\\', byte(r))
+		case r >= 0x20 && r < 0x7f:
+			out = append(out, byte(r))
+		case ok:
+			out = append(out, b)
+		default:
+			out = append(out, '?')
+		}
+	}
+
+	return string(append(out, '"'))
+}
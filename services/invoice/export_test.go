@@ -0,0 +1,116 @@
+package invoice
+
+import (
+	"bytes"
+	"encoding/csv"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"afry-toll-calculator/services/fee"
+)
+
+func testInvoice() Invoice {
+	cet := time.FixedZone("CET", 3600)
+
+	return Invoice{
+		Number:        7,
+		Owner:         "customer-1001",
+		Period:        "2025-03",
+		IssuedAt:      time.Date(2025, 4, 2, 9, 0, 0, 0, cet),
+		DueDate:       "2025-05-02",
+		TariffVersion: "c0fb3c4fe37f",
+		Currency:      "SEK",
+		Lines: []Line{
+			{
+				Date: "2025-03-04", Registration: "ABC123", VehicleType: "car", Fee: 36,
+				Passages: []fee.PassageExplanation{
+					{Timestamp: time.Date(2025, 3, 4, 7, 15, 0, 0, cet), Gantry: "STO-01", Classification: fee.ClassificationBillable, Price: 18},
+					{Timestamp: time.Date(2025, 3, 4, 7, 15, 5, 0, cet), Gantry: "STO-01", Classification: fee.ClassificationDuplicate},
+					{Timestamp: time.Date(2025, 3, 4, 16, 0, 0, 0, cet), Gantry: "STO-02", Classification: fee.ClassificationBillable, Price: 18},
+				},
+				Collapsed: 1,
+			},
+			{
+				Date: "2025-03-08", Registration: "ABC123", VehicleType: "car", Fee: 0,
+				Passages: []fee.PassageExplanation{
+					{Timestamp: time.Date(2025, 3, 8, 7, 15, 0, 0, cet), Gantry: "STO-01", Classification: fee.ClassificationWeekend},
+				},
+			},
+			{
+				Date: "2025-03-10", Registration: "TRK001", VehicleType: "truck", Fee: 120, CapApplied: true,
+				Passages: []fee.PassageExplanation{
+					{Timestamp: time.Date(2025, 3, 10, 7, 15, 0, 0, cet), Gantry: "STO-01", Classification: fee.ClassificationBillable, Price: 36},
+				},
+			},
+		},
+		Total: 156,
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, testInvoice()); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+
+	got, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("WriteCSV() wrote invalid CSV: %v", err)
+	}
+	want := [][]string{
+		{"invoice", "owner", "period", "date", "registration", "vehicle_type", "zone", "passages", "collapsed", "fee", "currency"},
+		{"7", "customer-1001", "2025-03", "2025-03-04", "ABC123", "car", "", "3", "1", "36", "SEK"},
+		{"7", "customer-1001", "2025-03", "2025-03-08", "ABC123", "car", "", "1", "0", "0", "SEK"},
+		{"7", "customer-1001", "2025-03", "2025-03-10", "TRK001", "truck", "", "1", "0", "120", "SEK"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("WriteCSV() got = %v, want %v", got, want)
+	}
+}
+
+func TestWriteSIE(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteSIE(&buf, testInvoice(), SIEAccounts{Receivable: "1510", Revenue: "3000"}); err != nil {
+		t.Fatalf("WriteSIE() error = %v", err)
+	}
+
+	want := strings.Join([]string{
+		`#FLAGGA 0`,
+		`#FORMAT PC8`,
+		`#SIETYP 4`,
+		`#PROGRAM "toll-calculator" 1`,
+		`#GEN 20250402`,
+		`#VALUTA SEK`,
+		`#VER "" "" 20250402 "Invoice 7 customer-1001 2025-03"`,
+		`{`,
+		`#TRANS 1510 {} 156.00`,
+		`#TRANS 3000 {} -36.00 20250304 "ABC123"`,
+		`#TRANS 3000 {} -120.00 20250310 "TRK001"`,
+		`}`,
+		``,
+	}, "\r\n")
+	if got := buf.String(); got != want {
+		t.Errorf("WriteSIE() got =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func Test_sieString(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{text: "ABC123", want: `"ABC123"`},
+		{text: `say "hi"`, want: `"say \"hi\""`},
+		{text: "Göteborg", want: "\"G\x94teborg\""},
+		{text: "Łódź", want: `"??d?"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := sieString(tt.text); got != tt.want {
+				t.Errorf("sieString() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package invoice
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"afry-toll-calculator/models"
+	"afry-toll-calculator/services/fee"
+)
+
+var (
+	ErrInvalid         = errors.New("invalid invoice request")
+	ErrNotFound        = errors.New("invoice not found")
+	ErrAlreadyInvoiced = errors.New("period is already invoiced")
+	ErrNoPassages      = errors.New("no passages to invoice")
+	// ErrTariffChanged is returned if the tariff was reloaded while an invoice was generated, so its days were
+	// priced with different tariff versions. Generating the invoice again prices all days with the new tariff.
+	ErrTariffChanged = errors.New("tariff changed while generating the invoice")
+)
+
+// Kinds of documents in the invoice sequence. Credit and debit notes correct the amounts of an earlier invoice.
+const (
+	KindInvoice    = "invoice"
+	KindCreditNote = "credit_note"
+	KindDebitNote  = "debit_note"
+)
+
+// Reason codes of credit and debit notes.
+const (
+	ReasonDisputeAccepted     = "dispute_accepted"
+	ReasonVehicleReclassified = "vehicle_reclassified"
+	ReasonExemptionGranted    = "exemption_granted"
+	ReasonTariffCorrected     = "tariff_corrected"
+	ReasonOther               = "other"
+)
+
+// periodFormat is the format of billing periods, which are calendar months in the billing time zone.
+const periodFormat = "2006-01"
+
+// Invoice bills the passages of all vehicles of an owner in a billing period. Number is assigned from a gap-free
+// sequence when the invoice is stored. Amounts are in whole units of Currency.
+//
+// Credit and debit notes share the type and the sequence of invoices. Their lines hold the difference to the
+// amounts billed so far, which is negative on credit notes, and Corrects is the number of the invoice they correct.
+type Invoice struct {
+	Number   int       `json:"number"`
+	Kind     string    `json:"kind"`
+	Corrects int       `json:"corrects,omitempty"`
+	Reason   string    `json:"reason,omitempty"`
+	Owner    string    `json:"owner"`
+	Period   string    `json:"period"`
+	IssuedAt time.Time `json:"issuedAt"`
+	DueDate  string    `json:"dueDate"`
+	// TariffVersion identifies the tariff document all lines were priced with, or the tariff document of every
+	// zone if zones are configured.
+	TariffVersion string `json:"tariffVersion,omitempty"`
+	Currency      string `json:"currency,omitempty"`
+	Lines         []Line `json:"lines"`
+	Total         int    `json:"total"`
+}
+
+// Line is the fee of a single vehicle on a single billing day, with the daily maximum already applied. If zones are
+// configured, every zone the vehicle passed gets a line of its own, priced with the zone's tariff. Passages lists
+// every stored passage of the day with its classification and price.
+type Line struct {
+	Date         string                   `json:"date"`
+	Registration string                   `json:"registration"`
+	VehicleType  models.VehicleType       `json:"vehicleType"`
+	Zone         string                   `json:"zone,omitempty"`
+	Passages     []fee.PassageExplanation `json:"passages"`
+	Collapsed    int                      `json:"collapsed,omitempty"`
+	Fee          int                      `json:"fee"`
+	CapApplied   bool                     `json:"capApplied,omitempty"`
+}
+
+// Title returns the kind and number of the document, such as "Credit note 12".
+func (inv Invoice) Title() string {
+	switch inv.Kind {
+	case KindCreditNote:
+		return fmt.Sprintf("Credit note %d", inv.Number)
+	case KindDebitNote:
+		return fmt.Sprintf("Debit note %d", inv.Number)
+	default:
+		return fmt.Sprintf("Invoice %d", inv.Number)
+	}
+}
+
+func (inv Invoice) isCorrection() bool {
+	return inv.Kind == KindCreditNote || inv.Kind == KindDebitNote
+}
+
+func validReason(reason string) error {
+	switch reason {
+	case ReasonDisputeAccepted, ReasonVehicleReclassified, ReasonExemptionGranted, ReasonTariffCorrected, ReasonOther:
+		return nil
+	default:
+		return fmt.Errorf("%w: reason %q must be one of %q, %q, %q, %q or %q", ErrInvalid, reason,
+			ReasonDisputeAccepted, ReasonVehicleReclassified, ReasonExemptionGranted, ReasonTariffCorrected, ReasonOther)
+	}
+}
+
+// parsePeriod returns the start of a billing period such as 2025-03 and the start of the following period.
+func parsePeriod(period string, location *time.Location) (time.Time, time.Time, error) {
+	start, err := time.ParseInLocation(periodFormat, period, location)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: period %q must be a month such as 2025-03", ErrInvalid, period)
+	}
+
+	return start, start.AddDate(0, 1, 0), nil
+}
@@ -0,0 +1,246 @@
+package invoice
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Page geometry of rendered invoices in points, on A4 paper.
+const (
+	pdfPageWidth  = 595
+	pdfPageHeight = 842
+	pdfMargin     = 50
+	pdfLineHeight = 13
+	pdfFontSize   = 9
+	pdfTitleSize  = 14
+)
+
+// pdfColDetail is the left edge of the invoice details next to their labels.
+const pdfColDetail = 130
+
+// Left edges of the columns of the passage table. Amounts are right-aligned at the right margin.
+const (
+	pdfColDate           = pdfMargin
+	pdfColRegistration   = 115
+	pdfColVehicleType    = 190
+	pdfColTime           = 260
+	pdfColGantry         = 315
+	pdfColClassification = 385
+	pdfColAmount         = pdfPageWidth - pdfMargin
+)
+
+// pdfText is a piece of text placed on a page. Right-aligned text ends at x instead of starting there.
+type pdfText struct {
+	x, y  float64
+	size  int
+	bold  bool
+	right bool
+	text  string
+}
+
+// WritePDF renders the invoice as a paginated PDF document, with a table of the passages of every day under the
+// daily fee of the vehicle. Every page repeats the invoice details and the table header. The document uses the
+// standard Helvetica fonts that every PDF reader provides, so no fonts are embedded.
+func WritePDF(w io.Writer, inv Invoice) error {
+	_, err := w.Write(encodePDF(layoutPDF(inv)))
+
+	return err
+}
+
+// pdfLayout places the rows of an invoice on pages, starting a new page when the current one is full.
+type pdfLayout struct {
+	inv   Invoice
+	pages [][]pdfText
+	y     float64
+}
+
+func layoutPDF(inv Invoice) [][]pdfText {
+	l := &pdfLayout{inv: inv}
+	l.newPage()
+
+	for _, line := range inv.Lines {
+		fee := "Daily fee"
+		if line.Zone != "" {
+			fee += " " + line.Zone
+		}
+		if line.CapApplied {
+			fee += " (cap applied)"
+		}
+		// Keep a day together with its first passage.
+		l.ensureSpace(2)
+		l.row(true, line.Date, line.Registration, string(line.VehicleType), "", "", fee, formatAmount(line.Fee))
+
+		for _, p := range line.Passages {
+			price := ""
+			if p.Price > 0 {
+				price = formatAmount(p.Price)
+			}
+			l.row(false, "", "", "", p.Timestamp.Format("15:04:05"), p.Gantry, string(p.Classification), price)
+		}
+		l.y -= pdfLineHeight / 2
+	}
+
+	l.ensureSpace(2)
+	l.y -= pdfLineHeight / 2
+	l.row(true, "Total", "", "", "", "", inv.Currency, formatAmount(inv.Total))
+
+	for i := range l.pages {
+		l.pages[i] = append(l.pages[i], pdfText{
+			x: pdfColAmount, y: pdfMargin / 2, size: pdfFontSize, right: true,
+			text: fmt.Sprintf("Page %d of %d", i+1, len(l.pages)),
+		})
+	}
+
+	return l.pages
+}
+
+// newPage starts a page with the invoice details and the table header.
+func (l *pdfLayout) newPage() {
+	l.pages = append(l.pages, nil)
+	l.y = pdfPageHeight - pdfMargin
+
+	l.add(pdfText{x: pdfMargin, y: l.y, size: pdfTitleSize, bold: true, text: l.inv.Title()})
+	l.y -= pdfLineHeight * 2
+
+	details := [][2]string{
+		{"Owner", l.inv.Owner},
+		{"Period", l.inv.Period},
+		{"Issued", l.inv.IssuedAt.Format("2006-01-02")},
+		{"Due date", l.inv.DueDate},
+		{"Tariff version", l.inv.TariffVersion},
+	}
+	if l.inv.isCorrection() {
+		details = append(details, [2]string{"Corrects", "Invoice " + strconv.Itoa(l.inv.Corrects)}, [2]string{"Reason", l.inv.Reason})
+	}
+	for _, d := range details {
+		l.add(pdfText{x: pdfMargin, y: l.y, size: pdfFontSize, bold: true, text: d[0]})
+		l.add(pdfText{x: pdfColDetail, y: l.y, size: pdfFontSize, text: d[1]})
+		l.y -= pdfLineHeight
+	}
+	l.y -= pdfLineHeight
+
+	l.row(true, "Date", "Registration", "Vehicle type", "Time", "Gantry", "Classification", "Amount")
+	l.y -= pdfLineHeight / 2
+}
+
+// ensureSpace starts a new page unless n more rows fit on the current one.
+func (l *pdfLayout) ensureSpace(n int) {
+	if l.y-float64(n-1)*pdfLineHeight < pdfMargin {
+		l.newPage()
+	}
+}
+
+// row adds a row of the passage table. The last cell is the amount.
+func (l *pdfLayout) row(bold bool, date, registration, vehicleType, time, gantry, classification, amount string) {
+	l.ensureSpace(1)
+
+	columns := []float64{pdfColDate, pdfColRegistration, pdfColVehicleType, pdfColTime, pdfColGantry, pdfColClassification}
+	for i, text := range []string{date, registration, vehicleType, time, gantry, classification} {
+		if text != "" {
+			l.add(pdfText{x: columns[i], y: l.y, size: pdfFontSize, bold: bold, text: text})
+		}
+	}
+	if amount != "" {
+		l.add(pdfText{x: pdfColAmount, y: l.y, size: pdfFontSize, bold: bold, right: true, text: amount})
+	}
+	l.y -= pdfLineHeight
+}
+
+func (l *pdfLayout) add(t pdfText) {
+	l.pages[len(l.pages)-1] = append(l.pages[len(l.pages)-1], t)
+}
+
+// encodePDF writes the pages as a PDF 1.4 document: the catalog, the page tree and the two fonts, followed by a
+// page object and a content stream for every page, and the cross-reference table.
+func encodePDF(pages [][]pdfText) []byte {
+	var buf bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	const firstPage = 5
+	kids := make([]byte, 0, len(pages)*8)
+	for i := range pages {
+		kids = fmt.Appendf(kids, "%d 0 R ", firstPage+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", bytes.TrimSpace(kids), len(pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, page := range pages {
+		var content bytes.Buffer
+		for _, t := range page {
+			font := "F1"
+			if t.bold {
+				font = "F2"
+			}
+			x := t.x
+			if t.right {
+				x -= textWidth(t.text, t.size)
+			}
+			fmt.Fprintf(&content, "BT /%s %d Tf %.2f %.2f Td (%s) Tj ET\n", font, t.size, x, t.y, pdfString(t.text))
+		}
+
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] "+
+			"/Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, firstPage+2*i+1))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.Bytes()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return buf.Bytes()
+}
+
+// pdfString encodes text for a literal string in a WinAnsiEncoding font. Characters outside Latin-1 are replaced
+// with a question mark.
+func pdfString(text string) []byte {
+	out := make([]byte, 0, len(text))
+	for _, r := range text {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			out = append(out, '\\', byte(r))
+		case r >= 0x20 && r < 0x7f, r >= 0xa0 && r <= 0xff:
+			out = append(out, byte(r))
+		default:
+			out = append(out, '?')
+		}
+	}
+
+	return out
+}
+
+// textWidth approximates the width of text in Helvetica, which is exact for amounts: digits, separators and
+// signs have the same width in the regular and the bold font.
+func textWidth(text string, size int) float64 {
+	units := 0
+	for _, r := range text {
+		switch r {
+		case '.', ',', ' ':
+			units += 278
+		case '-':
+			units += 333
+		default:
+			units += 556
+		}
+	}
+
+	return float64(units*size) / 1000
+}
+
+// formatAmount formats an amount in whole units with two decimals.
+func formatAmount(amount int) string {
+	return strconv.Itoa(amount) + ".00"
+}
@@ -0,0 +1,82 @@
+package invoice
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func TestWritePDF(t *testing.T) {
+	long := testInvoice()
+	for i := 0; i < 40; i++ {
+		long.Lines = append(long.Lines, long.Lines[0])
+	}
+
+	tests := []struct {
+		name      string
+		inv       Invoice
+		wantPages int
+	}{
+		{name: "single page", inv: testInvoice(), wantPages: 1},
+		{name: "paginated", inv: long, wantPages: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WritePDF(&buf, tt.inv); err != nil {
+				t.Fatalf("WritePDF() error = %v", err)
+			}
+			doc := buf.Bytes()
+
+			if !bytes.HasPrefix(doc, []byte("%PDF-1.4\n")) || !bytes.HasSuffix(doc, []byte("%%EOF\n")) {
+				t.Fatal("WritePDF() did not write a complete PDF document")
+			}
+			if got := len(regexp.MustCompile(`/Type /Page /Parent`).FindAll(doc, -1)); got != tt.wantPages {
+				t.Errorf("WritePDF() wrote %d pages, want %d", got, tt.wantPages)
+			}
+			if !bytes.Contains(doc, []byte("(Page 1 of "+strconv.Itoa(tt.wantPages)+") Tj")) {
+				t.Error("WritePDF() did not number the pages")
+			}
+
+			// Every object must start at the offset the cross-reference table records for it.
+			m := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(doc)
+			if m == nil {
+				t.Fatal("WritePDF() wrote no startxref")
+			}
+			xref, _ := strconv.Atoi(string(m[1]))
+			if !bytes.HasPrefix(doc[xref:], []byte("xref\n")) {
+				t.Fatalf("startxref %d does not point at the cross-reference table", xref)
+			}
+			entries := regexp.MustCompile(`(\d{10}) 00000 n \n`).FindAllSubmatch(doc[xref:], -1)
+			for i, entry := range entries {
+				offset, _ := strconv.Atoi(string(entry[1]))
+				if want := strconv.Itoa(i+1) + " 0 obj\n"; !bytes.HasPrefix(doc[offset:], []byte(want)) {
+					t.Errorf("cross-reference entry %d points at %q, want %q", i+1, doc[offset:offset+10], want)
+				}
+			}
+		})
+	}
+}
+
+func TestWritePDF_amountsMatchInvoice(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WritePDF(&buf, testInvoice()); err != nil {
+		t.Fatalf("WritePDF() error = %v", err)
+	}
+
+	for _, text := range []string{"(Invoice 7)", "(36.00)", "(120.00)", "(156.00)", "(Daily fee \\(cap applied\\))", "(duplicate)"} {
+		if !bytes.Contains(buf.Bytes(), []byte(text)) {
+			t.Errorf("WritePDF() does not show %s", text)
+		}
+	}
+}
+
+func Test_pdfString(t *testing.T) {
+	if got, want := string(pdfString(`Göteborg (C:\)`)), "G\xf6teborg \\(C:\\\\\\)"; got != want {
+		t.Errorf("pdfString() = %q, want %q", got, want)
+	}
+	if got := string(pdfString("Łódź")); got != "?\xf3d?" {
+		t.Errorf("pdfString() = %q, want %q", got, "?\xf3d?")
+	}
+}
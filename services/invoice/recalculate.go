@@ -0,0 +1,226 @@
+package invoice
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"time"
+
+	"afry-toll-calculator/models"
+	"afry-toll-calculator/services/fee"
+	"afry-toll-calculator/services/vehicleregistry"
+)
+
+// RecalculationRequest selects the billing days of a vehicle to recalculate. From and To are inclusive dates in the
+// billing time zone. Reason is the reason code of the notes, which is only required if notes are issued.
+type RecalculationRequest struct {
+	Registration string
+	From         string
+	To           string
+	Reason       string
+	// DryRun only reports the differences without issuing notes.
+	DryRun bool
+}
+
+// Recalculation compares the fees of a vehicle recalculated with the current passages, registrations, exemptions
+// and tariff to the amounts billed so far.
+type Recalculation struct {
+	Registration string          `json:"registration"`
+	From         string          `json:"from"`
+	To           string          `json:"to"`
+	Reason       string          `json:"reason,omitempty"`
+	Days         []DayDifference `json:"days"`
+	Difference   int             `json:"difference"`
+	// Notes are the credit and debit notes issued for the differences, which is none for a dry run.
+	Notes []Invoice `json:"notes"`
+}
+
+// DayDifference is the difference between the billed and the recalculated fee of a vehicle on a billing day.
+// Invoice is the invoice the day belongs to, or 0 if the owner has not been invoiced for the period yet. Days that
+// are not invoiced yet get no note, as the invoice of the period will bill the recalculated fee.
+type DayDifference struct {
+	Date         string `json:"date"`
+	Invoice      int    `json:"invoice"`
+	Billed       int    `json:"billed"`
+	Recalculated int    `json:"recalculated"`
+	Difference   int    `json:"difference"`
+}
+
+// dayRecalculation collects the billed and the recalculated amounts of a billing day.
+type dayRecalculation struct {
+	DayDifference
+	owner string
+	line  Line
+}
+
+func (s *invoiceService) Recalculate(req RecalculationRequest) (Recalculation, error) {
+	number := vehicleregistry.Normalize(req.Registration)
+	if number == "" {
+		return Recalculation{}, fmt.Errorf("%w: missing registration", ErrInvalid)
+	}
+	from, errFrom := time.ParseInLocation(models.PUBLIC_HOLIDAY_DATE_FORMAT, req.From, s.location)
+	to, errTo := time.ParseInLocation(models.PUBLIC_HOLIDAY_DATE_FORMAT, req.To, s.location)
+	if errFrom != nil || errTo != nil {
+		return Recalculation{}, fmt.Errorf("%w: from and to must be dates such as 2025-03-01", ErrInvalid)
+	}
+	if to.Before(from) {
+		return Recalculation{}, fmt.Errorf("%w: to %s is before from %s", ErrInvalid, req.To, req.From)
+	}
+	if !req.DryRun {
+		if err := validReason(req.Reason); err != nil {
+			return Recalculation{}, err
+		}
+	}
+
+	// Recalculations of the same days must not both issue notes for the same difference.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	collected, err := s.collect(from, to.AddDate(0, 0, 1), func(r vehicleregistry.Registration) bool {
+		return r.Number == number
+	})
+	if err != nil {
+		return Recalculation{}, err
+	}
+	lines, tariffVersion, currency, err := s.price(collected)
+	if err != nil {
+		return Recalculation{}, err
+	}
+
+	days := map[string]*dayRecalculation{}
+	day := func(date string) *dayRecalculation {
+		d, ok := days[date]
+		if !ok {
+			d = &dayRecalculation{DayDifference: DayDifference{Date: date}}
+			days[date] = d
+		}
+		return d
+	}
+
+	for _, inv := range s.store.List() {
+		original := inv.Number
+		if inv.isCorrection() {
+			original = inv.Corrects
+		}
+		for _, line := range inv.Lines {
+			if line.Registration != number || line.Date < req.From || line.Date > req.To {
+				continue
+			}
+			d := day(line.Date)
+			d.Invoice = original
+			d.Billed += line.Fee
+			d.line.VehicleType = line.VehicleType
+		}
+	}
+
+	for _, c := range collected {
+		day(c.date).owner = c.registration.OwnerRef
+	}
+	for _, line := range lines {
+		d := day(line.Date)
+		d.Recalculated += line.Fee
+		// A vehicle registered to two owners or passing several zones on the same day keeps all passages of the
+		// day on a single line.
+		d.line.VehicleType = line.VehicleType
+		d.line.Passages = append(d.line.Passages, line.Passages...)
+		d.line.Collapsed += line.Collapsed
+		d.line.CapApplied = d.line.CapApplied || line.CapApplied
+	}
+
+	result := Recalculation{
+		Registration: number,
+		From:         req.From,
+		To:           req.To,
+		Days:         []DayDifference{},
+		Notes:        []Invoice{},
+	}
+	if !req.DryRun {
+		result.Reason = req.Reason
+	}
+
+	notes := map[int]map[string]*Invoice{}
+	for _, d := range sortedDays(days) {
+		if d.Invoice == 0 {
+			if inv, ok := s.store.Find(d.owner, d.Date[:len(periodFormat)]); ok {
+				d.Invoice = inv.Number
+			}
+		}
+		d.Difference = d.Recalculated - d.Billed
+		result.Days = append(result.Days, d.DayDifference)
+		if d.Invoice == 0 || d.Difference == 0 {
+			continue
+		}
+		result.Difference += d.Difference
+
+		kind := KindDebitNote
+		if d.Difference < 0 {
+			kind = KindCreditNote
+		}
+		if notes[d.Invoice] == nil {
+			notes[d.Invoice] = map[string]*Invoice{}
+		}
+		note, ok := notes[d.Invoice][kind]
+		if !ok {
+			note = &Invoice{Kind: kind, Corrects: d.Invoice, Reason: req.Reason}
+			notes[d.Invoice][kind] = note
+		}
+
+		line := d.line
+		line.Date = d.Date
+		line.Registration = number
+		line.Fee = d.Difference
+		if line.Passages == nil {
+			line.Passages = []fee.PassageExplanation{}
+		}
+		note.Lines = append(note.Lines, line)
+		note.Total += line.Fee
+	}
+
+	if req.DryRun {
+		return result, nil
+	}
+
+	// Notes are issued one at a time. If one fails, the notes issued before it are part of the billed amounts of
+	// the next recalculation, which only issues the remaining difference.
+	now := s.now()
+	for _, invoiceNumber := range slices.Sorted(maps.Keys(notes)) {
+		original, err := s.store.Get(invoiceNumber)
+		if err != nil {
+			return Recalculation{}, err
+		}
+		for _, kind := range []string{KindCreditNote, KindDebitNote} {
+			note, ok := notes[invoiceNumber][kind]
+			if !ok {
+				continue
+			}
+			note.Owner = original.Owner
+			note.Period = original.Period
+			note.IssuedAt = now
+			note.DueDate = s.dueDate(now)
+			note.TariffVersion = tariffVersion
+			note.Currency = currency
+			if note.Currency == "" {
+				note.Currency = original.Currency
+			}
+
+			issued, err := s.store.Create(*note)
+			if err != nil {
+				return Recalculation{}, err
+			}
+			result.Notes = append(result.Notes, issued)
+		}
+	}
+
+	return result, nil
+}
+
+func sortedDays(days map[string]*dayRecalculation) []*dayRecalculation {
+	sorted := make([]*dayRecalculation, 0, len(days))
+	for _, d := range days {
+		sorted = append(sorted, d)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Date < sorted[j].Date })
+
+	return sorted
+}
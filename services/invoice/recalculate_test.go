@@ -0,0 +1,152 @@
+package invoice
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	mock_fee "afry-toll-calculator/mocks/afry-toll-calculator/services/fee"
+	mock_passagelog "afry-toll-calculator/mocks/afry-toll-calculator/services/passagelog"
+	mock_vehicleregistry "afry-toll-calculator/mocks/afry-toll-calculator/services/vehicleregistry"
+	"afry-toll-calculator/models"
+	"afry-toll-calculator/services/fee"
+	"afry-toll-calculator/services/passagelog"
+	"afry-toll-calculator/services/vehicleregistry"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_invoiceService_Recalculate(t *testing.T) {
+	events := []passagelog.Event{
+		{Registration: "ABC123", Gantry: "STO-01", Timestamp: time.Date(2025, 3, 3, 7, 0, 0, 0, time.UTC)},
+		{Registration: "ABC123", Gantry: "STO-01", Timestamp: time.Date(2025, 3, 4, 7, 0, 0, 0, time.UTC)},
+		// Not billed on the invoice of the period, such as after an exemption ended early.
+		{Registration: "ABC123", Gantry: "STO-01", Timestamp: time.Date(2025, 3, 5, 7, 0, 0, 0, time.UTC)},
+		{Registration: "TRK001", Gantry: "STO-01", Timestamp: time.Date(2025, 3, 3, 7, 0, 0, 0, time.UTC)},
+		// After the requested days, and in a period that has not been invoiced.
+		{Registration: "ABC123", Gantry: "STO-01", Timestamp: time.Date(2025, 4, 1, 7, 0, 0, 0, time.UTC)},
+	}
+	log := mock_passagelog.NewMockLog(t)
+	log.EXPECT().Scan(mock.Anything).RunAndReturn(func(fn func(passagelog.Event) error) error {
+		for _, e := range events {
+			if err := fn(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	registry := mock_vehicleregistry.NewMockStore(t)
+	registry.EXPECT().Lookup(mock.Anything, mock.Anything).RunAndReturn(
+		func(number string, at time.Time) (vehicleregistry.Registration, error) {
+			return vehicleregistry.Registration{Number: number, VehicleType: "car", OwnerRef: "owner-1"}, nil
+		})
+
+	// The fees of 3 and 5 March changed since the invoice was issued; the fee of 4 March did not.
+	fees := map[int]int{3: 18, 4: 20, 5: 8}
+	feeService := mock_fee.NewMockService(t)
+	feeService.EXPECT().ExplainPassages(mock.Anything, mock.Anything).RunAndReturn(
+		func(vehicle models.Vehicle, passages []models.Passage) (fee.Explanation, error) {
+			return fee.Explanation{Fee: fees[passages[0].Timestamp.Day()], TariffVersion: "v2", Currency: "SEK"}, nil
+		})
+
+	store := newTestStore(t, filepath.Join(t.TempDir(), "invoices.jsonl"))
+	original, err := store.Create(Invoice{
+		Kind:          KindInvoice,
+		Owner:         "owner-1",
+		Period:        "2025-03",
+		TariffVersion: "v1",
+		Currency:      "SEK",
+		Lines: []Line{
+			{Date: "2025-03-03", Registration: "ABC123", VehicleType: "car", Fee: 30},
+			{Date: "2025-03-03", Registration: "TRK001", VehicleType: "truck", Fee: 26},
+			{Date: "2025-03-04", Registration: "ABC123", VehicleType: "car", Fee: 20},
+		},
+		Total: 76,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := New(log, registry, nil, feeService, nil, store, time.UTC, 30).(*invoiceService)
+	issuedAt := time.Date(2025, 5, 10, 9, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return issuedAt }
+
+	req := RecalculationRequest{Registration: "abc-123", From: "2025-03-01", To: "2025-03-31", DryRun: true}
+	wantDays := []DayDifference{
+		{Date: "2025-03-03", Invoice: original.Number, Billed: 30, Recalculated: 18, Difference: -12},
+		{Date: "2025-03-04", Invoice: original.Number, Billed: 20, Recalculated: 20},
+		{Date: "2025-03-05", Invoice: original.Number, Recalculated: 8, Difference: 8},
+	}
+
+	got, err := s.Recalculate(req)
+	if err != nil {
+		t.Fatalf("Recalculate() dry run error = %v", err)
+	}
+	if !reflect.DeepEqual(got.Days, wantDays) || got.Difference != -4 || len(got.Notes) != 0 {
+		t.Errorf("Recalculate() dry run got = %+v, want days %+v, difference -4 and no notes", got, wantDays)
+	}
+	if len(store.List()) != 1 {
+		t.Errorf("Recalculate() dry run stored %d documents, want 1", len(store.List()))
+	}
+
+	req.DryRun = false
+	req.Reason = ReasonExemptionGranted
+	got, err = s.Recalculate(req)
+	if err != nil {
+		t.Fatalf("Recalculate() error = %v", err)
+	}
+	wantNotes := []Invoice{
+		{
+			Number: 2, Kind: KindCreditNote, Corrects: 1, Reason: ReasonExemptionGranted, Owner: "owner-1",
+			Period: "2025-03", IssuedAt: issuedAt, DueDate: "2025-06-09", TariffVersion: "v2", Currency: "SEK",
+			Lines: []Line{{
+				Date: "2025-03-03", Registration: "ABC123", VehicleType: "car", Passages: []fee.PassageExplanation{}, Fee: -12,
+			}},
+			Total: -12,
+		},
+		{
+			Number: 3, Kind: KindDebitNote, Corrects: 1, Reason: ReasonExemptionGranted, Owner: "owner-1",
+			Period: "2025-03", IssuedAt: issuedAt, DueDate: "2025-06-09", TariffVersion: "v2", Currency: "SEK",
+			Lines: []Line{{
+				Date: "2025-03-05", Registration: "ABC123", VehicleType: "car", Passages: []fee.PassageExplanation{}, Fee: 8,
+			}},
+			Total: 8,
+		},
+	}
+	if !reflect.DeepEqual(got.Notes, wantNotes) {
+		t.Errorf("Recalculate() notes = %+v, want %+v", got.Notes, wantNotes)
+	}
+
+	// The notes are part of the billed amounts, so recalculating again finds no differences.
+	got, err = s.Recalculate(req)
+	if err != nil {
+		t.Fatalf("Recalculate() again error = %v", err)
+	}
+	if got.Difference != 0 || len(got.Notes) != 0 {
+		t.Errorf("Recalculate() again got difference %d and %d notes, want none", got.Difference, len(got.Notes))
+	}
+}
+
+func Test_invoiceService_Recalculate_invalid(t *testing.T) {
+	s := New(nil, nil, nil, nil, nil, newTestStore(t, filepath.Join(t.TempDir(), "invoices.jsonl")), time.UTC, 30).(*invoiceService)
+
+	tests := []struct {
+		name string
+		req  RecalculationRequest
+	}{
+		{name: "missing registration", req: RecalculationRequest{From: "2025-03-01", To: "2025-03-31", DryRun: true}},
+		{name: "malformed date", req: RecalculationRequest{Registration: "ABC123", From: "March", To: "2025-03-31", DryRun: true}},
+		{name: "to before from", req: RecalculationRequest{Registration: "ABC123", From: "2025-03-31", To: "2025-03-01", DryRun: true}},
+		{name: "missing reason", req: RecalculationRequest{Registration: "ABC123", From: "2025-03-01", To: "2025-03-31"}},
+		{name: "unknown reason", req: RecalculationRequest{Registration: "ABC123", From: "2025-03-01", To: "2025-03-31", Reason: "typo"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.Recalculate(tt.req); !errors.Is(err, ErrInvalid) {
+				t.Errorf("Recalculate() error = %v, want %v", err, ErrInvalid)
+			}
+		})
+	}
+}
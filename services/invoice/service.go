@@ -0,0 +1,264 @@
+package invoice
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"afry-toll-calculator/models"
+	"afry-toll-calculator/services/exemption"
+	"afry-toll-calculator/services/fee"
+	"afry-toll-calculator/services/passagelog"
+	"afry-toll-calculator/services/vehicleregistry"
+	"afry-toll-calculator/services/zone"
+)
+
+type Service interface {
+	// Generate prices the stored passages of all vehicles of owner in a billing period, such as 2025-03, and
+	// issues an invoice for them. The period must have ended.
+	Generate(owner, period string) (Invoice, error)
+	Get(number int) (Invoice, error)
+	// Recalculate prices the passages of a vehicle on the requested days again and compares the fees to the
+	// amounts billed by invoices and earlier notes. Unless it is a dry run, it issues a credit note for the days
+	// that were billed too much and a debit note for the days that were billed too little, per invoice.
+	Recalculate(req RecalculationRequest) (Recalculation, error)
+}
+
+// New initializes and returns a new Service implementation, which prices the passages in passages with
+// feeService and stores the invoices in store. If zones is not nil, the passages are priced with the tariff of the
+// zone of their gantry instead, so every zone applies its own daily cap and window. Passages are attributed to the owner of the registration that
+// was valid at the time of the passage. If exemptions is not nil, the exemptions of the individual vehicles are
+// applied. Invoices are due paymentDays days after they are issued.
+func New(
+	passages passagelog.Log,
+	registry vehicleregistry.Store,
+	exemptions exemption.Store,
+	feeService fee.Service,
+	zones zone.Service,
+	store Store,
+	location *time.Location,
+	paymentDays int,
+) Service {
+	return &invoiceService{
+		passages:    passages,
+		registry:    registry,
+		exemptions:  exemptions,
+		feeService:  feeService,
+		zones:       zones,
+		store:       store,
+		location:    location,
+		paymentDays: paymentDays,
+		now:         time.Now,
+	}
+}
+
+type invoiceService struct {
+	passages    passagelog.Log
+	registry    vehicleregistry.Store
+	exemptions  exemption.Store
+	feeService  fee.Service
+	zones       zone.Service
+	store       Store
+	location    *time.Location
+	paymentDays int
+	now         func() time.Time
+	mu          sync.Mutex
+}
+
+// vehicleDay holds the passages of a single registration on a single billing day.
+type vehicleDay struct {
+	date         string
+	registration vehicleregistry.Registration
+	passages     []models.Passage
+}
+
+func (s *invoiceService) Generate(owner, period string) (Invoice, error) {
+	if owner == "" {
+		return Invoice{}, fmt.Errorf("%w: missing owner", ErrInvalid)
+	}
+	start, end, err := parsePeriod(period, s.location)
+	if err != nil {
+		return Invoice{}, err
+	}
+	now := s.now()
+	if end.After(now) {
+		return Invoice{}, fmt.Errorf("%w: period %s has not ended", ErrInvalid, period)
+	}
+	if _, ok := s.store.Find(owner, period); ok {
+		return Invoice{}, fmt.Errorf("%w: owner %q, period %s", ErrAlreadyInvoiced, owner, period)
+	}
+
+	days, err := s.collect(start, end, func(r vehicleregistry.Registration) bool {
+		return r.OwnerRef == owner
+	})
+	if err != nil {
+		return Invoice{}, err
+	}
+	if len(days) == 0 {
+		return Invoice{}, fmt.Errorf("%w: owner %q, period %s", ErrNoPassages, owner, period)
+	}
+
+	lines, tariffVersion, currency, err := s.price(days)
+	if err != nil {
+		return Invoice{}, err
+	}
+
+	inv := Invoice{
+		Kind:          KindInvoice,
+		Owner:         owner,
+		Period:        period,
+		IssuedAt:      now,
+		DueDate:       s.dueDate(now),
+		TariffVersion: tariffVersion,
+		Currency:      currency,
+		Lines:         lines,
+	}
+	for _, line := range lines {
+		inv.Total += line.Fee
+	}
+
+	return s.store.Create(inv)
+}
+
+func (s *invoiceService) dueDate(issuedAt time.Time) string {
+	return issuedAt.In(s.location).AddDate(0, 0, s.paymentDays).Format(models.PUBLIC_HOLIDAY_DATE_FORMAT)
+}
+
+// price calculates the fee of every vehicle day with the registration's vehicle type and exemptions, with one line
+// per zone if zones are configured, and returns the version and currency of the tariff the fees were calculated
+// with. With zones, the version lists the tariff version of every zone, such as "gothenburg:2025-1, stockholm:2025-2".
+func (s *invoiceService) price(days []vehicleDay) ([]Line, string, string, error) {
+	var currency string
+	versions := map[string]string{}
+	lines := make([]Line, 0, len(days))
+	for _, day := range days {
+		var vehicle models.Vehicle = day.registration
+		if s.exemptions != nil {
+			vehicle = exemption.WithExemptions(day.registration, s.exemptions.List(day.registration.Number))
+		}
+
+		explanations, err := s.explain(vehicle, day.passages)
+		if err != nil {
+			return nil, "", "", fmt.Errorf("registration %q on %s: %w", day.registration.Number, day.date, err)
+		}
+		for _, explanation := range explanations {
+			version, ok := versions[explanation.Zone]
+			if !ok {
+				versions[explanation.Zone] = explanation.TariffVersion
+			} else if explanation.TariffVersion != version {
+				return nil, "", "", ErrTariffChanged
+			}
+			if currency == "" {
+				currency = explanation.Currency
+			}
+
+			lines = append(lines, Line{
+				Date:         day.date,
+				Registration: day.registration.Number,
+				VehicleType:  day.registration.VehicleType,
+				Zone:         explanation.Zone,
+				Passages:     explanation.Passages,
+				Collapsed:    explanation.Collapsed,
+				Fee:          explanation.Fee,
+				CapApplied:   explanation.CapApplied,
+			})
+		}
+	}
+
+	return lines, tariffVersion(versions), currency, nil
+}
+
+// explain explains the fee of the passages of a vehicle day, per zone if zones are configured.
+func (s *invoiceService) explain(vehicle models.Vehicle, passages []models.Passage) ([]zone.Explanation, error) {
+	if s.zones != nil {
+		return s.zones.ExplainPassages(vehicle, passages)
+	}
+
+	explanation, err := s.feeService.ExplainPassages(vehicle, passages)
+	if err != nil {
+		return nil, err
+	}
+
+	return []zone.Explanation{{Explanation: explanation}}, nil
+}
+
+// tariffVersion returns the version of the tariff without zones, or the versions of the zone tariffs sorted by
+// zone.
+func tariffVersion(versions map[string]string) string {
+	if version, ok := versions[""]; ok {
+		return version
+	}
+
+	parts := make([]string, 0, len(versions))
+	for _, z := range slices.Sorted(maps.Keys(versions)) {
+		if versions[z] != "" {
+			parts = append(parts, z+":"+versions[z])
+		}
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// collect scans the passage log for the passages between start and end of vehicles whose registration at the time
+// matches, and groups them by registration and billing day. Passages of numbers without a valid registration are
+// not attributed to anyone. Days are sorted by date and then by registration number.
+func (s *invoiceService) collect(
+	start, end time.Time,
+	match func(vehicleregistry.Registration) bool,
+) ([]vehicleDay, error) {
+	type dayKey struct {
+		date         string
+		registration vehicleregistry.Registration
+	}
+	index := map[dayKey]int{}
+	days := []vehicleDay{}
+
+	err := s.passages.Scan(func(e passagelog.Event) error {
+		if e.Timestamp.Before(start) || !e.Timestamp.Before(end) {
+			return nil
+		}
+
+		registration, err := s.registry.Lookup(e.Registration, e.Timestamp)
+		if errors.Is(err, vehicleregistry.ErrUnknownRegistration) || errors.Is(err, vehicleregistry.ErrNotValid) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !match(registration) {
+			return nil
+		}
+
+		key := dayKey{e.Timestamp.In(s.location).Format(models.PUBLIC_HOLIDAY_DATE_FORMAT), registration}
+		i, ok := index[key]
+		if !ok {
+			i = len(days)
+			index[key] = i
+			days = append(days, vehicleDay{date: key.date, registration: registration})
+		}
+		days[i].passages = append(days[i].passages, models.Passage{Gantry: e.Gantry, Timestamp: e.Timestamp})
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read passages: %w", err)
+	}
+
+	sort.SliceStable(days, func(i, j int) bool {
+		if days[i].date != days[j].date {
+			return days[i].date < days[j].date
+		}
+		return days[i].registration.Number < days[j].registration.Number
+	})
+
+	return days, nil
+}
+
+func (s *invoiceService) Get(number int) (Invoice, error) {
+	return s.store.Get(number)
+}
@@ -0,0 +1,237 @@
+package invoice
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	mock_exemption "afry-toll-calculator/mocks/afry-toll-calculator/services/exemption"
+	mock_fee "afry-toll-calculator/mocks/afry-toll-calculator/services/fee"
+	mock_passagelog "afry-toll-calculator/mocks/afry-toll-calculator/services/passagelog"
+	mock_vehicleregistry "afry-toll-calculator/mocks/afry-toll-calculator/services/vehicleregistry"
+	"afry-toll-calculator/models"
+	"afry-toll-calculator/services/exemption"
+	"afry-toll-calculator/services/fee"
+	"afry-toll-calculator/services/passagelog"
+	"afry-toll-calculator/services/vehicleregistry"
+	"afry-toll-calculator/services/zone"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_invoiceService_Generate(t *testing.T) {
+	stockholm, err := time.LoadLocation("Europe/Stockholm")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	car := vehicleregistry.Registration{Number: "ABC123", VehicleType: "car", OwnerRef: "owner-1"}
+	truck := vehicleregistry.Registration{Number: "TRK001", VehicleType: "truck", OwnerRef: "owner-1"}
+	other := vehicleregistry.Registration{Number: "XYZ789", VehicleType: "car", OwnerRef: "owner-2"}
+	registrations := map[string]vehicleregistry.Registration{car.Number: car, truck.Number: truck, other.Number: other}
+
+	events := []passagelog.Event{
+		// Before the period in the billing time zone.
+		{Registration: "ABC123", Gantry: "STO-01", Timestamp: time.Date(2025, 2, 28, 22, 30, 0, 0, time.UTC)},
+		{Registration: "ABC123", Gantry: "STO-01", Timestamp: time.Date(2025, 3, 4, 7, 0, 0, 0, time.UTC)},
+		{Registration: "TRK001", Gantry: "STO-02", Timestamp: time.Date(2025, 3, 3, 8, 0, 0, 0, time.UTC)},
+		{Registration: "XYZ789", Gantry: "STO-01", Timestamp: time.Date(2025, 3, 4, 7, 5, 0, 0, time.UTC)},
+		{Registration: "NOREG1", Gantry: "STO-01", Timestamp: time.Date(2025, 3, 4, 7, 5, 0, 0, time.UTC)},
+		{Registration: "ABC123", Gantry: "STO-02", Timestamp: time.Date(2025, 3, 4, 16, 0, 0, 0, time.UTC)},
+		// After the period in the billing time zone.
+		{Registration: "ABC123", Gantry: "STO-01", Timestamp: time.Date(2025, 3, 31, 22, 30, 0, 0, time.UTC)},
+	}
+
+	log := mock_passagelog.NewMockLog(t)
+	log.EXPECT().Scan(mock.Anything).RunAndReturn(func(fn func(passagelog.Event) error) error {
+		for _, e := range events {
+			if err := fn(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	registry := mock_vehicleregistry.NewMockStore(t)
+	registry.EXPECT().Lookup(mock.Anything, mock.Anything).RunAndReturn(
+		func(number string, at time.Time) (vehicleregistry.Registration, error) {
+			r, ok := registrations[number]
+			if !ok {
+				return vehicleregistry.Registration{}, vehicleregistry.ErrUnknownRegistration
+			}
+			return r, nil
+		})
+
+	exemptions := mock_exemption.NewMockStore(t)
+	exemptions.EXPECT().List(mock.Anything).Return([]exemption.Exemption{})
+
+	feeService := mock_fee.NewMockService(t)
+	feeService.EXPECT().ExplainPassages(mock.Anything, []models.Passage{
+		{Gantry: "STO-02", Timestamp: time.Date(2025, 3, 3, 8, 0, 0, 0, time.UTC)},
+	}).Return(fee.Explanation{Fee: 26, TariffVersion: "v1", Currency: "SEK"}, nil).Once()
+	feeService.EXPECT().ExplainPassages(mock.Anything, []models.Passage{
+		{Gantry: "STO-01", Timestamp: time.Date(2025, 3, 4, 7, 0, 0, 0, time.UTC)},
+		{Gantry: "STO-02", Timestamp: time.Date(2025, 3, 4, 16, 0, 0, 0, time.UTC)},
+	}).Return(fee.Explanation{Fee: 36, TariffVersion: "v1", Currency: "SEK"}, nil).Once()
+
+	store := newTestStore(t, filepath.Join(t.TempDir(), "invoices.jsonl"))
+	s := New(log, registry, exemptions, feeService, nil, store, stockholm, 30).(*invoiceService)
+	issuedAt := time.Date(2025, 4, 2, 9, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return issuedAt }
+
+	got, err := s.Generate("owner-1", "2025-03")
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	want := Invoice{
+		Number:        1,
+		Kind:          "invoice",
+		Owner:         "owner-1",
+		Period:        "2025-03",
+		IssuedAt:      issuedAt,
+		DueDate:       "2025-05-02",
+		TariffVersion: "v1",
+		Currency:      "SEK",
+		Lines: []Line{
+			{Date: "2025-03-03", Registration: "TRK001", VehicleType: "truck", Fee: 26},
+			{Date: "2025-03-04", Registration: "ABC123", VehicleType: "car", Fee: 36},
+		},
+		Total: 62,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Generate() got = %+v, want %+v", got, want)
+	}
+	if stored, err := s.Get(1); err != nil || !reflect.DeepEqual(stored, got) {
+		t.Errorf("Get() got = %+v, %v, want %+v", stored, err, got)
+	}
+
+	if _, err := s.Generate("owner-1", "2025-03"); !errors.Is(err, ErrAlreadyInvoiced) {
+		t.Errorf("Generate() again error = %v, want %v", err, ErrAlreadyInvoiced)
+	}
+	if _, err := s.Generate("owner-3", "2025-03"); !errors.Is(err, ErrNoPassages) {
+		t.Errorf("Generate() for an owner without passages error = %v, want %v", err, ErrNoPassages)
+	}
+}
+
+func Test_invoiceService_Generate_invalid(t *testing.T) {
+	s := New(nil, nil, nil, nil, nil, newTestStore(t, filepath.Join(t.TempDir(), "invoices.jsonl")), time.UTC, 30).(*invoiceService)
+	s.now = func() time.Time { return time.Date(2025, 3, 15, 9, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name   string
+		owner  string
+		period string
+	}{
+		{name: "missing owner", period: "2025-02"},
+		{name: "malformed period", owner: "owner-1", period: "March"},
+		{name: "period has not ended", owner: "owner-1", period: "2025-03"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.Generate(tt.owner, tt.period); !errors.Is(err, ErrInvalid) {
+				t.Errorf("Generate() error = %v, want %v", err, ErrInvalid)
+			}
+		})
+	}
+}
+
+func Test_invoiceService_Generate_tariffChanged(t *testing.T) {
+	log := mock_passagelog.NewMockLog(t)
+	log.EXPECT().Scan(mock.Anything).RunAndReturn(func(fn func(passagelog.Event) error) error {
+		for _, day := range []int{3, 4} {
+			e := passagelog.Event{Registration: "ABC123", Gantry: "STO-01", Timestamp: time.Date(2025, 3, day, 7, 0, 0, 0, time.UTC)}
+			if err := fn(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	registry := mock_vehicleregistry.NewMockStore(t)
+	registry.EXPECT().Lookup("ABC123", mock.Anything).
+		Return(vehicleregistry.Registration{Number: "ABC123", VehicleType: "car", OwnerRef: "owner-1"}, nil)
+
+	feeService := mock_fee.NewMockService(t)
+	feeService.EXPECT().ExplainPassages(mock.Anything, mock.Anything).Return(fee.Explanation{TariffVersion: "v1"}, nil).Once()
+	feeService.EXPECT().ExplainPassages(mock.Anything, mock.Anything).Return(fee.Explanation{TariffVersion: "v2"}, nil).Once()
+
+	store := newTestStore(t, filepath.Join(t.TempDir(), "invoices.jsonl"))
+	s := New(log, registry, nil, feeService, nil, store, time.UTC, 30).(*invoiceService)
+	s.now = func() time.Time { return time.Date(2025, 4, 2, 9, 0, 0, 0, time.UTC) }
+
+	if _, err := s.Generate("owner-1", "2025-03"); !errors.Is(err, ErrTariffChanged) {
+		t.Errorf("Generate() error = %v, want %v", err, ErrTariffChanged)
+	}
+	if _, ok := store.Find("owner-1", "2025-03"); ok {
+		t.Error("Generate() stored an invoice priced with different tariff versions")
+	}
+}
+
+func Test_invoiceService_Generate_zones(t *testing.T) {
+	morning := time.Date(2025, 3, 4, 7, 0, 0, 0, time.UTC)
+	noon := time.Date(2025, 3, 4, 12, 0, 0, 0, time.UTC)
+	evening := time.Date(2025, 3, 4, 16, 0, 0, 0, time.UTC)
+
+	log := mock_passagelog.NewMockLog(t)
+	log.EXPECT().Scan(mock.Anything).RunAndReturn(func(fn func(passagelog.Event) error) error {
+		for _, e := range []passagelog.Event{
+			{Registration: "ABC123", Gantry: "STO-01", Timestamp: morning},
+			{Registration: "ABC123", Gantry: "GBG-01", Timestamp: noon},
+			{Registration: "ABC123", Gantry: "STO-02", Timestamp: evening},
+		} {
+			if err := fn(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	registry := mock_vehicleregistry.NewMockStore(t)
+	registry.EXPECT().Lookup("ABC123", mock.Anything).
+		Return(vehicleregistry.Registration{Number: "ABC123", VehicleType: "car", OwnerRef: "owner-1"}, nil)
+
+	// The same time of day is priced differently in the two zones, and each zone applies its own daily cap.
+	stockholm := mock_fee.NewMockService(t)
+	stockholm.EXPECT().ExplainPassages(mock.Anything, []models.Passage{
+		{Gantry: "STO-01", Timestamp: morning},
+		{Gantry: "STO-02", Timestamp: evening},
+	}).Return(fee.Explanation{Fee: 45, CapApplied: true, TariffVersion: "sto-1", Currency: "SEK"}, nil)
+	gothenburg := mock_fee.NewMockService(t)
+	gothenburg.EXPECT().ExplainPassages(mock.Anything, []models.Passage{{Gantry: "GBG-01", Timestamp: noon}}).
+		Return(fee.Explanation{Fee: 9, TariffVersion: "gbg-1", Currency: "SEK"}, nil)
+
+	zones := zone.New(
+		zone.NewRegistry(map[string]string{"STO-01": "stockholm", "STO-02": "stockholm", "GBG-01": "gothenburg"}),
+		map[string]fee.Service{"stockholm": stockholm, "gothenburg": gothenburg},
+	)
+
+	store := newTestStore(t, filepath.Join(t.TempDir(), "invoices.jsonl"))
+	s := New(log, registry, nil, nil, zones, store, time.UTC, 30).(*invoiceService)
+	s.now = func() time.Time { return time.Date(2025, 4, 2, 9, 0, 0, 0, time.UTC) }
+
+	got, err := s.Generate("owner-1", "2025-03")
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	wantLines := []Line{
+		{Date: "2025-03-04", Registration: "ABC123", VehicleType: "car", Zone: "gothenburg", Fee: 9},
+		{Date: "2025-03-04", Registration: "ABC123", VehicleType: "car", Zone: "stockholm", Fee: 45, CapApplied: true},
+	}
+	if !reflect.DeepEqual(got.Lines, wantLines) || got.Total != 54 {
+		t.Errorf("Generate() got lines %+v, total %d, want %+v, total 54", got.Lines, got.Total, wantLines)
+	}
+	if want := "gothenburg:gbg-1, stockholm:sto-1"; got.TariffVersion != want {
+		t.Errorf("Generate() got tariff version %q, want %q", got.TariffVersion, want)
+	}
+
+	// Recalculating the day with unchanged zone tariffs finds no difference to the invoice.
+	recalculation, err := s.Recalculate(RecalculationRequest{Registration: "ABC123", From: "2025-03-04", To: "2025-03-04", DryRun: true})
+	if err != nil {
+		t.Fatalf("Recalculate() error = %v", err)
+	}
+	want := []DayDifference{{Date: "2025-03-04", Invoice: 1, Billed: 54, Recalculated: 54}}
+	if !reflect.DeepEqual(recalculation.Days, want) {
+		t.Errorf("Recalculate() got days %+v, want %+v", recalculation.Days, want)
+	}
+}
@@ -0,0 +1,121 @@
+package invoice
+
+import (
+	"fmt"
+	"sync"
+
+	"afry-toll-calculator/internal/filestore"
+)
+
+// Store keeps issued invoices, and the credit and debit notes that correct them.
+type Store interface {
+	// Create assigns the invoice the next number of the sequence and stores it. Returns ErrAlreadyInvoiced if
+	// the owner already has an invoice for the period, and ErrNotFound for a note that corrects an unknown
+	// invoice. A number is only used once the invoice is stored, so the sequence has no gaps.
+	Create(inv Invoice) (Invoice, error)
+	// Get returns the invoice or note with the given number.
+	Get(number int) (Invoice, error)
+	// Find returns the invoice of an owner for a billing period, if it has been issued.
+	Find(owner, period string) (Invoice, bool)
+	// List returns all invoices and notes in the order of their numbers.
+	List() []Invoice
+}
+
+// Ensure conformance to the interface
+var _ Store = (*FileStore)(nil)
+
+// FileStore is a Store backed by an append-only file with one JSON invoice per line, in the order of their
+// numbers. Every invoice is synced to disk before it is returned. It is safe for concurrent use.
+type FileStore struct {
+	mu       sync.RWMutex
+	log      *filestore.Log[Invoice]
+	invoices []Invoice
+	byPeriod map[string]int
+}
+
+// NewFileStore opens the invoice file at path, creating it if it does not exist, and reads the issued invoices.
+// A last line that was only partially written, as after a crash, is discarded; its number is issued again.
+func NewFileStore(path string) (*FileStore, error) {
+	s := &FileStore{byPeriod: map[string]int{}}
+	log, err := filestore.OpenLog(path, "invoice", func(inv Invoice) error {
+		if inv.Number != len(s.invoices)+1 {
+			return fmt.Errorf("invoice number %d does not follow %d", inv.Number, len(s.invoices))
+		}
+		s.apply(inv)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.log = log
+
+	return s, nil
+}
+
+// Close closes the invoice file.
+func (s *FileStore) Close() error {
+	return s.log.Close()
+}
+
+func (s *FileStore) Create(inv Invoice) (Invoice, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if inv.isCorrection() {
+		if inv.Corrects < 1 || inv.Corrects > len(s.invoices) || s.invoices[inv.Corrects-1].isCorrection() {
+			return Invoice{}, fmt.Errorf("%w: %s corrects %d", ErrNotFound, inv.Kind, inv.Corrects)
+		}
+	} else if _, ok := s.byPeriod[periodKey(inv.Owner, inv.Period)]; ok {
+		return Invoice{}, fmt.Errorf("%w: owner %q, period %s", ErrAlreadyInvoiced, inv.Owner, inv.Period)
+	}
+
+	inv.Number = len(s.invoices) + 1
+	// The invoice is only applied once it is on disk, so the number is issued again if writing it fails.
+	if err := s.log.Append(inv); err != nil {
+		return Invoice{}, fmt.Errorf("failed to write invoice: %w", err)
+	}
+	s.apply(inv)
+
+	return inv, nil
+}
+
+func (s *FileStore) apply(inv Invoice) {
+	s.invoices = append(s.invoices, inv)
+	if !inv.isCorrection() {
+		s.byPeriod[periodKey(inv.Owner, inv.Period)] = inv.Number
+	}
+}
+
+func (s *FileStore) Get(number int) (Invoice, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if number < 1 || number > len(s.invoices) {
+		return Invoice{}, fmt.Errorf("%w: %d", ErrNotFound, number)
+	}
+
+	return s.invoices[number-1], nil
+}
+
+func (s *FileStore) Find(owner, period string) (Invoice, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	number, ok := s.byPeriod[periodKey(owner, period)]
+	if !ok {
+		return Invoice{}, false
+	}
+
+	return s.invoices[number-1], true
+}
+
+func (s *FileStore) List() []Invoice {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]Invoice(nil), s.invoices...)
+}
+
+func periodKey(owner, period string) string {
+	return owner + "|" + period
+}
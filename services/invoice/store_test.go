@@ -0,0 +1,125 @@
+package invoice
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func newTestStore(t *testing.T, path string) *FileStore {
+	t.Helper()
+
+	s, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+
+	return s
+}
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "invoices.jsonl")
+	store := newTestStore(t, path)
+
+	first, err := store.Create(Invoice{Owner: "owner-1", Period: "2025-03", Total: 120})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	second, err := store.Create(Invoice{Owner: "owner-2", Period: "2025-03", Total: 60})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if first.Number != 1 || second.Number != 2 {
+		t.Errorf("Create() numbers = %d, %d, want 1, 2", first.Number, second.Number)
+	}
+	if _, err := store.Create(Invoice{Owner: "owner-1", Period: "2025-03"}); !errors.Is(err, ErrAlreadyInvoiced) {
+		t.Errorf("Create() of an invoiced period error = %v, want %v", err, ErrAlreadyInvoiced)
+	}
+
+	// A rejected invoice does not use up a number.
+	third, err := store.Create(Invoice{Owner: "owner-1", Period: "2025-04"})
+	if err != nil || third.Number != 3 {
+		t.Errorf("Create() got = %+v, %v, want number 3", third, err)
+	}
+
+	if got, err := store.Get(2); err != nil || !reflect.DeepEqual(got, second) {
+		t.Errorf("Get() got = %+v, %v, want %+v", got, err, second)
+	}
+	if _, err := store.Get(4); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() of an unknown number error = %v, want %v", err, ErrNotFound)
+	}
+	if got, ok := store.Find("owner-1", "2025-03"); !ok || got.Number != 1 {
+		t.Errorf("Find() got = %+v, %v, want number 1", got, ok)
+	}
+	if _, ok := store.Find("owner-2", "2025-04"); ok {
+		t.Error("Find() of an uninvoiced period found an invoice")
+	}
+
+	// A new store over the same file continues the sequence, as after a restart.
+	reopened := newTestStore(t, path)
+	if got, err := reopened.Get(1); err != nil || !reflect.DeepEqual(got, first) {
+		t.Errorf("Get() after reopening got = %+v, %v, want %+v", got, err, first)
+	}
+	if next, err := reopened.Create(Invoice{Owner: "owner-2", Period: "2025-04"}); err != nil || next.Number != 4 {
+		t.Errorf("Create() after reopening got = %+v, %v, want number 4", next, err)
+	}
+}
+
+func TestNewFileStore_discardsPartialInvoice(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "invoices.jsonl")
+	content := `{"number":1,"owner":"owner-1","period":"2025-03"}` + "\n" + `{"number":2,"own`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	store := newTestStore(t, path)
+	if _, err := store.Get(2); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() of the partial invoice error = %v, want %v", err, ErrNotFound)
+	}
+
+	// The number of the partial invoice is issued again.
+	inv, err := store.Create(Invoice{Owner: "owner-2", Period: "2025-03"})
+	if err != nil || inv.Number != 2 {
+		t.Errorf("Create() got = %+v, %v, want number 2", inv, err)
+	}
+}
+
+func TestNewFileStore_rejectsGap(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "invoices.jsonl")
+	content := `{"number":1,"owner":"owner-1","period":"2025-03"}` + "\n" + `{"number":3,"owner":"owner-2","period":"2025-03"}` + "\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewFileStore(path); err == nil {
+		t.Error("NewFileStore() error = nil, want an error for the gap in the sequence")
+	}
+}
+
+func TestFileStore_notes(t *testing.T) {
+	store := newTestStore(t, filepath.Join(t.TempDir(), "invoices.jsonl"))
+	if _, err := store.Create(Invoice{Kind: KindInvoice, Owner: "owner-1", Period: "2025-03", Total: 120}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Notes correct an invoiced period, so they are not rejected as a second invoice of it.
+	credit, err := store.Create(Invoice{Kind: KindCreditNote, Corrects: 1, Owner: "owner-1", Period: "2025-03", Total: -20})
+	if err != nil || credit.Number != 2 {
+		t.Errorf("Create() of a credit note got = %+v, %v, want number 2", credit, err)
+	}
+	if got, ok := store.Find("owner-1", "2025-03"); !ok || got.Number != 1 {
+		t.Errorf("Find() got = %+v, %v, want the invoice", got, ok)
+	}
+
+	for _, corrects := range []int{0, 2, 3} {
+		if _, err := store.Create(Invoice{Kind: KindDebitNote, Corrects: corrects, Owner: "owner-1"}); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Create() of a note correcting %d error = %v, want %v", corrects, err, ErrNotFound)
+		}
+	}
+	if got := store.List(); len(got) != 2 || got[1].Kind != KindCreditNote {
+		t.Errorf("List() got = %+v, want the invoice and the credit note", got)
+	}
+}
@@ -0,0 +1,89 @@
+package multiplier
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"afry-toll-calculator/models"
+)
+
+// Rounding selects how prices are rounded to whole currency units after a multiplier has been applied.
+type Rounding string
+
+const (
+	// Nearest rounds to the nearest whole unit, halves away from zero.
+	Nearest Rounding = "nearest"
+	// Down rounds towards zero.
+	Down Rounding = "down"
+	// Up rounds away from zero.
+	Up Rounding = "up"
+)
+
+// ParseRounding returns the Rounding with the given name.
+func ParseRounding(name string) (Rounding, error) {
+	switch r := Rounding(name); r {
+	case Nearest, Down, Up:
+		return r, nil
+	default:
+		return "", fmt.Errorf("unknown rounding %q", name)
+	}
+}
+
+// Apply multiplies price by factor and rounds the result. The product is first rounded to six decimals, so
+// factors such as 1.1 that have no exact binary representation round as written.
+func (r Rounding) Apply(price int, factor float64) int {
+	x := math.Round(float64(price)*factor*1e6) / 1e6
+	switch r {
+	case Down:
+		return int(math.Trunc(x))
+	case Up:
+		if x < 0 {
+			return int(math.Floor(x))
+		}
+		return int(math.Ceil(x))
+	default:
+		return int(math.Round(x))
+	}
+}
+
+// Multiplier scales the prices paid by vehicles of a weight class, an emission class or both, such as 2 for
+// heavy vehicles or 0.5 for electric ones. An empty class matches any vehicle.
+type Multiplier struct {
+	WeightClass   models.WeightClass   `json:"weightClass,omitempty"`
+	EmissionClass models.EmissionClass `json:"emissionClass,omitempty"`
+	Factor        float64              `json:"factor"`
+}
+
+// Validate reports whether the multiplier selects vehicles by at least one class and has a usable factor.
+func (m Multiplier) Validate() error {
+	if m.WeightClass == "" && m.EmissionClass == "" {
+		return errors.New("multiplier must select a weightClass, an emissionClass or both")
+	}
+	if m.Factor < 0 || math.IsNaN(m.Factor) || math.IsInf(m.Factor, 0) {
+		return fmt.Errorf("multiplier factor %v must not be negative", m.Factor)
+	}
+
+	return nil
+}
+
+// Matches reports whether the multiplier applies to the vehicle.
+func (m Multiplier) Matches(v models.Vehicle) bool {
+	return (m.WeightClass == "" || m.WeightClass == v.GetWeightClass()) &&
+		(m.EmissionClass == "" || m.EmissionClass == v.GetEmissionClass())
+}
+
+// Factor returns the product of the factors of all multipliers that apply to the vehicle, together with those
+// multipliers in the order given. The factor is 1 if none apply.
+func Factor(multipliers []Multiplier, v models.Vehicle) (float64, []Multiplier) {
+	factor := 1.0
+	matched := []Multiplier{}
+	for _, m := range multipliers {
+		if m.Matches(v) {
+			factor *= m.Factor
+			matched = append(matched, m)
+		}
+	}
+
+	return factor, matched
+}
@@ -0,0 +1,110 @@
+package multiplier
+
+import (
+	"reflect"
+	"testing"
+
+	"afry-toll-calculator/models"
+)
+
+func TestRounding_Apply(t *testing.T) {
+	tests := []struct {
+		rounding Rounding
+		price    int
+		factor   float64
+		want     int
+	}{
+		{Nearest, 9, 0.5, 5},
+		{Down, 9, 0.5, 4},
+		{Up, 9, 0.5, 5},
+		{Nearest, 13, 0.5, 7},
+		{Nearest, 10, 1.1, 11},
+		{Up, 10, 1.1, 11},
+		{Down, 10, 1.1, 11},
+		{Down, 22, 1.25, 27},
+		{Up, 22, 1.25, 28},
+		{Nearest, 18, 2, 36},
+		{Nearest, 18, 0, 0},
+	}
+	for _, tt := range tests {
+		if got := tt.rounding.Apply(tt.price, tt.factor); got != tt.want {
+			t.Errorf("%s.Apply(%d, %v) = %d, want %d", tt.rounding, tt.price, tt.factor, got, tt.want)
+		}
+	}
+}
+
+func TestParseRounding(t *testing.T) {
+	for _, name := range []string{"nearest", "down", "up"} {
+		if _, err := ParseRounding(name); err != nil {
+			t.Errorf("ParseRounding(%q) error = %v", name, err)
+		}
+	}
+	if _, err := ParseRounding("bankers"); err == nil {
+		t.Error("ParseRounding() expected an error for an unknown rounding")
+	}
+}
+
+func TestFactor(t *testing.T) {
+	multipliers := []Multiplier{
+		{WeightClass: models.WeightClassHeavy, Factor: 2},
+		{EmissionClass: models.EmissionClassElectric, Factor: 0.5},
+		{WeightClass: models.WeightClassHeavy, EmissionClass: models.EmissionClassDiesel, Factor: 1.5},
+	}
+
+	tests := []struct {
+		name        string
+		vehicle     models.Vehicle
+		wantFactor  float64
+		wantMatched []Multiplier
+	}{
+		{
+			name:        "unclassified vehicle",
+			vehicle:     models.NewVehicle("car", false),
+			wantFactor:  1,
+			wantMatched: []Multiplier{},
+		},
+		{
+			name:        "heavy electric vehicle",
+			vehicle:     models.NewClassifiedVehicle("bus", false, models.WeightClassHeavy, models.EmissionClassElectric, models.Exemption{}),
+			wantFactor:  1,
+			wantMatched: []Multiplier{multipliers[0], multipliers[1]},
+		},
+		{
+			name:        "heavy diesel vehicle",
+			vehicle:     models.NewClassifiedVehicle("truck", false, models.WeightClassHeavy, models.EmissionClassDiesel, models.Exemption{}),
+			wantFactor:  3,
+			wantMatched: []Multiplier{multipliers[0], multipliers[2]},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			factor, matched := Factor(multipliers, tt.vehicle)
+			if factor != tt.wantFactor {
+				t.Errorf("Factor() factor = %v, want %v", factor, tt.wantFactor)
+			}
+			if !reflect.DeepEqual(matched, tt.wantMatched) {
+				t.Errorf("Factor() matched = %v, want %v", matched, tt.wantMatched)
+			}
+		})
+	}
+}
+
+func TestMultiplier_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		multiplier Multiplier
+		wantErr    bool
+	}{
+		{name: "weight class", multiplier: Multiplier{WeightClass: "heavy", Factor: 2}},
+		{name: "free for a class", multiplier: Multiplier{EmissionClass: "electric", Factor: 0}},
+		{name: "no class", multiplier: Multiplier{Factor: 2}, wantErr: true},
+		{name: "negative factor", multiplier: Multiplier{WeightClass: "heavy", Factor: -1}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.multiplier.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package passagelog
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"afry-toll-calculator/services/vehicleregistry"
+)
+
+// Event is a passage registered by a gantry camera.
+type Event struct {
+	// Seq is the position of the event in the log, assigned when it is appended.
+	Seq          uint64    `json:"seq"`
+	Registration string    `json:"registration"`
+	Gantry       string    `json:"gantry"`
+	Timestamp    time.Time `json:"timestamp"`
+	// Confidence is the certainty of the camera that it read the registration number correctly, between 0 and 1.
+	Confidence float64 `json:"confidence"`
+	// ImageRef refers to the camera image in external storage; it is never interpreted by the calculator.
+	ImageRef   string    `json:"imageRef,omitempty"`
+	ReceivedAt time.Time `json:"receivedAt"`
+}
+
+// Validate reports the first field of the event that is missing or invalid.
+func (e Event) Validate() error {
+	switch {
+	case vehicleregistry.Normalize(e.Registration) == "":
+		return errors.New("missing registration number")
+	case e.Gantry == "":
+		return errors.New("missing gantry")
+	case e.Timestamp.IsZero():
+		return errors.New("missing timestamp")
+	case math.IsNaN(e.Confidence) || e.Confidence < 0 || e.Confidence > 1:
+		return fmt.Errorf("confidence %v must be between 0 and 1", e.Confidence)
+	}
+
+	return nil
+}
+
+// key identifies the passage an event reports, so a camera resending an event is recognized as a duplicate.
+func (e Event) key() string {
+	return vehicleregistry.Normalize(e.Registration) + "|" + e.Gantry + "|" +
+		strconv.FormatInt(e.Timestamp.UnixNano(), 10)
+}
@@ -0,0 +1,309 @@
+package passagelog
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"afry-toll-calculator/services/vehicleregistry"
+)
+
+// DefaultSegmentSize is the size at which a new segment file is started.
+const DefaultSegmentSize = 64 << 20
+
+// DefaultDedupWindow is how long after an event was received a resent copy of it is recognized as a duplicate.
+const DefaultDedupWindow = 24 * time.Hour
+
+var (
+	ErrInvalid  = errors.New("invalid passage")
+	ErrNotFound = errors.New("passage not found")
+)
+
+// Log is a durable, append-only log of passage events.
+type Log interface {
+	// Append validates the event, assigns it a sequence number and writes it to the log. If an event of the same
+	// registration number, gantry and timestamp was received within the dedup window, that event is returned with
+	// duplicate set instead.
+	Append(e Event) (appended Event, duplicate bool, err error)
+	// Scan calls fn for every event in the log in the order they were appended, and stops at the first error
+	// returned by fn.
+	Scan(fn func(Event) error) error
+	// Get returns the event with the given sequence number.
+	Get(seq uint64) (Event, error)
+}
+
+// Ensure conformance to the interface
+var _ Log = (*SegmentLog)(nil)
+
+// SegmentLog is a Log stored in a directory of segment files. Every event is a record with a CRC-32C
+// checksum, and every append is synced to disk before it is acknowledged. A segment is closed once it reaches
+// the segment size and a new one is started, named after the sequence number of its first event.
+//
+// On startup all segments are verified. A record that was only partially written to the last segment, as after
+// a crash, is discarded; any other damage makes opening the log fail rather than silently losing passages.
+//
+// Resent events are recognized by the keys of the events received within the dedup window, which are kept in
+// memory. Older keys are evicted, so memory use does not grow with the size of the log.
+// It is safe for concurrent use.
+type SegmentLog struct {
+	dir         string
+	segmentSize int64
+	dedupWindow time.Duration
+	now         func() time.Time
+
+	mu       sync.RWMutex
+	segments []string
+	active   *os.File
+	size     int64
+	nextSeq  uint64
+	seen     map[string]uint64
+	// recent holds the keys in seen in the order they were received, for evicting them.
+	recent []recentKey
+}
+
+type recentKey struct {
+	key        string
+	receivedAt time.Time
+}
+
+// Open opens the segment log in dir, creating the directory if it does not exist. segmentSize is the size
+// at which a new segment is started and dedupWindow how long resent events are recognized; zero applies
+// DefaultSegmentSize and DefaultDedupWindow.
+func Open(dir string, segmentSize int64, dedupWindow time.Duration) (*SegmentLog, error) {
+	if segmentSize <= 0 {
+		segmentSize = DefaultSegmentSize
+	}
+	if dedupWindow <= 0 {
+		dedupWindow = DefaultDedupWindow
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	segments, err := filepath.Glob(filepath.Join(dir, "*.seg"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(segments)
+
+	l := &SegmentLog{
+		dir:         dir,
+		segmentSize: segmentSize,
+		dedupWindow: dedupWindow,
+		now:         time.Now,
+		segments:    segments,
+		nextSeq:     1,
+		seen:        map[string]uint64{},
+	}
+	for i, segment := range segments {
+		last := i == len(segments)-1
+		offset, err := readSegment(segment, -1, func(e Event) error {
+			// Events are replayed in the order they were received, so keys are evicted relative to the latest one.
+			l.evict(e.ReceivedAt.Add(-l.dedupWindow))
+			l.remember(e)
+			l.nextSeq = e.Seq + 1
+			return nil
+		})
+		if errors.Is(err, errTornRecord) && last {
+			slog.Warn("discarding partially written passage", "segment", segment, "offset", offset)
+			err = os.Truncate(segment, offset)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", segment, err)
+		}
+		if last {
+			l.size = offset
+		}
+	}
+
+	if len(segments) == 0 {
+		err = l.startSegment()
+	} else {
+		l.active, err = os.OpenFile(segments[len(segments)-1], os.O_WRONLY|os.O_APPEND, 0o644)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// Close closes the active segment.
+func (l *SegmentLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.active.Close()
+}
+
+func (l *SegmentLog) Append(e Event) (Event, bool, error) {
+	if err := e.Validate(); err != nil {
+		return Event{}, false, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.evict(now.Add(-l.dedupWindow))
+	if seq, ok := l.seen[e.key()]; ok {
+		existing, err := l.read(seq)
+		return existing, true, err
+	}
+
+	e.Seq = l.nextSeq
+	e.Registration = vehicleregistry.Normalize(e.Registration)
+	e.ReceivedAt = now
+	record, err := encodeRecord(e)
+	if err != nil {
+		return Event{}, false, err
+	}
+
+	if l.size > 0 && l.size+int64(len(record)) > l.segmentSize {
+		if err := l.startSegment(); err != nil {
+			return Event{}, false, fmt.Errorf("failed to start passage log segment: %w", err)
+		}
+	}
+
+	n, err := l.active.Write(record)
+	if err == nil {
+		err = l.active.Sync()
+	}
+	if err != nil {
+		// Cut off whatever part of the record made it to the file, so the next append starts on a record boundary.
+		if errTruncate := l.active.Truncate(l.size); errTruncate != nil {
+			slog.Error("failed to discard partial passage record", "error", errTruncate)
+		}
+		return Event{}, false, fmt.Errorf("failed to append passage: %w", err)
+	}
+
+	l.size += int64(n)
+	l.nextSeq++
+	l.remember(e)
+
+	return e, false, nil
+}
+
+// remember records the key of an event for recognizing resent copies of it. Must be called with l.mu held.
+func (l *SegmentLog) remember(e Event) {
+	key := e.key()
+	l.seen[key] = e.Seq
+	l.recent = append(l.recent, recentKey{key: key, receivedAt: e.ReceivedAt})
+}
+
+// evict forgets the keys of the events received before cutoff. Must be called with l.mu held.
+func (l *SegmentLog) evict(cutoff time.Time) {
+	i := 0
+	for ; i < len(l.recent) && l.recent[i].receivedAt.Before(cutoff); i++ {
+		delete(l.seen, l.recent[i].key)
+	}
+	l.recent = l.recent[i:]
+}
+
+// startSegment closes the active segment and starts a new one named after the next sequence number. Must be
+// called with l.mu held.
+func (l *SegmentLog) startSegment() error {
+	path := filepath.Join(l.dir, fmt.Sprintf("%020d.seg", l.nextSeq))
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return err
+	}
+	// Sync the directory, so the new segment survives a crash along with the records synced to it.
+	if err := syncDir(l.dir); err != nil {
+		f.Close()
+		return err
+	}
+
+	if l.active != nil {
+		if err := l.active.Close(); err != nil {
+			slog.Error("failed to close passage log segment", "error", err)
+		}
+	}
+	l.active, l.size = f, 0
+	l.segments = append(l.segments, path)
+
+	return nil
+}
+
+func (l *SegmentLog) Scan(fn func(Event) error) error {
+	// Only the events appended so far are scanned, without holding the lock while reading, so appends are not
+	// blocked by a long scan.
+	l.mu.RLock()
+	segments := append([]string(nil), l.segments...)
+	activeSize := l.size
+	l.mu.RUnlock()
+
+	for i, segment := range segments {
+		limit := int64(-1)
+		if i == len(segments)-1 {
+			limit = activeSize
+		}
+		if _, err := readSegment(segment, limit, fn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (l *SegmentLog) Get(seq uint64) (Event, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if seq == 0 || seq >= l.nextSeq {
+		return Event{}, fmt.Errorf("%w: %d", ErrNotFound, seq)
+	}
+
+	return l.read(seq)
+}
+
+// read returns the event with the given sequence number. Must be called with l.mu held.
+func (l *SegmentLog) read(seq uint64) (Event, error) {
+	// Segments are named after the sequence number of their first event, so the event is in the last segment
+	// whose name sorts at or before it.
+	name := fmt.Sprintf("%020d.seg", seq)
+	i := sort.Search(len(l.segments), func(i int) bool {
+		return filepath.Base(l.segments[i]) > name
+	}) - 1
+	if i < 0 {
+		return Event{}, fmt.Errorf("%w: %d", ErrNotFound, seq)
+	}
+
+	limit := int64(-1)
+	if i == len(l.segments)-1 {
+		limit = l.size
+	}
+
+	var found Event
+	errFound := errors.New("found")
+	_, err := readSegment(l.segments[i], limit, func(e Event) error {
+		if e.Seq == seq {
+			found = e
+			return errFound
+		}
+		return nil
+	})
+	if errors.Is(err, errFound) {
+		return found, nil
+	}
+	if err == nil {
+		err = fmt.Errorf("%w: %d", ErrNotFound, seq)
+	}
+
+	return Event{}, err
+}
+
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	return d.Sync()
+}
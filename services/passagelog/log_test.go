@@ -0,0 +1,255 @@
+package passagelog
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func newTestLog(t *testing.T, dir string, segmentSize int64) *SegmentLog {
+	t.Helper()
+
+	l, err := Open(dir, segmentSize, time.Hour)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	t.Cleanup(func() { l.Close() })
+	l.now = func() time.Time { return time.Date(2025, 3, 4, 8, 0, 0, 0, time.UTC) }
+
+	return l
+}
+
+func testEvent(minute int) Event {
+	return Event{
+		Registration: "ABC123",
+		Gantry:       "STO-01",
+		Timestamp:    time.Date(2025, 3, 4, 7, minute, 0, 0, time.UTC),
+		Confidence:   0.98,
+		ImageRef:     "s3://images/1.jpg",
+	}
+}
+
+func scanAll(t *testing.T, l *SegmentLog) []Event {
+	t.Helper()
+
+	var events []Event
+	if err := l.Scan(func(e Event) error {
+		events = append(events, e)
+		return nil
+	}); err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+
+	return events
+}
+
+func TestSegmentLog(t *testing.T) {
+	dir := t.TempDir()
+	l := newTestLog(t, dir, 0)
+
+	first, duplicate, err := l.Append(testEvent(0))
+	if err != nil || duplicate {
+		t.Fatalf("Append() got = %v, %v", duplicate, err)
+	}
+	if first.Seq != 1 || first.ReceivedAt.IsZero() {
+		t.Errorf("Append() got = %+v", first)
+	}
+
+	// The same passage reported again, with a differently formatted registration number, is a duplicate.
+	resent := testEvent(0)
+	resent.Registration = "abc 123"
+	resent.Confidence = 0.5
+	got, duplicate, err := l.Append(resent)
+	if err != nil || !duplicate || !reflect.DeepEqual(got, first) {
+		t.Errorf("Append() of a duplicate got = %+v, %v, %v", got, duplicate, err)
+	}
+
+	if _, _, err := l.Append(Event{Gantry: "STO-01"}); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Append() of an invalid event error = %v, want %v", err, ErrInvalid)
+	}
+
+	second, _, err := l.Append(testEvent(5))
+	if err != nil || second.Seq != 2 {
+		t.Fatalf("Append() got = %+v, %v", second, err)
+	}
+
+	// A new log over the same directory sees the events and their keys, as after a restart.
+	want := scanAll(t, l)
+	l.Close()
+	l = newTestLog(t, dir, 0)
+	if got := scanAll(t, l); !reflect.DeepEqual(got, want) {
+		t.Errorf("Scan() after restart got = %+v, want %+v", got, want)
+	}
+	if _, duplicate, _ := l.Append(testEvent(5)); !duplicate {
+		t.Error("Append() after restart did not recognize a duplicate")
+	}
+	if third, _, err := l.Append(testEvent(10)); err != nil || third.Seq != 3 {
+		t.Errorf("Append() after restart got = %+v, %v", third, err)
+	}
+}
+
+func TestSegmentLog_rotation(t *testing.T) {
+	dir := t.TempDir()
+	record, err := encodeRecord(testEvent(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Room for two records per segment.
+	l := newTestLog(t, dir, int64(2*len(record)+10))
+
+	for minute := 0; minute < 5; minute++ {
+		if _, _, err := l.Append(testEvent(minute)); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	segments, err := filepath.Glob(filepath.Join(dir, "*.seg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(segments) != 3 {
+		t.Errorf("Append() wrote %d segments, want 3", len(segments))
+	}
+	if got := len(scanAll(t, l)); got != 5 {
+		t.Errorf("Scan() got %d events, want 5", got)
+	}
+
+	if got, err := l.Get(3); err != nil || got.Seq != 3 || !got.Timestamp.Equal(testEvent(2).Timestamp) {
+		t.Errorf("Get() from a closed segment got = %+v, %v", got, err)
+	}
+	if got, err := l.Get(5); err != nil || got.Seq != 5 {
+		t.Errorf("Get() from the active segment got = %+v, %v", got, err)
+	}
+	for _, seq := range []uint64{0, 6} {
+		if _, err := l.Get(seq); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Get(%d) error = %v, want %v", seq, err, ErrNotFound)
+		}
+	}
+
+	// Duplicates are found in closed segments as well.
+	got, duplicate, err := l.Append(testEvent(0))
+	if err != nil || !duplicate || got.Seq != 1 {
+		t.Errorf("Append() of a duplicate in a closed segment got = %+v, %v, %v", got, duplicate, err)
+	}
+}
+
+func TestSegmentLog_dedupWindow(t *testing.T) {
+	dir := t.TempDir()
+	l := newTestLog(t, dir, 0)
+	received := l.now()
+
+	if _, _, err := l.Append(testEvent(0)); err != nil {
+		t.Fatal(err)
+	}
+	l.now = func() time.Time { return received.Add(30 * time.Minute) }
+	if _, _, err := l.Append(testEvent(5)); err != nil {
+		t.Fatal(err)
+	}
+
+	// The first event was received more than the window of an hour ago, so its key has been evicted and a resent
+	// copy is stored as a new event.
+	l.now = func() time.Time { return received.Add(time.Hour + time.Minute) }
+	if got, duplicate, err := l.Append(testEvent(5)); err != nil || !duplicate || got.Seq != 2 {
+		t.Errorf("Append() within the window got = %+v, %v, %v", got, duplicate, err)
+	}
+	if got, duplicate, err := l.Append(testEvent(0)); err != nil || duplicate || got.Seq != 3 {
+		t.Errorf("Append() after the window got = %+v, %v, %v", got, duplicate, err)
+	}
+	if len(l.seen) != 2 || len(l.recent) != 2 {
+		t.Errorf("Append() kept %d keys and %d recent keys, want 2", len(l.seen), len(l.recent))
+	}
+
+	// Replaying the log on startup only keeps the keys within the window of the latest event.
+	l.Close()
+	l = newTestLog(t, dir, 0)
+	if len(l.seen) != 2 {
+		t.Errorf("Open() kept %d keys, want 2", len(l.seen))
+	}
+}
+
+func TestOpen_tornRecord(t *testing.T) {
+	dir := t.TempDir()
+	l := newTestLog(t, dir, 0)
+	for minute := 0; minute < 2; minute++ {
+		if _, _, err := l.Append(testEvent(minute)); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+	l.Close()
+
+	// Simulate a crash in the middle of appending the third event.
+	record, err := encodeRecord(testEvent(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	segment := filepath.Join(dir, "00000000000000000001.seg")
+	f, err := os.OpenFile(segment, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Write(record[:len(record)/2]); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	l = newTestLog(t, dir, 0)
+	if got := len(scanAll(t, l)); got != 2 {
+		t.Fatalf("Scan() got %d events, want 2", got)
+	}
+	if third, _, err := l.Append(testEvent(2)); err != nil || third.Seq != 3 {
+		t.Fatalf("Append() got = %+v, %v", third, err)
+	}
+	if got := len(scanAll(t, l)); got != 3 {
+		t.Errorf("Scan() got %d events, want 3", got)
+	}
+}
+
+func TestOpen_corruptRecord(t *testing.T) {
+	dir := t.TempDir()
+	l := newTestLog(t, dir, 0)
+	if _, _, err := l.Append(testEvent(0)); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	l.Close()
+
+	segment := filepath.Join(dir, "00000000000000000001.seg")
+	data, err := os.ReadFile(segment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data[len(data)-2] ^= 0xff
+	if err := os.WriteFile(segment, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Open(dir, 0, 0); err == nil {
+		t.Error("Open() expected an error for a record with an invalid checksum")
+	}
+}
+
+func TestEvent_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(e *Event)
+		wantErr bool
+	}{
+		{name: "valid", modify: func(*Event) {}},
+		{name: "missing registration", modify: func(e *Event) { e.Registration = "" }, wantErr: true},
+		{name: "missing gantry", modify: func(e *Event) { e.Gantry = "" }, wantErr: true},
+		{name: "missing timestamp", modify: func(e *Event) { e.Timestamp = time.Time{} }, wantErr: true},
+		{name: "confidence above 1", modify: func(e *Event) { e.Confidence = 1.5 }, wantErr: true},
+		{name: "negative confidence", modify: func(e *Event) { e.Confidence = -0.1 }, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := testEvent(0)
+			tt.modify(&e)
+			if err := e.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
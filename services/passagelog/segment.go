@@ -0,0 +1,95 @@
+package passagelog
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+)
+
+// Records are framed by a header holding the length of the JSON payload and its CRC-32C checksum, both as
+// big-endian uint32.
+const (
+	headerSize    = 8
+	maxRecordSize = 1 << 20
+)
+
+var (
+	crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+	// errTornRecord is returned for a record that ends before its header says it should, as after a crash in
+	// the middle of an append.
+	errTornRecord = errors.New("torn record")
+)
+
+func encodeRecord(e Event) ([]byte, error) {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	if len(payload) > maxRecordSize {
+		return nil, fmt.Errorf("event of %d bytes exceeds the maximum record size", len(payload))
+	}
+
+	record := make([]byte, headerSize+len(payload))
+	binary.BigEndian.PutUint32(record[0:4], uint32(len(payload)))
+	binary.BigEndian.PutUint32(record[4:8], crc32.Checksum(payload, crcTable))
+	copy(record[headerSize:], payload)
+
+	return record, nil
+}
+
+// readSegment calls fn for every record of the segment at path, up to limit bytes if limit is not negative.
+// It returns the offset after the last valid record; the error describes why reading stopped early, if it did.
+func readSegment(path string, limit int64, fn func(Event) error) (int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if limit >= 0 {
+		r = io.LimitReader(f, limit)
+	}
+	br := bufio.NewReader(r)
+
+	var (
+		offset int64
+		header [headerSize]byte
+	)
+	for {
+		if _, err := io.ReadFull(br, header[:]); err != nil {
+			if errors.Is(err, io.EOF) {
+				return offset, nil
+			}
+			return offset, errTornRecord
+		}
+
+		size := binary.BigEndian.Uint32(header[0:4])
+		if size > maxRecordSize {
+			return offset, fmt.Errorf("record at offset %d has invalid size %d", offset, size)
+		}
+		payload := make([]byte, size)
+		if _, err := io.ReadFull(br, payload); err != nil {
+			return offset, errTornRecord
+		}
+		if crc32.Checksum(payload, crcTable) != binary.BigEndian.Uint32(header[4:8]) {
+			return offset, fmt.Errorf("record at offset %d has an invalid checksum", offset)
+		}
+
+		var e Event
+		if err := json.Unmarshal(payload, &e); err != nil {
+			return offset, fmt.Errorf("record at offset %d: %w", offset, err)
+		}
+		if err := fn(e); err != nil {
+			return offset, err
+		}
+
+		offset += headerSize + int64(size)
+	}
+}
@@ -0,0 +1,698 @@
+package pricelist
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"afry-toll-calculator/models"
+	"afry-toll-calculator/services/calendar"
+	"afry-toll-calculator/services/multiplier"
+	"afry-toll-calculator/services/window"
+)
+
+// Ensure conformance to the interface
+var _ PriceListVersionGetter = (*FileGetter)(nil)
+
+// FileGetter provides price list versions loaded from a YAML or JSON tariff document. JSON documents are
+// read with the YAML parser, which accepts them as-is and keeps line numbers for error reporting.
+//
+// A tariff document either lists price blocks directly, which are then in force until further notice:
+//
+//	name: Stockholm congestion tax
+//	currency: SEK
+//	blocks:
+//	  - start: "06:00"
+//	    price: 8
+//
+// or lists versions with validity dates, which are interpreted as midnight in the billing location. The versions
+// must price every passage, so the first has no validFrom and the last no validTo:
+//
+//	versions:
+//	  - validTo: 2026-01-01
+//	    blocks:
+//	      - start: "06:00"
+//	        price: 8
+//	  - validFrom: 2026-01-01
+//	    blocks:
+//	      - start: "06:00"
+//	        price: 9
+//
+// An optional calendar lists the rules that make a day toll-free, evaluated in order. The default calendar
+// exempts weekends and public holidays:
+//
+//	calendar:
+//	  - weekend
+//	  - publicHoliday
+//	  - dayBeforePublicHoliday
+//	  - month: July
+//	  - dates: [2025-06-05]
+//
+// The optional dailyCap, window and windowStrategy fields replace the Stockholm daily cap of 60, the one hour
+// window and the window opened by the first passage. A dailyCap of none charges every day in full:
+//
+//	dailyCap: 60
+//	window: 60m
+//	windowStrategy: slidingHighest
+//
+// Optional multipliers scale the prices and the daily cap of vehicles by weight class, emission class or both.
+// The factors of all matching multipliers are multiplied, and the results are rounded to whole units with
+// rounding, which is nearest (default), down or up:
+//
+//	multipliers:
+//	  - weightClass: heavy
+//	    factor: 2
+//	  - emissionClass: electric
+//	    factor: 0.5
+//	rounding: up
+//
+// The optional dedupWindow collapses passages at the same gantry less than the given duration apart into the
+// first of them, such as repeated reads of the same vehicle by one gantry:
+//
+//	dedupWindow: 10s
+type FileGetter struct {
+	version        string
+	name           string
+	currency       string
+	versions       []PriceListVersion
+	calendar       []calendar.Rule
+	dailyCap       *int
+	window         time.Duration
+	windowStrategy window.Strategy
+	multipliers    []multiplier.Multiplier
+	rounding       multiplier.Rounding
+	dedupWindow    time.Duration
+}
+
+// ParseError is returned when a tariff document fails to parse or validate. Line is 0 for errors that
+// do not relate to a specific line.
+type ParseError struct {
+	Path string
+	Line int
+	Err  error
+}
+
+func (e *ParseError) Error() string {
+	if e.Line == 0 {
+		return fmt.Sprintf("%s: %v", e.Path, e.Err)
+	}
+
+	return fmt.Sprintf("%s:%d: %v", e.Path, e.Line, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+var (
+	startTimeFormat = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`)
+	yamlSyntaxError = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+)
+
+// NewFileGetter reads and validates the tariff document at path. Returns a *ParseError pointing at the
+// offending line if the document is invalid.
+func NewFileGetter(path string, location *time.Location) (*FileGetter, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	p := tariffParser{path: path, location: location}
+	g, err := p.parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(data)
+	g.version = hex.EncodeToString(sum[:6])
+
+	return g, nil
+}
+
+// GetPriceListVersions returns the price list versions of the tariff document.
+func (g *FileGetter) GetPriceListVersions() []PriceListVersion {
+	out := make([]PriceListVersion, len(g.versions))
+	copy(out, g.versions)
+
+	return out
+}
+
+// Version identifies the content of the tariff document. It is the start of the SHA-256 digest of the document,
+// so it changes with every edit and stays the same across restarts.
+func (g *FileGetter) Version() string {
+	return g.version
+}
+
+// Name returns the name of the tariff.
+func (g *FileGetter) Name() string {
+	return g.name
+}
+
+// Currency returns the currency the tariff prices are expressed in.
+func (g *FileGetter) Currency() string {
+	return g.currency
+}
+
+// Calendar returns the calendar rules of the tariff, or nil if the tariff does not define a calendar.
+func (g *FileGetter) Calendar() []calendar.Rule {
+	return g.calendar
+}
+
+// DailyCap returns the daily cap of the tariff, 0 if the tariff has no daily cap, or nil if it does not define one.
+func (g *FileGetter) DailyCap() *int {
+	return g.dailyCap
+}
+
+// Window returns the window length of the tariff, or 0 if the tariff does not define one.
+func (g *FileGetter) Window() time.Duration {
+	return g.window
+}
+
+// WindowStrategy returns the window strategy of the tariff, or an empty strategy if the tariff does not
+// define one.
+func (g *FileGetter) WindowStrategy() window.Strategy {
+	return g.windowStrategy
+}
+
+// Multipliers returns the vehicle multipliers of the tariff, or nil if the tariff does not define any.
+func (g *FileGetter) Multipliers() []multiplier.Multiplier {
+	return g.multipliers
+}
+
+// Rounding returns the rounding of multiplied prices, or an empty rounding if the tariff does not define one.
+func (g *FileGetter) Rounding() multiplier.Rounding {
+	return g.rounding
+}
+
+// DedupWindow returns the window within which passages at the same gantry are collapsed, or 0 if the tariff
+// does not define one.
+func (g *FileGetter) DedupWindow() time.Duration {
+	return g.dedupWindow
+}
+
+type tariffParser struct {
+	path     string
+	location *time.Location
+}
+
+func (p *tariffParser) errorf(node *yaml.Node, format string, args ...interface{}) error {
+	line := 0
+	if node != nil {
+		line = node.Line
+	}
+
+	return &ParseError{Path: p.path, Line: line, Err: fmt.Errorf(format, args...)}
+}
+
+func (p *tariffParser) parse(data []byte) (*FileGetter, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		if m := yamlSyntaxError.FindStringSubmatch(err.Error()); m != nil {
+			line, _ := strconv.Atoi(m[1])
+			return nil, &ParseError{Path: p.path, Line: line, Err: errors.New(m[2])}
+		}
+
+		return nil, &ParseError{Path: p.path, Err: err}
+	}
+	if len(root.Content) == 0 {
+		return nil, &ParseError{Path: p.path, Err: errors.New("tariff document is empty")}
+	}
+
+	doc := root.Content[0]
+	if doc.Kind != yaml.MappingNode {
+		return nil, p.errorf(doc, "tariff document must be a mapping")
+	}
+
+	g := &FileGetter{}
+	var blocks, versions, windowNode *yaml.Node
+	for i := 0; i < len(doc.Content); i += 2 {
+		key, value := doc.Content[i], doc.Content[i+1]
+		var err error
+		switch key.Value {
+		case "name":
+			g.name, err = p.scalar(value, "name")
+		case "currency":
+			g.currency, err = p.scalar(value, "currency")
+		case "blocks":
+			blocks = value
+		case "versions":
+			versions = value
+		case "calendar":
+			g.calendar, err = p.parseCalendar(value)
+		case "dailyCap":
+			g.dailyCap, err = p.parseDailyCap(value)
+		case "window":
+			windowNode = value
+			g.window, err = p.parseWindow(value)
+		case "windowStrategy":
+			g.windowStrategy, err = p.parseWindowStrategy(value)
+		case "multipliers":
+			g.multipliers, err = p.parseMultipliers(value)
+		case "rounding":
+			g.rounding, err = p.parseRounding(value)
+		case "dedupWindow":
+			g.dedupWindow, err = p.parseDedupWindow(value)
+		default:
+			err = p.errorf(key, "unknown field %q", key.Value)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if g.name == "" {
+		return nil, p.errorf(doc, "missing tariff name")
+	}
+	if g.currency == "" {
+		return nil, p.errorf(doc, "missing tariff currency")
+	}
+	if windowNode != nil {
+		strategy := g.windowStrategy
+		if strategy == "" {
+			strategy = window.FirstPassage
+		}
+		if err := strategy.Validate(g.window); err != nil {
+			return nil, p.errorf(windowNode, "%v", err)
+		}
+	}
+
+	switch {
+	case blocks != nil && versions != nil:
+		return nil, p.errorf(versions, "tariff must define either blocks or versions, not both")
+	case blocks != nil:
+		b, err := p.parseBlocks(blocks)
+		if err != nil {
+			return nil, err
+		}
+		g.versions = []PriceListVersion{{Blocks: b}}
+	case versions != nil:
+		v, err := p.parseVersions(versions)
+		if err != nil {
+			return nil, err
+		}
+		g.versions = v
+	default:
+		return nil, p.errorf(doc, "tariff must define blocks or versions")
+	}
+
+	return g, nil
+}
+
+func (p *tariffParser) scalar(node *yaml.Node, field string) (string, error) {
+	if node.Kind != yaml.ScalarNode {
+		return "", p.errorf(node, "%s must be a scalar value", field)
+	}
+
+	return node.Value, nil
+}
+
+func (p *tariffParser) parseVersions(node *yaml.Node) ([]PriceListVersion, error) {
+	if node.Kind != yaml.SequenceNode || len(node.Content) == 0 {
+		return nil, p.errorf(node, "versions must be a non-empty list")
+	}
+
+	versions := make([]PriceListVersion, 0, len(node.Content))
+	for _, item := range node.Content {
+		if item.Kind != yaml.MappingNode {
+			return nil, p.errorf(item, "version must be a mapping")
+		}
+
+		var (
+			v      PriceListVersion
+			blocks *yaml.Node
+		)
+		for i := 0; i < len(item.Content); i += 2 {
+			key, value := item.Content[i], item.Content[i+1]
+			var err error
+			switch key.Value {
+			case "validFrom":
+				v.ValidFrom, err = p.parseDate(value, "validFrom")
+			case "validTo":
+				v.ValidTo, err = p.parseDate(value, "validTo")
+			case "blocks":
+				blocks = value
+			default:
+				err = p.errorf(key, "unknown field %q", key.Value)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if blocks == nil {
+			return nil, p.errorf(item, "version must define blocks")
+		}
+		if !v.ValidTo.IsZero() && !v.ValidTo.After(v.ValidFrom) {
+			return nil, p.errorf(item, "validTo must be after validFrom")
+		}
+
+		b, err := p.parseBlocks(blocks)
+		if err != nil {
+			return nil, err
+		}
+		v.Blocks = b
+
+		versions = append(versions, v)
+	}
+
+	// validateVersions sorts the versions stably by ValidFrom, so sorting their positions the same way maps the
+	// offending version back to its node.
+	order := make([]int, len(versions))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return versions[order[i]].ValidFrom.Before(versions[order[j]].ValidFrom)
+	})
+	if i, err := validateVersions(versions); err != nil {
+		var item *yaml.Node
+		if i >= 0 {
+			item = node.Content[order[i]]
+		}
+		return nil, p.errorf(item, "%w", err)
+	}
+
+	return versions, nil
+}
+
+func (p *tariffParser) parseDate(node *yaml.Node, field string) (time.Time, error) {
+	value, err := p.scalar(node, field)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	t, err := time.ParseInLocation("2006-01-02", value, p.location)
+	if err != nil {
+		return time.Time{}, p.errorf(node, "%s %q is not a date in YYYY-MM-DD format", field, value)
+	}
+
+	return t, nil
+}
+
+// parseBlocks parses and validates a list of price blocks. Blocks must be listed in ascending order of their
+// start time; repeating a start time is only allowed with the same price and the repeated block is dropped.
+func (p *tariffParser) parseBlocks(node *yaml.Node) ([]PriceBlock, error) {
+	if node.Kind != yaml.SequenceNode || len(node.Content) == 0 {
+		return nil, p.errorf(node, "blocks must be a non-empty list")
+	}
+
+	blocks := make([]PriceBlock, 0, len(node.Content))
+	for _, item := range node.Content {
+		block, err := p.parseBlock(item)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(blocks) > 0 {
+			prev := blocks[len(blocks)-1]
+			switch {
+			case block.Start < prev.Start:
+				return nil, p.errorf(item, "block starting at %s must be listed before block starting at %s",
+					formatMinutes(block.Start), formatMinutes(prev.Start))
+			case block.Start == prev.Start && block.Price != prev.Price:
+				return nil, p.errorf(item, "block starting at %s is defined twice with different prices",
+					formatMinutes(block.Start))
+			case block.Start == prev.Start:
+				continue
+			}
+		}
+
+		blocks = append(blocks, block)
+	}
+
+	return blocks, nil
+}
+
+func (p *tariffParser) parseBlock(node *yaml.Node) (PriceBlock, error) {
+	if node.Kind != yaml.MappingNode {
+		return PriceBlock{}, p.errorf(node, "block must be a mapping")
+	}
+
+	var (
+		block              PriceBlock
+		hasStart, hasPrice bool
+	)
+	for i := 0; i < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		switch key.Value {
+		case "start":
+			if value.Kind != yaml.ScalarNode || !startTimeFormat.MatchString(value.Value) {
+				return PriceBlock{}, p.errorf(value, "start %q must be a time between 00:00 and 23:59 in HH:MM format", value.Value)
+			}
+			block.Start = mustMinutes(value.Value)
+			hasStart = true
+		case "price":
+			price, err := strconv.Atoi(value.Value)
+			if value.Kind != yaml.ScalarNode || err != nil {
+				return PriceBlock{}, p.errorf(value, "price %q must be a whole number", value.Value)
+			}
+			if price < 0 {
+				return PriceBlock{}, p.errorf(value, "price %d must not be negative", price)
+			}
+			block.Price = price
+			hasPrice = true
+		default:
+			return PriceBlock{}, p.errorf(key, "unknown field %q", key.Value)
+		}
+	}
+
+	if !hasStart {
+		return PriceBlock{}, p.errorf(node, "block is missing start")
+	}
+	if !hasPrice {
+		return PriceBlock{}, p.errorf(node, "block is missing price")
+	}
+
+	return block, nil
+}
+
+func (p *tariffParser) parseCalendar(node *yaml.Node) ([]calendar.Rule, error) {
+	if node.Kind != yaml.SequenceNode || len(node.Content) == 0 {
+		return nil, p.errorf(node, "calendar must be a non-empty list")
+	}
+
+	rules := make([]calendar.Rule, 0, len(node.Content))
+	for _, item := range node.Content {
+		rule, err := p.parseCalendarRule(item)
+		if err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+func (p *tariffParser) parseCalendarRule(node *yaml.Node) (calendar.Rule, error) {
+	if node.Kind == yaml.ScalarNode {
+		switch node.Value {
+		case "weekend":
+			return calendar.Weekend(), nil
+		case "publicHoliday":
+			return calendar.PublicHoliday(), nil
+		case "dayBeforePublicHoliday":
+			return calendar.DayBeforePublicHoliday(), nil
+		default:
+			return nil, p.errorf(node, "unknown calendar rule %q", node.Value)
+		}
+	}
+
+	if node.Kind != yaml.MappingNode || len(node.Content) != 2 {
+		return nil, p.errorf(node, "calendar rule must be a rule name or a mapping with a single field")
+	}
+
+	key, value := node.Content[0], node.Content[1]
+	switch key.Value {
+	case "month":
+		month, err := p.parseMonth(value)
+		if err != nil {
+			return nil, err
+		}
+		return calendar.Month(month), nil
+	case "dates":
+		if value.Kind != yaml.SequenceNode || len(value.Content) == 0 {
+			return nil, p.errorf(value, "dates must be a non-empty list")
+		}
+		dates := make([]string, 0, len(value.Content))
+		for _, item := range value.Content {
+			date, err := p.parseDate(item, "date")
+			if err != nil {
+				return nil, err
+			}
+			dates = append(dates, date.Format("2006-01-02"))
+		}
+		return calendar.Dates(dates...), nil
+	default:
+		return nil, p.errorf(key, "unknown calendar rule %q", key.Value)
+	}
+}
+
+// parseMonth accepts an English month name or a month number between 1 and 12.
+func (p *tariffParser) parseMonth(node *yaml.Node) (time.Month, error) {
+	value, err := p.scalar(node, "month")
+	if err != nil {
+		return 0, err
+	}
+
+	if n, err := strconv.Atoi(value); err == nil && n >= 1 && n <= 12 {
+		return time.Month(n), nil
+	}
+	for m := time.January; m <= time.December; m++ {
+		if strings.EqualFold(value, m.String()) {
+			return m, nil
+		}
+	}
+
+	return 0, p.errorf(node, "month %q must be a month name or a number between 1 and 12", value)
+}
+
+// noDailyCap is the dailyCap of a tariff without a daily cap.
+const noDailyCap = "none"
+
+func (p *tariffParser) parseDailyCap(node *yaml.Node) (*int, error) {
+	if node.Kind == yaml.ScalarNode && node.Value == noDailyCap {
+		dailyCap := 0
+		return &dailyCap, nil
+	}
+
+	dailyCap, err := strconv.Atoi(node.Value)
+	if node.Kind != yaml.ScalarNode || err != nil || dailyCap <= 0 {
+		return nil, p.errorf(node, "dailyCap %q must be a positive whole number or %s", node.Value, noDailyCap)
+	}
+
+	return &dailyCap, nil
+}
+
+func (p *tariffParser) parseWindow(node *yaml.Node) (time.Duration, error) {
+	value, err := p.scalar(node, "window")
+	if err != nil {
+		return 0, err
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, p.errorf(node, "window %q must be a duration such as 60m", value)
+	}
+
+	return d, nil
+}
+
+func (p *tariffParser) parseDedupWindow(node *yaml.Node) (time.Duration, error) {
+	value, err := p.scalar(node, "dedupWindow")
+	if err != nil {
+		return 0, err
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return 0, p.errorf(node, "dedupWindow %q must be a non-negative duration such as 10s", value)
+	}
+
+	return d, nil
+}
+
+func (p *tariffParser) parseWindowStrategy(node *yaml.Node) (window.Strategy, error) {
+	value, err := p.scalar(node, "windowStrategy")
+	if err != nil {
+		return "", err
+	}
+
+	strategy, err := window.ParseStrategy(value)
+	if err != nil {
+		return "", p.errorf(node, "%v", err)
+	}
+
+	return strategy, nil
+}
+
+func (p *tariffParser) parseMultipliers(node *yaml.Node) ([]multiplier.Multiplier, error) {
+	if node.Kind != yaml.SequenceNode || len(node.Content) == 0 {
+		return nil, p.errorf(node, "multipliers must be a non-empty list")
+	}
+
+	multipliers := make([]multiplier.Multiplier, 0, len(node.Content))
+	for _, item := range node.Content {
+		m, err := p.parseMultiplier(item)
+		if err != nil {
+			return nil, err
+		}
+
+		multipliers = append(multipliers, m)
+	}
+
+	return multipliers, nil
+}
+
+func (p *tariffParser) parseMultiplier(node *yaml.Node) (multiplier.Multiplier, error) {
+	if node.Kind != yaml.MappingNode {
+		return multiplier.Multiplier{}, p.errorf(node, "multiplier must be a mapping")
+	}
+
+	var (
+		m         multiplier.Multiplier
+		hasFactor bool
+	)
+	for i := 0; i < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		switch key.Value {
+		case "weightClass":
+			class, err := p.scalar(value, "weightClass")
+			if err != nil {
+				return multiplier.Multiplier{}, err
+			}
+			m.WeightClass = models.WeightClass(class)
+		case "emissionClass":
+			class, err := p.scalar(value, "emissionClass")
+			if err != nil {
+				return multiplier.Multiplier{}, err
+			}
+			m.EmissionClass = models.EmissionClass(class)
+		case "factor":
+			factor, err := strconv.ParseFloat(value.Value, 64)
+			if value.Kind != yaml.ScalarNode || err != nil {
+				return multiplier.Multiplier{}, p.errorf(value, "factor %q must be a number", value.Value)
+			}
+			m.Factor = factor
+			hasFactor = true
+		default:
+			return multiplier.Multiplier{}, p.errorf(key, "unknown field %q", key.Value)
+		}
+	}
+
+	if !hasFactor {
+		return multiplier.Multiplier{}, p.errorf(node, "missing multiplier factor")
+	}
+	if err := m.Validate(); err != nil {
+		return multiplier.Multiplier{}, p.errorf(node, "%v", err)
+	}
+
+	return m, nil
+}
+
+func (p *tariffParser) parseRounding(node *yaml.Node) (multiplier.Rounding, error) {
+	value, err := p.scalar(node, "rounding")
+	if err != nil {
+		return "", err
+	}
+
+	rounding, err := multiplier.ParseRounding(value)
+	if err != nil {
+		return "", p.errorf(node, "%v", err)
+	}
+
+	return rounding, nil
+}
+
+func formatMinutes(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
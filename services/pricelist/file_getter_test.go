@@ -0,0 +1,523 @@
+package pricelist
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"afry-toll-calculator/services/calendar"
+	"afry-toll-calculator/services/multiplier"
+	"afry-toll-calculator/services/window"
+)
+
+func TestNewFileGetter(t *testing.T) {
+	tests := []struct {
+		name            string
+		file            string
+		content         string
+		wantName        string
+		wantCurrency    string
+		want            []PriceListVersion
+		wantCalendar    []calendar.Rule
+		wantDailyCap    *int
+		wantWindow      time.Duration
+		wantStrategy    window.Strategy
+		wantMultipliers []multiplier.Multiplier
+		wantRounding    multiplier.Rounding
+		wantDedup       time.Duration
+		wantErrText     string
+		wantErrLine     int
+	}{
+		{
+			name: "yaml document with blocks",
+			file: "tariff.yaml",
+			content: `name: City
+currency: SEK
+blocks:
+  - start: "00:00"
+    price: 0
+  - start: "06:00"
+    price: 8
+  - start: "06:00"
+    price: 8
+`,
+			wantName:     "City",
+			wantCurrency: "SEK",
+			want: []PriceListVersion{{Blocks: []PriceBlock{
+				{Start: 0, Price: 0},
+				{Start: 360, Price: 8},
+			}}},
+		},
+		{
+			name: "json document with versions",
+			file: "tariff.json",
+			content: `{
+	"name": "City",
+	"currency": "SEK",
+	"versions": [
+		{"validTo": "2025-01-01", "blocks": [{"start": "06:00", "price": 8}]},
+		{"validFrom": "2025-01-01", "blocks": [{"start": "06:00", "price": 9}]}
+	]
+}`,
+			wantName:     "City",
+			wantCurrency: "SEK",
+			want: []PriceListVersion{
+				{ValidTo: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Blocks: []PriceBlock{{Start: 360, Price: 8}}},
+				{ValidFrom: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Blocks: []PriceBlock{{Start: 360, Price: 9}}},
+			},
+		},
+		{
+			name:        "malformed document",
+			file:        "tariff.yaml",
+			content:     "name: [",
+			wantErrText: "did not find expected node content",
+			wantErrLine: 1,
+		},
+		{
+			name:        "missing currency",
+			file:        "tariff.yaml",
+			content:     "name: City\nblocks:\n  - start: \"06:00\"\n    price: 8\n",
+			wantErrText: "missing tariff currency",
+			wantErrLine: 1,
+		},
+		{
+			name:        "unknown field",
+			file:        "tariff.yaml",
+			content:     "name: City\ncurrency: SEK\nprices: []\n",
+			wantErrText: `unknown field "prices"`,
+			wantErrLine: 3,
+		},
+		{
+			name: "start time out of range",
+			file: "tariff.yaml",
+			content: `name: City
+currency: SEK
+blocks:
+  - start: "24:00"
+    price: 8
+`,
+			wantErrText: `start "24:00" must be a time between 00:00 and 23:59 in HH:MM format`,
+			wantErrLine: 4,
+		},
+		{
+			name: "negative price",
+			file: "tariff.yaml",
+			content: `name: City
+currency: SEK
+blocks:
+  - start: "06:00"
+    price: -8
+`,
+			wantErrText: "price -8 must not be negative",
+			wantErrLine: 5,
+		},
+		{
+			name: "non numeric price",
+			file: "tariff.yaml",
+			content: `name: City
+currency: SEK
+blocks:
+  - start: "06:00"
+    price: 8.5
+`,
+			wantErrText: `price "8.5" must be a whole number`,
+			wantErrLine: 5,
+		},
+		{
+			name: "unsorted blocks",
+			file: "tariff.yaml",
+			content: `name: City
+currency: SEK
+blocks:
+  - start: "07:00"
+    price: 18
+  - start: "06:00"
+    price: 8
+`,
+			wantErrText: "block starting at 06:00 must be listed before block starting at 07:00",
+			wantErrLine: 6,
+		},
+		{
+			name: "duplicate start with different prices",
+			file: "tariff.yaml",
+			content: `name: City
+currency: SEK
+blocks:
+  - start: "06:00"
+    price: 8
+  - start: "06:00"
+    price: 13
+`,
+			wantErrText: "block starting at 06:00 is defined twice with different prices",
+			wantErrLine: 6,
+		},
+		{
+			name: "block without price",
+			file: "tariff.yaml",
+			content: `name: City
+currency: SEK
+blocks:
+  - start: "06:00"
+`,
+			wantErrText: "block is missing price",
+			wantErrLine: 4,
+		},
+		{
+			name: "invalid validity date",
+			file: "tariff.yaml",
+			content: `name: City
+currency: SEK
+versions:
+  - validFrom: 2025-13-01
+    blocks:
+      - start: "06:00"
+        price: 8
+`,
+			wantErrText: `validFrom "2025-13-01" is not a date in YYYY-MM-DD format`,
+			wantErrLine: 4,
+		},
+		{
+			name: "gap between versions",
+			file: "tariff.yaml",
+			content: `name: City
+currency: SEK
+versions:
+  - validTo: 2025-01-01
+    blocks:
+      - start: "06:00"
+        price: 8
+  - validFrom: 2025-02-01
+    blocks:
+      - start: "06:00"
+        price: 9
+`,
+			wantErrText: "price list has a gap between 2025-01-01T00:00:00Z and 2025-02-01T00:00:00Z",
+			wantErrLine: 4,
+		},
+		{
+			name: "versions that do not cover later passages",
+			file: "tariff.yaml",
+			content: `name: City
+currency: SEK
+versions:
+  - validTo: 2025-01-01
+    blocks:
+      - start: "06:00"
+        price: 8
+  - validFrom: 2025-01-01
+    validTo: 2026-01-01
+    blocks:
+      - start: "06:00"
+        price: 9
+`,
+			wantErrText: "price list ends at 2026-01-01T00:00:00Z, passages after it would not be priced",
+			wantErrLine: 8,
+		},
+		{
+			name: "overlapping versions out of order",
+			file: "tariff.yaml",
+			content: `name: City
+currency: SEK
+versions:
+  - validFrom: 2025-02-01
+    blocks:
+      - start: "06:00"
+        price: 9
+  - validTo: 2025-03-01
+    blocks:
+      - start: "06:00"
+        price: 8
+`,
+			wantErrText: "price list version valid from the beginning overlaps version valid from 2025-02-01T00:00:00Z",
+			wantErrLine: 8,
+		},
+		{
+			name: "calendar rules",
+			file: "tariff.yaml",
+			content: `name: Gothenburg
+currency: SEK
+blocks:
+  - start: "06:00"
+    price: 9
+calendar:
+  - weekend
+  - publicHoliday
+  - dayBeforePublicHoliday
+  - month: July
+  - month: 12
+  - dates: [2025-06-05, 2025-11-01]
+`,
+			wantName:     "Gothenburg",
+			wantCurrency: "SEK",
+			want:         []PriceListVersion{{Blocks: []PriceBlock{{Start: 360, Price: 9}}}},
+			wantCalendar: []calendar.Rule{
+				calendar.Weekend(),
+				calendar.PublicHoliday(),
+				calendar.DayBeforePublicHoliday(),
+				calendar.Month(time.July),
+				calendar.Month(time.December),
+				calendar.Dates("2025-06-05", "2025-11-01"),
+			},
+		},
+		{
+			name: "unknown calendar rule",
+			file: "tariff.yaml",
+			content: `name: City
+currency: SEK
+calendar:
+  - weekend
+  - easter
+`,
+			wantErrText: `unknown calendar rule "easter"`,
+			wantErrLine: 5,
+		},
+		{
+			name: "invalid exempt month",
+			file: "tariff.yaml",
+			content: `name: City
+currency: SEK
+calendar:
+  - month: 13
+`,
+			wantErrText: `month "13" must be a month name or a number between 1 and 12`,
+			wantErrLine: 4,
+		},
+		{
+			name: "invalid exempt date",
+			file: "tariff.yaml",
+			content: `name: City
+currency: SEK
+calendar:
+  - dates: [2025-02-30]
+`,
+			wantErrText: `date "2025-02-30" is not a date in YYYY-MM-DD format`,
+			wantErrLine: 4,
+		},
+		{
+			name:        "empty calendar",
+			file:        "tariff.yaml",
+			content:     "name: City\ncurrency: SEK\ncalendar: []\n",
+			wantErrText: "calendar must be a non-empty list",
+			wantErrLine: 3,
+		},
+		{
+			name: "window settings",
+			file: "tariff.yaml",
+			content: `name: City
+currency: SEK
+blocks:
+  - start: "06:00"
+    price: 9
+dailyCap: 90
+window: 30m
+windowStrategy: clockHour
+`,
+			wantName:     "City",
+			wantCurrency: "SEK",
+			want:         []PriceListVersion{{Blocks: []PriceBlock{{Start: 360, Price: 9}}}},
+			wantDailyCap: intPtr(90),
+			wantWindow:   30 * time.Minute,
+			wantStrategy: window.ClockHour,
+		},
+		{
+			name: "no daily cap",
+			file: "tariff.yaml",
+			content: `name: City
+currency: SEK
+blocks:
+  - start: "06:00"
+    price: 9
+dailyCap: none
+`,
+			wantName:     "City",
+			wantCurrency: "SEK",
+			want:         []PriceListVersion{{Blocks: []PriceBlock{{Start: 360, Price: 9}}}},
+			wantDailyCap: intPtr(0),
+		},
+		{
+			name:        "non positive daily cap",
+			file:        "tariff.yaml",
+			content:     "name: City\ncurrency: SEK\ndailyCap: 0\n",
+			wantErrText: `dailyCap "0" must be a positive whole number or none`,
+			wantErrLine: 3,
+		},
+		{
+			name:        "unknown window strategy",
+			file:        "tariff.yaml",
+			content:     "name: City\ncurrency: SEK\nwindowStrategy: daily\n",
+			wantErrText: `unknown window strategy "daily"`,
+			wantErrLine: 3,
+		},
+		{
+			name:        "window not dividing the day into clock buckets",
+			file:        "tariff.yaml",
+			content:     "name: City\ncurrency: SEK\nwindow: 7h\nwindowStrategy: clockHour\nblocks: []\n",
+			wantErrText: "window 7h0m0s must divide the day evenly for the clockHour strategy",
+			wantErrLine: 3,
+		},
+		{
+			name: "multipliers and rounding",
+			file: "tariff.yaml",
+			content: `name: City
+currency: SEK
+multipliers:
+  - weightClass: heavy
+    factor: 2
+  - weightClass: heavy
+    emissionClass: electric
+    factor: 0.5
+rounding: up
+blocks:
+  - start: "06:00"
+    price: 8
+`,
+			wantName:     "City",
+			wantCurrency: "SEK",
+			want:         []PriceListVersion{{Blocks: []PriceBlock{{Start: 360, Price: 8}}}},
+			wantMultipliers: []multiplier.Multiplier{
+				{WeightClass: "heavy", Factor: 2},
+				{WeightClass: "heavy", EmissionClass: "electric", Factor: 0.5},
+			},
+			wantRounding: multiplier.Up,
+		},
+		{
+			name:        "multiplier without a class",
+			file:        "tariff.yaml",
+			content:     "name: City\ncurrency: SEK\nmultipliers:\n  - factor: 2\nblocks: []\n",
+			wantErrText: "multiplier must select a weightClass, an emissionClass or both",
+			wantErrLine: 4,
+		},
+		{
+			name:        "multiplier without a factor",
+			file:        "tariff.yaml",
+			content:     "name: City\ncurrency: SEK\nmultipliers:\n  - weightClass: heavy\nblocks: []\n",
+			wantErrText: "missing multiplier factor",
+			wantErrLine: 4,
+		},
+		{
+			name:        "multiplier with an invalid factor",
+			file:        "tariff.yaml",
+			content:     "name: City\ncurrency: SEK\nmultipliers:\n  - weightClass: heavy\n    factor: twice\nblocks: []\n",
+			wantErrText: `factor "twice" must be a number`,
+			wantErrLine: 5,
+		},
+		{
+			name:        "unknown rounding",
+			file:        "tariff.yaml",
+			content:     "name: City\ncurrency: SEK\nrounding: bankers\nblocks: []\n",
+			wantErrText: `unknown rounding "bankers"`,
+			wantErrLine: 3,
+		},
+		{
+			name:         "dedup window",
+			file:         "tariff.yaml",
+			content:      "name: City\ncurrency: SEK\ndedupWindow: 10s\nblocks:\n  - start: \"06:00\"\n    price: 8\n",
+			wantName:     "City",
+			wantCurrency: "SEK",
+			want:         []PriceListVersion{{Blocks: []PriceBlock{{Start: 360, Price: 8}}}},
+			wantDedup:    10 * time.Second,
+		},
+		{
+			name:        "negative dedup window",
+			file:        "tariff.yaml",
+			content:     "name: City\ncurrency: SEK\ndedupWindow: -5s\nblocks: []\n",
+			wantErrText: `dedupWindow "-5s" must be a non-negative duration such as 10s`,
+			wantErrLine: 3,
+		},
+		{
+			name:        "both blocks and versions",
+			file:        "tariff.yaml",
+			content:     "name: City\ncurrency: SEK\nblocks: []\nversions: []\n",
+			wantErrText: "tariff must define either blocks or versions, not both",
+			wantErrLine: 4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := NewFileGetter(path, time.UTC)
+			if tt.wantErrText != "" {
+				var parseErr *ParseError
+				if !errors.As(err, &parseErr) {
+					t.Fatalf("NewFileGetter() error = %v, want *ParseError", err)
+				}
+				if parseErr.Line != tt.wantErrLine {
+					t.Errorf("NewFileGetter() error line = %v, want %v", parseErr.Line, tt.wantErrLine)
+				}
+				if parseErr.Err.Error() != tt.wantErrText {
+					t.Errorf("NewFileGetter() error = %v, wantErrText %v", parseErr.Err, tt.wantErrText)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewFileGetter() error = %v", err)
+			}
+			if got.Name() != tt.wantName || got.Currency() != tt.wantCurrency {
+				t.Errorf("NewFileGetter() metadata = %v %v, want %v %v", got.Name(), got.Currency(), tt.wantName, tt.wantCurrency)
+			}
+			if !reflect.DeepEqual(got.GetPriceListVersions(), tt.want) {
+				t.Errorf("GetPriceListVersions() = %v, want %v", got.GetPriceListVersions(), tt.want)
+			}
+			if !reflect.DeepEqual(got.Calendar(), tt.wantCalendar) {
+				t.Errorf("Calendar() = %v, want %v", got.Calendar(), tt.wantCalendar)
+			}
+			if !reflect.DeepEqual(got.DailyCap(), tt.wantDailyCap) || got.Window() != tt.wantWindow ||
+				got.WindowStrategy() != tt.wantStrategy {
+				t.Errorf("NewFileGetter() window settings = %v %v %v, want %v %v %v",
+					got.DailyCap(), got.Window(), got.WindowStrategy(), tt.wantDailyCap, tt.wantWindow, tt.wantStrategy)
+			}
+			if !reflect.DeepEqual(got.Multipliers(), tt.wantMultipliers) || got.Rounding() != tt.wantRounding {
+				t.Errorf("NewFileGetter() multipliers = %v %v, want %v %v",
+					got.Multipliers(), got.Rounding(), tt.wantMultipliers, tt.wantRounding)
+			}
+			if len(got.Version()) != 12 {
+				t.Errorf("Version() = %q, want 12 hex digits", got.Version())
+			}
+			if got.DedupWindow() != tt.wantDedup {
+				t.Errorf("DedupWindow() = %v, want %v", got.DedupWindow(), tt.wantDedup)
+			}
+		})
+	}
+}
+
+func TestNewFileGetter_sampleTariffMatchesHardcoded(t *testing.T) {
+	got, err := NewFileGetter("../../tariffs/stockholm.yaml", time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := (&HardcodedPriceBlocksGetter{}).GetPriceBlocks()
+	if !reflect.DeepEqual(got.GetPriceListVersions(), []PriceListVersion{{Blocks: want}}) {
+		t.Errorf("sample tariff = %v, want %v", got.GetPriceListVersions(), want)
+	}
+}
+
+func TestNewFileGetter_sampleTariffsAreValid(t *testing.T) {
+	paths, err := filepath.Glob("../../tariffs/*.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range paths {
+		if filepath.Base(path) == "zones.yaml" {
+			continue
+		}
+
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			if _, err := NewFileGetter(path, time.UTC); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func intPtr(i int) *int {
+	return &i
+}
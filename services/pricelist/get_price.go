@@ -15,10 +15,16 @@ import (
 //     which overlaps with the previous block and evaluates incorrectly due to operator precedence.
 //   - These issues were corrected in the `priceBlocks` slice by defining continuous, non-overlapping
 //     ranges.
+//
+// The entry time is converted to the service location first, so the same instant is priced the same
+// regardless of the offset it was submitted with. The price is taken from the price list version in force
+// at the entry time. The versions cover all entry times, so every entry has a price.
 func (s *svc) GetPrice(entry time.Time) int {
+	v := s.versionAt(entry)
+	entry = entry.In(s.location)
 	minutesFromMidnight := entry.Hour()*60 + entry.Minute()
 
-	return s.priceOfMinute[minutesFromMidnight]
+	return v.priceOfMinute[minutesFromMidnight]
 }
 
 // mustMinutes returns the number of minutes after midnight. If parsing fails, the function will panic.
@@ -83,9 +83,7 @@ func Test_svc_createPriceBlockLookup(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := &svc{
-				priceOfMinute: map[int]int{},
-			}
+			s := &svc{}
 			if got := s.createPriceBlockLookup(tt.priceBlocks); !tt.wantFn(got) {
 				t.Errorf("createPriceBlockLookup() = does not pass validation fn\ndata: %v", got)
 			}
@@ -214,7 +212,7 @@ func BenchmarkGetPrice(b *testing.B) {
 			},
 		}
 
-		service := New(getter)
+		service := New(getter, time.UTC)
 		someTime := time.Now()
 
 		b.ResetTimer() // We only care about the runtime performance of GetPrice
@@ -235,7 +233,7 @@ func BenchmarkGetPrice(b *testing.B) {
 			},
 		}
 
-		service := New(getter)
+		service := New(getter, time.UTC)
 
 		// Test different times, it doesn't matter if now + duration causes day overlap in this benchmark
 		times := []time.Time{
@@ -265,7 +263,7 @@ func BenchmarkGetPrice(b *testing.B) {
 		}
 
 		getter := &mockPriceBlockGetter{blocks: blocks}
-		service := New(getter)
+		service := New(getter, time.UTC)
 		someTime := time.Now()
 
 		b.ResetTimer()
@@ -287,7 +285,7 @@ func BenchmarkGetPrice_TenMillion(b *testing.B) {
 		},
 	}
 
-	service := New(getter)
+	service := New(getter, time.UTC)
 
 	someTime := time.Now()
 	b.Run("sequential 10M calls", func(b *testing.B) {
@@ -1,5 +1,7 @@
 package pricelist
 
+import "time"
+
 type PriceBlock struct {
 	Start int
 	Price int
@@ -10,3 +12,18 @@ type PriceBlock struct {
 type PriceBlockGetter interface {
 	GetPriceBlocks() []PriceBlock
 }
+
+// PriceListVersion is a list of price blocks in force from ValidFrom (inclusive) until ValidTo (exclusive).
+// A zero ValidFrom means the version applies to all passages before ValidTo, and a zero ValidTo means
+// the version applies until further notice.
+type PriceListVersion struct {
+	ValidFrom time.Time
+	ValidTo   time.Time
+	Blocks    []PriceBlock
+}
+
+// PriceListVersionGetter defines an interface for retrieving all versions of a price list, so historic
+// passages can be priced with the tariff that was in force at the time.
+type PriceListVersionGetter interface {
+	GetPriceListVersions() []PriceListVersion
+}
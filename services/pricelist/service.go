@@ -1,6 +1,9 @@
 package pricelist
 
 import (
+	"errors"
+	"fmt"
+	"sort"
 	"time"
 )
 
@@ -9,15 +12,110 @@ type Service interface {
 }
 
 type svc struct {
+	versions []version
+	location *time.Location
+}
+
+// version is a PriceListVersion with its price blocks converted to a minutes after midnight lookup.
+type version struct {
+	validFrom     time.Time
+	validTo       time.Time
 	priceOfMinute map[int]int
 }
 
-// New initializes and returns a new Service implementation using the provided PriceBlockGetter.
-func New(priceBlocksGetter PriceBlockGetter) Service {
+// New initializes and returns a new Service implementation using the provided PriceBlockGetter. Price block
+// start times are interpreted as wall clock time in the given location. The price blocks are in force for
+// all passages.
+func New(priceBlocksGetter PriceBlockGetter, location *time.Location) Service {
 	priceBlocks := priceBlocksGetter.GetPriceBlocks()
 
-	s := svc{}
-	s.priceOfMinute = s.createPriceBlockLookup(priceBlocks)
+	s := svc{location: location}
+	s.versions = []version{{priceOfMinute: s.createPriceBlockLookup(priceBlocks)}}
 
 	return &s
 }
+
+// NewVersioned initializes and returns a new Service implementation using the provided PriceListVersionGetter.
+// Returns an error if the versions overlap or leave a gap between them.
+func NewVersioned(versionGetter PriceListVersionGetter, location *time.Location) (Service, error) {
+	priceListVersions := versionGetter.GetPriceListVersions()
+	if _, err := validateVersions(priceListVersions); err != nil {
+		return nil, err
+	}
+
+	s := svc{location: location}
+	for _, v := range priceListVersions {
+		s.versions = append(s.versions, version{
+			validFrom:     v.ValidFrom,
+			validTo:       v.ValidTo,
+			priceOfMinute: s.createPriceBlockLookup(v.Blocks),
+		})
+	}
+
+	return &s, nil
+}
+
+// validateVersions sorts the versions by ValidFrom and ensures that each version ends exactly when the next
+// one starts. The first version must be open-ended in the past and the last one in the future, so every entry
+// time is covered by a version and no passage goes unpriced. On error,
+// it returns the index of the offending version after sorting, or -1 if there are no versions.
+func validateVersions(versions []PriceListVersion) (int, error) {
+	if len(versions) == 0 {
+		return -1, errors.New("price list has no versions")
+	}
+
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versions[i].ValidFrom.Before(versions[j].ValidFrom)
+	})
+	if len(versions) > 1 && versions[1].ValidFrom.IsZero() {
+		return 1, errors.New("only one price list version may be open-ended in the past")
+	}
+
+	for i, v := range versions {
+		if !v.ValidTo.IsZero() && !v.ValidTo.After(v.ValidFrom) {
+			return i, fmt.Errorf("price list version valid from %s ends before it starts", formatValidity(v.ValidFrom))
+		}
+		if i == len(versions)-1 {
+			break
+		}
+
+		next := versions[i+1]
+		switch {
+		case v.ValidTo.IsZero() || v.ValidTo.After(next.ValidFrom):
+			return i, fmt.Errorf("price list version valid from %s overlaps version valid from %s",
+				formatValidity(v.ValidFrom), formatValidity(next.ValidFrom))
+		case v.ValidTo.Before(next.ValidFrom):
+			return i, fmt.Errorf("price list has a gap between %s and %s",
+				formatValidity(v.ValidTo), formatValidity(next.ValidFrom))
+		}
+	}
+
+	if first := versions[0]; !first.ValidFrom.IsZero() {
+		return 0, fmt.Errorf("price list starts at %s, passages before it would not be priced",
+			formatValidity(first.ValidFrom))
+	}
+	if last := versions[len(versions)-1]; !last.ValidTo.IsZero() {
+		return len(versions) - 1, fmt.Errorf("price list ends at %s, passages after it would not be priced",
+			formatValidity(last.ValidTo))
+	}
+
+	return -1, nil
+}
+
+func formatValidity(t time.Time) string {
+	if t.IsZero() {
+		return "the beginning"
+	}
+
+	return t.Format(time.RFC3339)
+}
+
+// versionAt returns the version in force at the entry time. Versions are contiguous and the first one starts
+// at the beginning, so it is the last version starting at or before the entry time.
+func (s *svc) versionAt(entry time.Time) version {
+	i := sort.Search(len(s.versions), func(i int) bool {
+		return s.versions[i].validFrom.After(entry)
+	})
+
+	return s.versions[max(i-1, 0)]
+}
@@ -3,6 +3,7 @@ package pricelist_test
 import (
 	"testing"
 	"time"
+	_ "time/tzdata"
 
 	mock_pricelist "afry-toll-calculator/mocks/afry-toll-calculator/services/pricelist"
 	"afry-toll-calculator/services/pricelist"
@@ -36,7 +37,7 @@ func TestNew(t *testing.T) {
 			priceBlocksGetter := mock_pricelist.NewMockPriceBlockGetter(t)
 			tt.mocks(priceBlocksGetter)
 
-			s := pricelist.New(priceBlocksGetter)
+			s := pricelist.New(priceBlocksGetter, time.UTC)
 			for k, v := range tt.checkPrices {
 				if s.GetPrice(minutesFromMidnightToTime(k)) != v {
 					t.Errorf("price for %v minutes is %v, want %v", k, s.GetPrice(minutesFromMidnightToTime(k)), v)
@@ -45,3 +46,172 @@ func TestNew(t *testing.T) {
 		})
 	}
 }
+
+func TestGetPrice_normalisesLocation(t *testing.T) {
+	stockholm, err := time.LoadLocation("Europe/Stockholm")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := pricelist.New(&pricelist.HardcodedPriceBlocksGetter{}, stockholm)
+
+	tests := []struct {
+		name  string
+		entry time.Time
+		want  int
+	}{
+		{
+			name:  "UTC entry is priced by Stockholm wall clock in winter",
+			entry: time.Date(2025, 12, 5, 6, 30, 0, 0, time.UTC), // 07:30 CET
+			want:  18,
+		},
+		{
+			name:  "UTC entry is priced by Stockholm wall clock in summer",
+			entry: time.Date(2025, 6, 5, 6, 30, 0, 0, time.UTC), // 08:30 CEST
+			want:  8,
+		},
+		{
+			name:  "entry with a foreign offset is priced by Stockholm wall clock",
+			entry: time.Date(2025, 12, 5, 1, 30, 0, 0, time.FixedZone("EST", -5*60*60)), // 07:30 CET
+			want:  18,
+		},
+		{
+			name:  "entry already in Stockholm time is priced as is",
+			entry: time.Date(2025, 12, 5, 6, 30, 0, 0, stockholm),
+			want:  13,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.GetPrice(tt.entry); got != tt.want {
+				t.Errorf("GetPrice() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewVersioned(t *testing.T) {
+	jan := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	mar := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	apr := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	blocks := func(price int) []pricelist.PriceBlock {
+		return []pricelist.PriceBlock{{Start: 0, Price: price}}
+	}
+
+	tests := []struct {
+		name        string
+		versions    []pricelist.PriceListVersion
+		checkPrices map[time.Time]int
+		wantErr     bool
+		wantErrText string
+	}{
+		{
+			name: "price is taken from the version in force at the entry time",
+			versions: []pricelist.PriceListVersion{
+				{ValidFrom: mar, Blocks: blocks(30)},
+				{ValidTo: feb, Blocks: blocks(10)},
+				{ValidFrom: feb, ValidTo: mar, Blocks: blocks(20)},
+			},
+			checkPrices: map[time.Time]int{
+				jan.Add(-time.Hour * 24 * 365): 10,
+				feb.Add(-time.Minute):          10,
+				feb:                            20,
+				mar.Add(-time.Minute):          20,
+				mar:                            30,
+				apr.Add(time.Hour * 24 * 365):  30,
+			},
+		},
+		{
+			name: "first version with a start leaves earlier passages unpriced",
+			versions: []pricelist.PriceListVersion{
+				{ValidFrom: feb, ValidTo: mar, Blocks: blocks(20)},
+				{ValidFrom: mar, Blocks: blocks(30)},
+			},
+			wantErr:     true,
+			wantErrText: "price list starts at 2025-02-01T00:00:00Z, passages before it would not be priced",
+		},
+		{
+			name: "last version with an end leaves later passages unpriced",
+			versions: []pricelist.PriceListVersion{
+				{ValidTo: feb, Blocks: blocks(10)},
+				{ValidFrom: feb, ValidTo: mar, Blocks: blocks(20)},
+			},
+			wantErr:     true,
+			wantErrText: "price list ends at 2025-03-01T00:00:00Z, passages after it would not be priced",
+		},
+		{
+			name:        "no versions",
+			versions:    []pricelist.PriceListVersion{},
+			wantErr:     true,
+			wantErrText: "price list has no versions",
+		},
+		{
+			name: "overlapping versions",
+			versions: []pricelist.PriceListVersion{
+				{ValidFrom: jan, ValidTo: mar, Blocks: blocks(10)},
+				{ValidFrom: feb, ValidTo: apr, Blocks: blocks(20)},
+			},
+			wantErr:     true,
+			wantErrText: "price list version valid from 2025-01-01T00:00:00Z overlaps version valid from 2025-02-01T00:00:00Z",
+		},
+		{
+			name: "open-ended version followed by another version",
+			versions: []pricelist.PriceListVersion{
+				{ValidFrom: jan, Blocks: blocks(10)},
+				{ValidFrom: feb, Blocks: blocks(20)},
+			},
+			wantErr:     true,
+			wantErrText: "price list version valid from 2025-01-01T00:00:00Z overlaps version valid from 2025-02-01T00:00:00Z",
+		},
+		{
+			name: "gap between versions",
+			versions: []pricelist.PriceListVersion{
+				{ValidFrom: jan, ValidTo: feb, Blocks: blocks(10)},
+				{ValidFrom: mar, ValidTo: apr, Blocks: blocks(20)},
+			},
+			wantErr:     true,
+			wantErrText: "price list has a gap between 2025-02-01T00:00:00Z and 2025-03-01T00:00:00Z",
+		},
+		{
+			name: "more than one version open-ended in the past",
+			versions: []pricelist.PriceListVersion{
+				{ValidTo: feb, Blocks: blocks(10)},
+				{ValidTo: mar, Blocks: blocks(20)},
+			},
+			wantErr:     true,
+			wantErrText: "only one price list version may be open-ended in the past",
+		},
+		{
+			name: "version ending before it starts",
+			versions: []pricelist.PriceListVersion{
+				{ValidFrom: feb, ValidTo: jan, Blocks: blocks(10)},
+			},
+			wantErr:     true,
+			wantErrText: "price list version valid from 2025-02-01T00:00:00Z ends before it starts",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			versionGetter := mock_pricelist.NewMockPriceListVersionGetter(t)
+			versionGetter.EXPECT().GetPriceListVersions().Return(tt.versions)
+
+			s, err := pricelist.NewVersioned(versionGetter, time.UTC)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewVersioned() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				if err.Error() != tt.wantErrText {
+					t.Errorf("NewVersioned() error = %v, wantErrText %v", err, tt.wantErrText)
+				}
+				return
+			}
+			for entry, want := range tt.checkPrices {
+				if got := s.GetPrice(entry); got != want {
+					t.Errorf("price at %v is %v, want %v", entry, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package vehiclelist
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"afry-toll-calculator/models"
+)
+
+// Ensure conformance to the interface
+var _ Getter = (*FileGetter)(nil)
+
+// FileGetter provides the vehicle list of a YAML or JSON vehicles document. It is safe for concurrent use.
+//
+// Every vehicle type may have a weight class and an emission class. Toll-free vehicle types may be limited to an
+// exemption period with dates that are interpreted as midnight in the billing location, exemptTo being exclusive:
+//
+//	vehicles:
+//	  - type: car
+//	    weightClass: light
+//	  - type: bus
+//	    weightClass: heavy
+//	    emissionClass: diesel
+//	  - type: electric-heavy
+//	    weightClass: heavy
+//	    emissionClass: electric
+//	    tollFree: true
+//	    exemptFrom: 2025-01-01
+//	    exemptTo: 2027-01-01
+type FileGetter struct {
+	path     string
+	location *time.Location
+	vehicles atomic.Pointer[[]models.Vehicle]
+}
+
+type vehicleConfig struct {
+	Type          models.VehicleType   `yaml:"type"`
+	TollFree      bool                 `yaml:"tollFree"`
+	WeightClass   models.WeightClass   `yaml:"weightClass"`
+	EmissionClass models.EmissionClass `yaml:"emissionClass"`
+	ExemptFrom    string               `yaml:"exemptFrom"`
+	ExemptTo      string               `yaml:"exemptTo"`
+}
+
+// NewFileGetter reads and validates the vehicles document at path.
+func NewFileGetter(path string, location *time.Location) (*FileGetter, error) {
+	g := &FileGetter{path: path, location: location}
+	if err := g.Reload(); err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+// GetVehicleList returns the vehicle list of the last successfully read vehicles document.
+func (g *FileGetter) GetVehicleList() []models.Vehicle {
+	vehicles := *g.vehicles.Load()
+	out := make([]models.Vehicle, len(vehicles))
+	copy(out, vehicles)
+
+	return out
+}
+
+// Reload reads the vehicles document again. If it is invalid, the previous vehicle list is kept and the error
+// is returned.
+func (g *FileGetter) Reload() error {
+	_, commit, err := g.PrepareReload()
+	if err != nil {
+		return err
+	}
+	commit()
+
+	return nil
+}
+
+// PrepareReload reads and validates the vehicles document again without swapping it in. It returns the new vehicle
+// list and a function that swaps it in.
+func (g *FileGetter) PrepareReload() ([]models.Vehicle, func(), error) {
+	data, err := os.ReadFile(g.path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var doc struct {
+		Vehicles []vehicleConfig `yaml:"vehicles"`
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", g.path, err)
+	}
+
+	vehicles, err := g.parse(doc.Vehicles)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", g.path, err)
+	}
+
+	return vehicles, func() { g.vehicles.Store(&vehicles) }, nil
+}
+
+func (g *FileGetter) parse(configs []vehicleConfig) ([]models.Vehicle, error) {
+	if len(configs) == 0 {
+		return nil, errors.New("no vehicles defined")
+	}
+
+	seen := map[models.VehicleType]bool{}
+	vehicles := make([]models.Vehicle, 0, len(configs))
+	for _, c := range configs {
+		if c.Type == "" {
+			return nil, errors.New("vehicle without a type")
+		}
+		if seen[c.Type] {
+			return nil, fmt.Errorf("vehicle type %q is defined twice", c.Type)
+		}
+		seen[c.Type] = true
+
+		exemption, err := g.parseExemption(c)
+		if err != nil {
+			return nil, fmt.Errorf("vehicle type %q: %w", c.Type, err)
+		}
+
+		vehicles = append(vehicles,
+			models.NewClassifiedVehicle(c.Type, c.TollFree, c.WeightClass, c.EmissionClass, exemption))
+	}
+
+	return vehicles, nil
+}
+
+func (g *FileGetter) parseExemption(c vehicleConfig) (models.Exemption, error) {
+	if !c.TollFree && (c.ExemptFrom != "" || c.ExemptTo != "") {
+		return models.Exemption{}, errors.New("exemption period of a vehicle type that is not toll-free")
+	}
+
+	var (
+		exemption models.Exemption
+		err       error
+	)
+	if c.ExemptFrom != "" {
+		if exemption.From, err = time.ParseInLocation(models.PUBLIC_HOLIDAY_DATE_FORMAT, c.ExemptFrom, g.location); err != nil {
+			return models.Exemption{}, fmt.Errorf("invalid exemptFrom %q, expected YYYY-MM-DD", c.ExemptFrom)
+		}
+	}
+	if c.ExemptTo != "" {
+		if exemption.To, err = time.ParseInLocation(models.PUBLIC_HOLIDAY_DATE_FORMAT, c.ExemptTo, g.location); err != nil {
+			return models.Exemption{}, fmt.Errorf("invalid exemptTo %q, expected YYYY-MM-DD", c.ExemptTo)
+		}
+	}
+	if !exemption.From.IsZero() && !exemption.To.IsZero() && !exemption.To.After(exemption.From) {
+		return models.Exemption{}, errors.New("exemptTo must be after exemptFrom")
+	}
+
+	return exemption, nil
+}
@@ -0,0 +1,137 @@
+package vehiclelist
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"afry-toll-calculator/models"
+)
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "vehicles.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestNewFileGetter(t *testing.T) {
+	stockholm, err := time.LoadLocation("Europe/Stockholm")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g, err := NewFileGetter(writeFile(t, `
+vehicles:
+  - type: bus
+    weightClass: heavy
+    emissionClass: diesel
+  - type: electric-heavy
+    weightClass: heavy
+    emissionClass: electric
+    tollFree: true
+    exemptFrom: 2025-01-01
+    exemptTo: 2027-01-01
+`), stockholm)
+	if err != nil {
+		t.Fatalf("NewFileGetter() error = %v", err)
+	}
+
+	vehicles := g.GetVehicleList()
+	if len(vehicles) != 2 {
+		t.Fatalf("GetVehicleList() got %d vehicles, want 2", len(vehicles))
+	}
+	bus, electric := vehicles[0], vehicles[1]
+	if bus.GetType() != "bus" || bus.GetWeightClass() != models.WeightClassHeavy || bus.IsTollFree() {
+		t.Errorf("GetVehicleList() bus = %+v", bus)
+	}
+	if electric.GetEmissionClass() != models.EmissionClassElectric || !electric.IsTollFree() {
+		t.Errorf("GetVehicleList() electric-heavy = %+v", electric)
+	}
+
+	tests := []struct {
+		at   time.Time
+		want bool
+	}{
+		{time.Date(2024, 12, 31, 23, 59, 0, 0, stockholm), false},
+		{time.Date(2025, 1, 1, 0, 0, 0, 0, stockholm), true},
+		{time.Date(2026, 12, 31, 23, 59, 0, 0, stockholm), true},
+		{time.Date(2027, 1, 1, 0, 0, 0, 0, stockholm), false},
+	}
+	for _, tt := range tests {
+		if got := electric.IsTollFreeAt(tt.at); got != tt.want {
+			t.Errorf("IsTollFreeAt(%v) = %v, want %v", tt.at, got, tt.want)
+		}
+	}
+}
+
+func TestNewFileGetter_invalidDocument(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "empty", content: `vehicles: []`},
+		{name: "unknown field", content: "vehicles:\n  - type: car\n    colour: red\n"},
+		{name: "missing type", content: "vehicles:\n  - weightClass: heavy\n"},
+		{name: "duplicate type", content: "vehicles:\n  - type: car\n  - type: car\n"},
+		{name: "invalid date", content: "vehicles:\n  - type: bus\n    tollFree: true\n    exemptFrom: 2025-13-01\n"},
+		{name: "exemption of a billable type", content: "vehicles:\n  - type: bus\n    exemptTo: 2025-01-01\n"},
+		{
+			name:    "empty exemption period",
+			content: "vehicles:\n  - type: bus\n    tollFree: true\n    exemptFrom: 2025-01-01\n    exemptTo: 2025-01-01\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewFileGetter(writeFile(t, tt.content), time.UTC); err == nil {
+				t.Error("NewFileGetter() expected an error")
+			}
+		})
+	}
+}
+
+func TestFileGetter_Reload(t *testing.T) {
+	path := writeFile(t, "vehicles:\n  - type: car\n")
+	g, err := NewFileGetter(path, time.UTC)
+	if err != nil {
+		t.Fatalf("NewFileGetter() error = %v", err)
+	}
+
+	// An invalid document leaves the previous vehicle list in place.
+	if err := os.WriteFile(path, []byte("vehicles: []\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Reload(); err == nil {
+		t.Fatal("Reload() expected an error")
+	}
+	if got := g.GetVehicleList(); len(got) != 1 || got[0].GetType() != "car" {
+		t.Errorf("GetVehicleList() after a failed reload got = %v", got)
+	}
+
+	if err := os.WriteFile(path, []byte("vehicles:\n  - type: car\n  - type: bus\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	vehicles, commit, err := g.PrepareReload()
+	if err != nil {
+		t.Fatalf("PrepareReload() error = %v", err)
+	}
+	if got := g.GetVehicleList(); len(vehicles) != 2 || len(got) != 1 {
+		t.Errorf("PrepareReload() got %d vehicles and swapped in %d, want 2 and 1", len(vehicles), len(got))
+	}
+	commit()
+	if got := g.GetVehicleList(); len(got) != 2 {
+		t.Errorf("GetVehicleList() after reload got %d vehicles, want 2", len(got))
+	}
+}
+
+func TestNewFileGetter_sampleVehiclesAreValid(t *testing.T) {
+	if _, err := NewFileGetter(filepath.Join("..", "..", "vehicles", "vehicles.yaml"), time.UTC); err != nil {
+		t.Errorf("NewFileGetter() error = %v", err)
+	}
+}
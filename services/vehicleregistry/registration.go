@@ -0,0 +1,89 @@
+package vehicleregistry
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"afry-toll-calculator/models"
+)
+
+// Registration is the registration of a vehicle under a registration number. It is valid from ValidFrom
+// (inclusive) until ValidTo (exclusive); a zero ValidTo means it is valid until further notice.
+type Registration struct {
+	Number      string             `json:"number"`
+	VehicleType models.VehicleType `json:"vehicleType"`
+	// TollFree exempts the individual vehicle, regardless of its type.
+	TollFree bool `json:"tollFree"`
+	// WeightClass and EmissionClass classify the individual vehicle. If empty, the classes of its vehicle type
+	// apply.
+	WeightClass   models.WeightClass   `json:"weightClass,omitempty"`
+	EmissionClass models.EmissionClass `json:"emissionClass,omitempty"`
+	// OwnerRef refers to the owner in an external system; it is never interpreted by the calculator.
+	OwnerRef  string    `json:"ownerRef"`
+	ValidFrom time.Time `json:"validFrom"`
+	ValidTo   time.Time `json:"validTo,omitzero"`
+}
+
+// Ensure conformance to the interface
+var _ models.Vehicle = Registration{}
+
+func (r Registration) GetType() models.VehicleType {
+	return r.VehicleType
+}
+
+func (r Registration) IsTollFree() bool {
+	return r.TollFree
+}
+
+// IsTollFreeAt reports whether the vehicle is exempt at t. The exemption of a registered vehicle lasts as long
+// as the registration.
+func (r Registration) IsTollFreeAt(t time.Time) bool {
+	return r.TollFree && r.IsValid(t)
+}
+
+func (r Registration) GetWeightClass() models.WeightClass {
+	return r.WeightClass
+}
+
+func (r Registration) GetEmissionClass() models.EmissionClass {
+	return r.EmissionClass
+}
+
+// IsValid reports whether the registration is valid at t.
+func (r Registration) IsValid(t time.Time) bool {
+	return !t.Before(r.ValidFrom) && (r.ValidTo.IsZero() || t.Before(r.ValidTo))
+}
+
+// Validate reports the first field of the registration that is missing or invalid.
+func (r Registration) Validate() error {
+	switch {
+	case Normalize(r.Number) == "":
+		return errors.New("missing registration number")
+	case r.VehicleType == "":
+		return errors.New("missing vehicle type")
+	case r.ValidFrom.IsZero():
+		return errors.New("missing validFrom")
+	case !r.ValidTo.IsZero() && !r.ValidTo.After(r.ValidFrom):
+		return errors.New("validTo must be after validFrom")
+	}
+
+	return nil
+}
+
+// overlaps reports whether the validity periods of two registrations have any time in common.
+func (r Registration) overlaps(other Registration) bool {
+	return (r.ValidTo.IsZero() || other.ValidFrom.Before(r.ValidTo)) &&
+		(other.ValidTo.IsZero() || r.ValidFrom.Before(other.ValidTo))
+}
+
+// Normalize returns a registration number in the form it is stored in: upper case, without spaces or hyphens,
+// so "abc 123" and "ABC-123" both become "ABC123".
+func Normalize(number string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' {
+			return -1
+		}
+		return r
+	}, strings.ToUpper(strings.TrimSpace(number)))
+}
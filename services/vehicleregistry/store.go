@@ -0,0 +1,103 @@
+package vehicleregistry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	ErrUnknownRegistration = errors.New("unknown registration number")
+	ErrNotValid            = errors.New("registration is not valid")
+)
+
+// Store resolves registration numbers to registered vehicles.
+type Store interface {
+	// Lookup returns the registration of a number that is valid at the given time. Returns
+	// ErrUnknownRegistration for numbers that were never registered and ErrNotValid for numbers that have no
+	// registration valid at that time.
+	Lookup(number string, at time.Time) (Registration, error)
+	// Reload reads the registrations from the underlying source again. If that fails, the previous
+	// registrations are kept.
+	Reload() error
+}
+
+// Ensure conformance to the interface
+var _ Store = (*FileStore)(nil)
+
+// FileStore is a Store that reads registrations from a JSON file into memory. It is safe for concurrent use.
+type FileStore struct {
+	path          string
+	registrations atomic.Pointer[map[string][]Registration]
+}
+
+// NewFileStore returns a FileStore with the registrations in the JSON file at path, an array of registrations.
+// A number may be registered several times as long as the validity periods do not overlap.
+func NewFileStore(path string) (*FileStore, error) {
+	s := &FileStore{path: path}
+	if err := s.Reload(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *FileStore) Reload() error {
+	commit, err := s.PrepareReload()
+	if err != nil {
+		return err
+	}
+	commit()
+
+	return nil
+}
+
+// PrepareReload reads and validates the registrations again without swapping them in, and returns a function that
+// swaps them in.
+func (s *FileStore) PrepareReload() (func(), error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, err
+	}
+
+	var registrations []Registration
+	if err := json.Unmarshal(data, &registrations); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", s.path, err)
+	}
+
+	byNumber := map[string][]Registration{}
+	for i, r := range registrations {
+		if err := r.Validate(); err != nil {
+			return nil, fmt.Errorf("%s: registration %d: %w", s.path, i, err)
+		}
+
+		r.Number = Normalize(r.Number)
+		for _, other := range byNumber[r.Number] {
+			if r.overlaps(other) {
+				return nil, fmt.Errorf("%s: registrations of %q have overlapping validity periods", s.path, r.Number)
+			}
+		}
+		byNumber[r.Number] = append(byNumber[r.Number], r)
+	}
+
+	return func() { s.registrations.Store(&byNumber) }, nil
+}
+
+func (s *FileStore) Lookup(number string, at time.Time) (Registration, error) {
+	number = Normalize(number)
+	registrations, ok := (*s.registrations.Load())[number]
+	if !ok {
+		return Registration{}, fmt.Errorf("%w: %q", ErrUnknownRegistration, number)
+	}
+
+	for _, r := range registrations {
+		if r.IsValid(at) {
+			return r, nil
+		}
+	}
+
+	return Registration{}, fmt.Errorf("%w: %q at %s", ErrNotValid, number, at.Format(time.RFC3339))
+}
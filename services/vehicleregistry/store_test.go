@@ -0,0 +1,124 @@
+package vehicleregistry
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const registrations = `[
+	{"number": "abc 123", "vehicleType": "car", "ownerRef": "c-1", "validFrom": "2020-01-01T00:00:00Z"},
+	{"number": "XYZ789", "vehicleType": "car", "ownerRef": "c-2", "validFrom": "2020-01-01T00:00:00Z",
+		"validTo": "2025-01-01T00:00:00Z"},
+	{"number": "XYZ-789", "vehicleType": "truck", "ownerRef": "c-3", "validFrom": "2025-01-01T00:00:00Z"}
+]`
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "registrations.json")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestFileStore_Lookup(t *testing.T) {
+	store, err := NewFileStore(writeFile(t, registrations))
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		number       string
+		at           time.Time
+		wantOwnerRef string
+		wantErr      error
+	}{
+		{name: "normalized number", number: "ABC-123", at: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), wantOwnerRef: "c-1"},
+		{name: "before re-registration", number: "XYZ789", at: time.Date(2024, 12, 31, 23, 0, 0, 0, time.UTC), wantOwnerRef: "c-2"},
+		{name: "after re-registration", number: "xyz789", at: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), wantOwnerRef: "c-3"},
+		{name: "before first registration", number: "ABC123", at: time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), wantErr: ErrNotValid},
+		{name: "unknown number", number: "QQQ999", at: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), wantErr: ErrUnknownRegistration},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := store.Lookup(tt.number, tt.at)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Lookup() error = %v, want %v", err, tt.wantErr)
+			}
+			if got.OwnerRef != tt.wantOwnerRef {
+				t.Errorf("Lookup() ownerRef = %q, want %q", got.OwnerRef, tt.wantOwnerRef)
+			}
+		})
+	}
+}
+
+func TestNewFileStore_invalidFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "corrupt", content: `[{"number":`},
+		{name: "missing vehicle type", content: `[{"number": "ABC123", "validFrom": "2020-01-01T00:00:00Z"}]`},
+		{name: "missing validFrom", content: `[{"number": "ABC123", "vehicleType": "car"}]`},
+		{
+			name: "empty validity period",
+			content: `[{"number": "ABC123", "vehicleType": "car", "validFrom": "2020-01-01T00:00:00Z",
+				"validTo": "2020-01-01T00:00:00Z"}]`,
+		},
+		{
+			name: "overlapping validity periods",
+			content: `[
+				{"number": "ABC123", "vehicleType": "car", "validFrom": "2020-01-01T00:00:00Z", "validTo": "2025-01-01T00:00:00Z"},
+				{"number": "ABC 123", "vehicleType": "car", "validFrom": "2024-01-01T00:00:00Z"}
+			]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewFileStore(writeFile(t, tt.content)); err == nil {
+				t.Error("NewFileStore() expected an error")
+			}
+		})
+	}
+}
+
+func TestFileStore_Reload(t *testing.T) {
+	path := writeFile(t, registrations)
+	store, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+	at := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// A broken file leaves the previous registrations in place.
+	if err := os.WriteFile(path, []byte(`[`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Reload(); err == nil {
+		t.Fatal("Reload() expected an error")
+	}
+	if _, err := store.Lookup("ABC123", at); err != nil {
+		t.Errorf("Lookup() after a failed reload error = %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte(`[]`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	commit, err := store.PrepareReload()
+	if err != nil {
+		t.Fatalf("PrepareReload() error = %v", err)
+	}
+	if _, err := store.Lookup("ABC123", at); err != nil {
+		t.Errorf("Lookup() before commit error = %v", err)
+	}
+	commit()
+	if _, err := store.Lookup("ABC123", at); !errors.Is(err, ErrUnknownRegistration) {
+		t.Errorf("Lookup() after reload error = %v, want %v", err, ErrUnknownRegistration)
+	}
+}
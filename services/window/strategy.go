@@ -0,0 +1,171 @@
+package window
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Strategy selects how billable passages close to each other are combined into a single charge.
+type Strategy string
+
+const (
+	// FirstPassage lets the first passage open a window; every passage inside it is covered by one charge
+	// of the highest price in the window. The next passage after the window opens a new one.
+	FirstPassage Strategy = "firstPassage"
+	// ClockHour divides the day into fixed buckets starting at midnight, such as clock hours, and charges
+	// the highest price within each bucket.
+	ClockHour Strategy = "clockHour"
+	// SlidingHighest charges the highest priced passages first; a passage within the window length of an
+	// already charged passage is covered by it. No two charges are closer than the window length.
+	SlidingHighest Strategy = "slidingHighest"
+)
+
+// ParseStrategy returns the Strategy with the given name.
+func ParseStrategy(name string) (Strategy, error) {
+	switch s := Strategy(name); s {
+	case FirstPassage, ClockHour, SlidingHighest:
+		return s, nil
+	default:
+		return "", fmt.Errorf("unknown window strategy %q", name)
+	}
+}
+
+// Validate reports whether the strategy can be used with a window of the given length.
+func (s Strategy) Validate(length time.Duration) error {
+	if length <= 0 || length%time.Minute != 0 || length > 24*time.Hour {
+		return fmt.Errorf("window %v must be a whole number of minutes between 1m and 24h", length)
+	}
+	if s == ClockHour && (24*time.Hour)%length != 0 {
+		return fmt.Errorf("window %v must divide the day evenly for the %s strategy", length, s)
+	}
+
+	return nil
+}
+
+// Passage is a billable passage with its price.
+type Passage struct {
+	Time  time.Time
+	Price int
+}
+
+// Charge is a single fee covering one or more passages. Start and End delimit the window the charge covers.
+type Charge struct {
+	Start time.Time
+	End   time.Time
+	Price int
+}
+
+// Charges combines passages of a single day, sorted chronologically, into charges sorted by start time.
+func (s Strategy) Charges(passages []Passage, length time.Duration) []Charge {
+	switch s {
+	case ClockHour:
+		return clockHourCharges(passages, length)
+	case SlidingHighest:
+		return slidingHighestCharges(passages, length)
+	default:
+		return firstPassageCharges(passages, length)
+	}
+}
+
+func firstPassageCharges(passages []Passage, length time.Duration) []Charge {
+	charges := []Charge{}
+	for _, p := range passages {
+		last := len(charges) - 1
+		if last < 0 || charges[last].End.Before(p.Time.Add(time.Minute)) {
+			charges = append(charges, Charge{Start: p.Time, End: p.Time.Add(length), Price: p.Price})
+			continue
+		}
+
+		charges[last].Price = max(charges[last].Price, p.Price)
+	}
+
+	return charges
+}
+
+// clockHourCharges buckets passages by their wall clock time, so the buckets of a day on which daylight saving
+// time starts or ends follow the clock rather than elapsed time.
+func clockHourCharges(passages []Passage, length time.Duration) []Charge {
+	bucketMinutes := int(length / time.Minute)
+
+	charges := []Charge{}
+	lastBucket := -1
+	for _, p := range passages {
+		bucket := (p.Time.Hour()*60 + p.Time.Minute()) / bucketMinutes
+		if len(charges) == 0 || bucket != lastBucket {
+			y, m, d := p.Time.Date()
+			start := time.Date(y, m, d, 0, bucket*bucketMinutes, 0, 0, p.Time.Location())
+			charges = append(charges, Charge{Start: start, End: start.Add(length), Price: p.Price})
+			lastBucket = bucket
+			continue
+		}
+
+		last := len(charges) - 1
+		charges[last].Price = max(charges[last].Price, p.Price)
+	}
+
+	return charges
+}
+
+// slidingHighestCharges charges passages in order of descending price, earliest first on equal prices. A
+// passage less than the window length away from a charged passage is covered by that charge, which then
+// spans from the earliest to the latest passage it covers.
+func slidingHighestCharges(passages []Passage, length time.Duration) []Charge {
+	order := make([]int, len(passages))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return passages[order[i]].Price > passages[order[j]].Price
+	})
+
+	charged := []int{}
+	coveredBy := make([]int, len(passages))
+	for _, i := range order {
+		coveredBy[i] = -1
+		for _, c := range charged {
+			if absDuration(passages[i].Time.Sub(passages[c].Time)) < length {
+				coveredBy[i] = c
+				break
+			}
+		}
+		if coveredBy[i] == -1 {
+			coveredBy[i] = i
+			charged = append(charged, i)
+		}
+	}
+
+	byPassage := map[int]*Charge{}
+	for i, p := range passages {
+		c := coveredBy[i]
+		charge, ok := byPassage[c]
+		if !ok {
+			charge = &Charge{Start: p.Time, End: p.Time, Price: passages[c].Price}
+			byPassage[c] = charge
+		}
+		if p.Time.Before(charge.Start) {
+			charge.Start = p.Time
+		}
+		if p.Time.After(charge.End) {
+			charge.End = p.Time
+		}
+	}
+
+	charges := make([]Charge, 0, len(byPassage))
+	for _, charge := range byPassage {
+		charges = append(charges, *charge)
+	}
+	sort.Slice(charges, func(i, j int) bool {
+		return charges[i].Start.Before(charges[j].Start)
+	})
+
+	return charges
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+
+	return d
+}
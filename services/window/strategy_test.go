@@ -0,0 +1,142 @@
+package window
+
+import (
+	"reflect"
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func TestStrategy_Charges(t *testing.T) {
+	at := func(hour, min int) time.Time {
+		return time.Date(2025, 3, 4, hour, min, 0, 0, time.UTC)
+	}
+	passages := []Passage{
+		{Time: at(6, 20), Price: 8},
+		{Time: at(6, 50), Price: 13},
+		{Time: at(7, 10), Price: 18},
+		{Time: at(7, 40), Price: 18},
+		{Time: at(8, 15), Price: 13},
+	}
+
+	tests := []struct {
+		name     string
+		strategy Strategy
+		length   time.Duration
+		want     []Charge
+	}{
+		{
+			name:     "first passage opens the window",
+			strategy: FirstPassage,
+			length:   time.Hour,
+			want: []Charge{
+				{Start: at(6, 20), End: at(7, 20), Price: 18},
+				{Start: at(7, 40), End: at(8, 40), Price: 18},
+			},
+		},
+		{
+			name:     "clock hour buckets",
+			strategy: ClockHour,
+			length:   time.Hour,
+			want: []Charge{
+				{Start: at(6, 0), End: at(7, 0), Price: 13},
+				{Start: at(7, 0), End: at(8, 0), Price: 18},
+				{Start: at(8, 0), End: at(9, 0), Price: 13},
+			},
+		},
+		{
+			name:     "half hour buckets",
+			strategy: ClockHour,
+			length:   30 * time.Minute,
+			want: []Charge{
+				{Start: at(6, 0), End: at(6, 30), Price: 8},
+				{Start: at(6, 30), End: at(7, 0), Price: 13},
+				{Start: at(7, 0), End: at(7, 30), Price: 18},
+				{Start: at(7, 30), End: at(8, 0), Price: 18},
+				{Start: at(8, 0), End: at(8, 30), Price: 13},
+			},
+		},
+		{
+			name:     "sliding window charges the highest passages first",
+			strategy: SlidingHighest,
+			length:   time.Hour,
+			want: []Charge{
+				{Start: at(6, 20), End: at(7, 40), Price: 18},
+				{Start: at(8, 15), End: at(8, 15), Price: 13},
+			},
+		},
+		{
+			name:     "unset strategy falls back to the first passage",
+			strategy: "",
+			length:   time.Hour,
+			want: []Charge{
+				{Start: at(6, 20), End: at(7, 20), Price: 18},
+				{Start: at(7, 40), End: at(8, 40), Price: 18},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.strategy.Charges(passages, tt.length)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Charges() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStrategy_Charges_noPassages(t *testing.T) {
+	for _, strategy := range []Strategy{FirstPassage, ClockHour, SlidingHighest} {
+		if got := strategy.Charges(nil, time.Hour); len(got) != 0 {
+			t.Errorf("%s Charges() got = %v, want none", strategy, got)
+		}
+	}
+}
+
+func TestStrategy_Charges_clockHourFollowsWallClock(t *testing.T) {
+	stockholm, err := time.LoadLocation("Europe/Stockholm")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Daylight saving time ends at 03:00 on 2025-10-26, so 02:30 occurs twice.
+	first := time.Date(2025, 10, 26, 0, 30, 0, 0, time.UTC).In(stockholm)
+	second := time.Date(2025, 10, 26, 1, 30, 0, 0, time.UTC).In(stockholm)
+
+	got := ClockHour.Charges([]Passage{{Time: first, Price: 8}, {Time: second, Price: 13}}, time.Hour)
+	if len(got) != 1 || got[0].Price != 13 {
+		t.Errorf("Charges() got = %v, want a single charge of 13", got)
+	}
+}
+
+func TestParseStrategy(t *testing.T) {
+	for _, name := range []string{"firstPassage", "clockHour", "slidingHighest"} {
+		if got, err := ParseStrategy(name); err != nil || string(got) != name {
+			t.Errorf("ParseStrategy(%q) got = %v, %v", name, got, err)
+		}
+	}
+
+	if _, err := ParseStrategy("daily"); err == nil || err.Error() != `unknown window strategy "daily"` {
+		t.Errorf("ParseStrategy() error = %v", err)
+	}
+}
+
+func TestStrategy_Validate(t *testing.T) {
+	tests := []struct {
+		strategy    Strategy
+		length      time.Duration
+		wantErrText string
+	}{
+		{strategy: FirstPassage, length: 45 * time.Minute},
+		{strategy: ClockHour, length: 30 * time.Minute},
+		{strategy: FirstPassage, length: 0, wantErrText: "window 0s must be a whole number of minutes between 1m and 24h"},
+		{strategy: SlidingHighest, length: 90 * time.Second, wantErrText: "window 1m30s must be a whole number of minutes between 1m and 24h"},
+		{strategy: ClockHour, length: 7 * time.Hour, wantErrText: "window 7h0m0s must divide the day evenly for the clockHour strategy"},
+	}
+	for _, tt := range tests {
+		err := tt.strategy.Validate(tt.length)
+		if (err != nil) != (tt.wantErrText != "") || (err != nil && err.Error() != tt.wantErrText) {
+			t.Errorf("%s Validate(%v) error = %v, wantErrText %v", tt.strategy, tt.length, err, tt.wantErrText)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package zone
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config is a tariff zone of a deployment: the tariff document it is priced with and the gantries inside it.
+type Config struct {
+	Name       string   `yaml:"name"`
+	TariffFile string   `yaml:"tariff"`
+	Gantries   []string `yaml:"gantries"`
+}
+
+// LoadConfig reads and validates a zones document listing the zones of a deployment:
+//
+//	zones:
+//	  - name: stockholm
+//	    tariff: stockholm.yaml
+//	    gantries: [STO-01, STO-02]
+//
+// Relative tariff paths are resolved against the directory of the zones document.
+func LoadConfig(path string) ([]Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc struct {
+		Zones []Config `yaml:"zones"`
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	if err := validateConfig(doc.Zones); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	for i, zone := range doc.Zones {
+		if !filepath.IsAbs(zone.TariffFile) {
+			doc.Zones[i].TariffFile = filepath.Join(filepath.Dir(path), zone.TariffFile)
+		}
+	}
+
+	return doc.Zones, nil
+}
+
+func validateConfig(zones []Config) error {
+	if len(zones) == 0 {
+		return errors.New("no zones defined")
+	}
+
+	names := map[string]bool{}
+	gantries := map[string]string{}
+	for _, zone := range zones {
+		if zone.Name == "" {
+			return errors.New("zone without a name")
+		}
+		if names[zone.Name] {
+			return fmt.Errorf("zone %q is defined twice", zone.Name)
+		}
+		names[zone.Name] = true
+
+		if zone.TariffFile == "" {
+			return fmt.Errorf("zone %q has no tariff", zone.Name)
+		}
+
+		for _, gantry := range zone.Gantries {
+			if other, ok := gantries[gantry]; ok {
+				return fmt.Errorf("gantry %q belongs to both zone %q and zone %q", gantry, other, zone.Name)
+			}
+			gantries[gantry] = zone.Name
+		}
+	}
+
+	return nil
+}
+
+// GantryZones returns the mapping of gantry IDs to zone names defined by zones.
+func GantryZones(zones []Config) map[string]string {
+	gantryZones := map[string]string{}
+	for _, zone := range zones {
+		for _, gantry := range zone.Gantries {
+			gantryZones[gantry] = zone.Name
+		}
+	}
+
+	return gantryZones
+}
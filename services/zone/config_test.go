@@ -0,0 +1,118 @@
+package zone
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		want        []Config
+		wantErrText string
+	}{
+		{
+			name: "zones with relative and absolute tariff paths",
+			content: `zones:
+  - name: stockholm
+    tariff: stockholm.yaml
+    gantries: [STO-01, STO-02]
+  - name: gothenburg
+    tariff: /etc/tariffs/gothenburg.yaml
+    gantries: [GBG-01]
+`,
+			want: []Config{
+				{Name: "stockholm", TariffFile: "DIR/stockholm.yaml", Gantries: []string{"STO-01", "STO-02"}},
+				{Name: "gothenburg", TariffFile: "/etc/tariffs/gothenburg.yaml", Gantries: []string{"GBG-01"}},
+			},
+		},
+		{
+			name:        "no zones",
+			content:     "zones: []\n",
+			wantErrText: "no zones defined",
+		},
+		{
+			name:        "unknown field",
+			content:     "zones:\n  - name: stockholm\n    tariff: stockholm.yaml\n    cap: 60\n",
+			wantErrText: "field cap not found",
+		},
+		{
+			name:        "duplicate zone",
+			content:     "zones:\n  - name: stockholm\n    tariff: a.yaml\n  - name: stockholm\n    tariff: b.yaml\n",
+			wantErrText: `zone "stockholm" is defined twice`,
+		},
+		{
+			name:        "zone without tariff",
+			content:     "zones:\n  - name: stockholm\n",
+			wantErrText: `zone "stockholm" has no tariff`,
+		},
+		{
+			name: "gantry in two zones",
+			content: `zones:
+  - name: stockholm
+    tariff: a.yaml
+    gantries: [X-01]
+  - name: gothenburg
+    tariff: b.yaml
+    gantries: [X-01]
+`,
+			wantErrText: `gantry "X-01" belongs to both zone "stockholm" and zone "gothenburg"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "zones.yaml")
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := LoadConfig(path)
+			if (err != nil) != (tt.wantErrText != "") {
+				t.Fatalf("LoadConfig() error = %v, wantErrText %v", err, tt.wantErrText)
+			}
+			if err != nil {
+				if !strings.Contains(err.Error(), tt.wantErrText) {
+					t.Errorf("LoadConfig() error = %v, wantErrText %v", err, tt.wantErrText)
+				}
+				return
+			}
+
+			for i := range tt.want {
+				tt.want[i].TariffFile = strings.Replace(tt.want[i].TariffFile, "DIR", dir, 1)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LoadConfig() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGantryZones(t *testing.T) {
+	got := GantryZones([]Config{
+		{Name: "stockholm", Gantries: []string{"STO-01", "STO-02"}},
+		{Name: "gothenburg", Gantries: []string{"GBG-01"}},
+	})
+
+	want := map[string]string{"STO-01": "stockholm", "STO-02": "stockholm", "GBG-01": "gothenburg"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GantryZones() got = %v, want %v", got, want)
+	}
+}
+
+func TestLoadConfig_sampleZones(t *testing.T) {
+	zones, err := LoadConfig("../../tariffs/zones.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, zone := range zones {
+		if _, err := os.Stat(zone.TariffFile); err != nil {
+			t.Errorf("zone %q: %v", zone.Name, err)
+		}
+	}
+}
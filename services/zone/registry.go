@@ -0,0 +1,28 @@
+package zone
+
+import "time"
+
+// Ensure conformance to the interface
+var _ Resolver = (*Registry)(nil)
+
+// Registry is a fixed mapping of gantries to zones.
+type Registry struct {
+	zones map[string]string
+}
+
+// NewRegistry returns a Registry for a mapping of gantry IDs to zone names.
+func NewRegistry(gantryZones map[string]string) *Registry {
+	zones := make(map[string]string, len(gantryZones))
+	for gantry, zone := range gantryZones {
+		zones[gantry] = zone
+	}
+
+	return &Registry{zones: zones}
+}
+
+// Zone returns the zone of a gantry. Gantries in a Registry belong to their zone at all times.
+func (r *Registry) Zone(gantry string, _ time.Time) (string, bool) {
+	zone, ok := r.zones[gantry]
+
+	return zone, ok
+}
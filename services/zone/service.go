@@ -0,0 +1,143 @@
+package zone
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"afry-toll-calculator/models"
+	"afry-toll-calculator/services/fee"
+)
+
+// ErrUnknownGantry is returned for passages at a gantry that does not belong to any zone.
+var ErrUnknownGantry = errors.New("unknown gantry")
+
+// Resolver maps gantries to the tariff zone they belong to at a point in time.
+type Resolver interface {
+	Zone(gantry string, at time.Time) (string, bool)
+}
+
+type Service interface {
+	// Resolver resolves gantries with the resolver the service was created with.
+	Resolver
+	GetFees(vehicleType models.VehicleType, passages []models.Passage) (Summary, error)
+	// ExplainPassages explains the fee of a vehicle's passages on a single day for every zone with at least one
+	// passage, each calculated with the tariff of the zone. Zones are returned sorted by name.
+	ExplainPassages(vehicle models.Vehicle, passages []models.Passage) ([]Explanation, error)
+	HasZone(name string) bool
+}
+
+// Summary holds the total fee for passages in any number of zones, together with the subtotal of each zone
+// that had at least one passage.
+type Summary struct {
+	Total     int       `json:"total"`
+	Zones     []ZoneFee `json:"zones"`
+	Collapsed int       `json:"collapsed,omitempty"`
+}
+
+// ZoneFee is the fee of a single zone, calculated with the zone's own tariff.
+type ZoneFee struct {
+	Zone      string       `json:"zone"`
+	Total     int          `json:"total"`
+	Days      []fee.DayFee `json:"days"`
+	Collapsed int          `json:"collapsed,omitempty"`
+}
+
+// Explanation is the fee explanation of a single zone, calculated with the zone's own tariff.
+type Explanation struct {
+	Zone string `json:"zone"`
+	fee.Explanation
+}
+
+// New initializes and returns a new Service implementation, which calculates the fees of each zone with the
+// fee service of that zone.
+func New(resolver Resolver, feeServices map[string]fee.Service) Service {
+	return &zoneService{
+		resolver:    resolver,
+		feeServices: feeServices,
+	}
+}
+
+type zoneService struct {
+	resolver    Resolver
+	feeServices map[string]fee.Service
+}
+
+// GetFees groups passages by the zone of their gantry and calculates the fees of every zone separately, so
+// each zone applies its own price list, daily cap and calendar. Zones are returned sorted by name.
+func (s *zoneService) GetFees(vehicleType models.VehicleType, passages []models.Passage) (Summary, error) {
+	zones, byZone, err := s.group(passages)
+	if err != nil {
+		return Summary{}, err
+	}
+
+	summary := Summary{Zones: []ZoneFee{}}
+	for _, zone := range zones {
+		fees, err := s.feeServices[zone].GetPassageFees(vehicleType, byZone[zone])
+		if err != nil {
+			return Summary{}, fmt.Errorf("zone %q: %w", zone, err)
+		}
+
+		summary.Total += fees.Total
+		summary.Collapsed += fees.Collapsed
+		summary.Zones = append(summary.Zones, ZoneFee{Zone: zone, Total: fees.Total, Days: fees.Days, Collapsed: fees.Collapsed})
+	}
+
+	return summary, nil
+}
+
+func (s *zoneService) ExplainPassages(vehicle models.Vehicle, passages []models.Passage) ([]Explanation, error) {
+	zones, byZone, err := s.group(passages)
+	if err != nil {
+		return nil, err
+	}
+
+	explanations := make([]Explanation, 0, len(zones))
+	for _, zone := range zones {
+		explanation, err := s.feeServices[zone].ExplainPassages(vehicle, byZone[zone])
+		if err != nil {
+			return nil, fmt.Errorf("zone %q: %w", zone, err)
+		}
+
+		explanations = append(explanations, Explanation{Zone: zone, Explanation: explanation})
+	}
+
+	return explanations, nil
+}
+
+// group groups passages by the zone of their gantry at the time of the passage, and returns the zones sorted by
+// name.
+func (s *zoneService) group(passages []models.Passage) ([]string, map[string][]models.Passage, error) {
+	byZone := map[string][]models.Passage{}
+	for _, p := range passages {
+		zone, ok := s.resolver.Zone(p.Gantry, p.Timestamp)
+		if !ok {
+			return nil, nil, fmt.Errorf("%w %q", ErrUnknownGantry, p.Gantry)
+		}
+		if _, ok := s.feeServices[zone]; !ok {
+			return nil, nil, fmt.Errorf("gantry %q belongs to zone %q, which has no tariff", p.Gantry, zone)
+		}
+
+		byZone[zone] = append(byZone[zone], p)
+	}
+
+	zones := make([]string, 0, len(byZone))
+	for zone := range byZone {
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+
+	return zones, byZone, nil
+}
+
+// HasZone reports whether a zone with the given name is configured.
+func (s *zoneService) HasZone(name string) bool {
+	_, ok := s.feeServices[name]
+
+	return ok
+}
+
+func (s *zoneService) Zone(gantry string, at time.Time) (string, bool) {
+	return s.resolver.Zone(gantry, at)
+}
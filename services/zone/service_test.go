@@ -0,0 +1,170 @@
+package zone_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	mock_fee "afry-toll-calculator/mocks/afry-toll-calculator/services/fee"
+	mock_zone "afry-toll-calculator/mocks/afry-toll-calculator/services/zone"
+	"afry-toll-calculator/models"
+	"afry-toll-calculator/services/fee"
+	"afry-toll-calculator/services/zone"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_zoneService_GetFees(t *testing.T) {
+	stockholmMorning := time.Date(2025, 3, 4, 7, 0, 0, 0, time.UTC)
+	stockholmEvening := time.Date(2025, 3, 4, 16, 0, 0, 0, time.UTC)
+	gothenburgNoon := time.Date(2025, 3, 4, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		mocks       func(resolver *mock_zone.MockResolver, stockholm, gothenburg *mock_fee.MockService)
+		passages    []models.Passage
+		want        zone.Summary
+		wantErr     error
+		wantErrText string
+	}{
+		{
+			name: "fees are calculated per zone and summed",
+			mocks: func(resolver *mock_zone.MockResolver, stockholm, gothenburg *mock_fee.MockService) {
+				resolver.EXPECT().Zone("STO-01", mock.Anything).Return("stockholm", true)
+				resolver.EXPECT().Zone("GBG-01", mock.Anything).Return("gothenburg", true)
+
+				stockholm.EXPECT().GetPassageFees(models.VehicleType("car"), []models.Passage{
+					{Gantry: "STO-01", Timestamp: stockholmMorning},
+					{Gantry: "STO-01", Timestamp: stockholmEvening},
+				}).
+					Return(fee.Summary{Total: 36, Days: []fee.DayFee{{Date: "2025-03-04", Fee: 36}}}, nil).Once()
+				gothenburg.EXPECT().GetPassageFees(models.VehicleType("car"), []models.Passage{{Gantry: "GBG-01", Timestamp: gothenburgNoon}}).
+					Return(fee.Summary{Total: 9, Days: []fee.DayFee{{Date: "2025-03-04", Fee: 9}}}, nil).Once()
+			},
+			passages: []models.Passage{
+				{Gantry: "STO-01", Timestamp: stockholmMorning},
+				{Gantry: "GBG-01", Timestamp: gothenburgNoon},
+				{Gantry: "STO-01", Timestamp: stockholmEvening},
+			},
+			want: zone.Summary{
+				Total: 45,
+				Zones: []zone.ZoneFee{
+					{Zone: "gothenburg", Total: 9, Days: []fee.DayFee{{Date: "2025-03-04", Fee: 9}}},
+					{Zone: "stockholm", Total: 36, Days: []fee.DayFee{{Date: "2025-03-04", Fee: 36}}},
+				},
+			},
+		},
+		{
+			name: "zones without passages are left out",
+			mocks: func(resolver *mock_zone.MockResolver, stockholm, gothenburg *mock_fee.MockService) {
+				resolver.EXPECT().Zone("STO-01", mock.Anything).Return("stockholm", true)
+
+				stockholm.EXPECT().GetPassageFees(models.VehicleType("car"), []models.Passage{{Gantry: "STO-01", Timestamp: stockholmMorning}}).
+					Return(fee.Summary{Total: 18, Days: []fee.DayFee{{Date: "2025-03-04", Fee: 18}}}, nil).Once()
+			},
+			passages: []models.Passage{
+				{Gantry: "STO-01", Timestamp: stockholmMorning},
+			},
+			want: zone.Summary{
+				Total: 18,
+				Zones: []zone.ZoneFee{
+					{Zone: "stockholm", Total: 18, Days: []fee.DayFee{{Date: "2025-03-04", Fee: 18}}},
+				},
+			},
+		},
+		{
+			name: "unknown gantry",
+			mocks: func(resolver *mock_zone.MockResolver, stockholm, gothenburg *mock_fee.MockService) {
+				resolver.EXPECT().Zone("XXX-01", mock.Anything).Return("", false)
+			},
+			passages: []models.Passage{
+				{Gantry: "XXX-01", Timestamp: stockholmMorning},
+			},
+			wantErr:     zone.ErrUnknownGantry,
+			wantErrText: `unknown gantry "XXX-01"`,
+		},
+		{
+			name: "gantry in a zone without tariff",
+			mocks: func(resolver *mock_zone.MockResolver, stockholm, gothenburg *mock_fee.MockService) {
+				resolver.EXPECT().Zone("MLM-01", mock.Anything).Return("malmo", true)
+			},
+			passages: []models.Passage{
+				{Gantry: "MLM-01", Timestamp: stockholmMorning},
+			},
+			wantErrText: `gantry "MLM-01" belongs to zone "malmo", which has no tariff`,
+		},
+		{
+			name: "fee calculation error names the zone",
+			mocks: func(resolver *mock_zone.MockResolver, stockholm, gothenburg *mock_fee.MockService) {
+				resolver.EXPECT().Zone("STO-01", mock.Anything).Return("stockholm", true)
+
+				stockholm.EXPECT().GetPassageFees(mock.Anything, mock.Anything).Return(fee.Summary{}, errors.New("some error")).Once()
+			},
+			passages: []models.Passage{
+				{Gantry: "STO-01", Timestamp: stockholmMorning},
+			},
+			wantErrText: `zone "stockholm": some error`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver := mock_zone.NewMockResolver(t)
+			stockholm := mock_fee.NewMockService(t)
+			gothenburg := mock_fee.NewMockService(t)
+			tt.mocks(resolver, stockholm, gothenburg)
+
+			s := zone.New(resolver, map[string]fee.Service{"stockholm": stockholm, "gothenburg": gothenburg})
+
+			got, err := s.GetFees("car", tt.passages)
+			if (err != nil) != (tt.wantErrText != "") {
+				t.Fatalf("GetFees() error = %v, wantErrText %v", err, tt.wantErrText)
+			}
+			if err != nil {
+				if err.Error() != tt.wantErrText {
+					t.Errorf("GetFees() error = %v, wantErrText %v", err, tt.wantErrText)
+				}
+				if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+					t.Errorf("GetFees() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetFees() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_zoneService_ExplainPassages(t *testing.T) {
+	morning := time.Date(2025, 3, 4, 7, 0, 0, 0, time.UTC)
+	noon := time.Date(2025, 3, 4, 12, 0, 0, 0, time.UTC)
+	vehicle := models.NewVehicle("car", false)
+
+	resolver := mock_zone.NewMockResolver(t)
+	resolver.EXPECT().Zone("STO-01", morning).Return("stockholm", true)
+	resolver.EXPECT().Zone("GBG-01", noon).Return("gothenburg", true)
+
+	stockholm := mock_fee.NewMockService(t)
+	stockholm.EXPECT().ExplainPassages(vehicle, []models.Passage{{Gantry: "STO-01", Timestamp: morning}}).
+		Return(fee.Explanation{Fee: 18}, nil).Once()
+	gothenburg := mock_fee.NewMockService(t)
+	gothenburg.EXPECT().ExplainPassages(vehicle, []models.Passage{{Gantry: "GBG-01", Timestamp: noon}}).
+		Return(fee.Explanation{Fee: 9}, nil).Once()
+
+	s := zone.New(resolver, map[string]fee.Service{"stockholm": stockholm, "gothenburg": gothenburg})
+
+	got, err := s.ExplainPassages(vehicle, []models.Passage{
+		{Gantry: "STO-01", Timestamp: morning},
+		{Gantry: "GBG-01", Timestamp: noon},
+	})
+	if err != nil {
+		t.Fatalf("ExplainPassages() error = %v", err)
+	}
+	want := []zone.Explanation{
+		{Zone: "gothenburg", Explanation: fee.Explanation{Fee: 9}},
+		{Zone: "stockholm", Explanation: fee.Explanation{Fee: 18}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ExplainPassages() got = %v, want %v", got, want)
+	}
+}